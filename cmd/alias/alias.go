@@ -0,0 +1,27 @@
+package alias
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdAlias returns the alias command group
+func NewCmdAlias() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Create command shortcuts",
+		Long: `Create, list, and delete command aliases.
+
+Aliases are stored in the config file and expanded before the command
+line is parsed. Use $1, $2, ... in an expansion to insert positional
+arguments; any remaining arguments are appended to the end.
+
+Expansions starting with "!" are run by the shell (sh -c), with the
+alias arguments available as $1, $2, ... and "$@".`,
+	}
+
+	cmd.AddCommand(newSetCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newDeleteCmd())
+
+	return cmd
+}
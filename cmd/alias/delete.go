@@ -0,0 +1,79 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	all bool
+}
+
+func newDeleteCmd() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a command alias",
+		Long:    `Delete a command alias from the config file.`,
+		Example: `  # Delete an alias
+  gf alias delete mine
+
+  # Delete all aliases
+  gf alias delete --all`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.all && len(args) == 0 {
+				return fmt.Errorf("specify an alias name or use --all")
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runDelete(opts, name)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Delete all aliases")
+
+	return cmd
+}
+
+func runDelete(opts *deleteOptions, name string) error {
+	var count int
+	var expansion string
+
+	err := config.Update(func(cfg *config.Config) error {
+		if opts.all {
+			count = len(cfg.Aliases)
+			cfg.Aliases = nil
+			return nil
+		}
+
+		var ok bool
+		expansion, ok = cfg.Aliases[name]
+		if !ok {
+			return fmt.Errorf("no such alias: %s", name)
+		}
+		delete(cfg.Aliases, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.all {
+		if count == 0 {
+			fmt.Println("No aliases configured")
+			return nil
+		}
+		fmt.Printf("✓ Deleted %d alias(es)\n", count)
+		return nil
+	}
+
+	fmt.Printf("✓ Deleted alias %s (was: %s)\n", name, expansion)
+	return nil
+}
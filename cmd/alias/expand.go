@@ -0,0 +1,131 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// placeholderRegex matches positional placeholders ($1, $2, ...) in expansions
+var placeholderRegex = regexp.MustCompile(`\$(\d+)`)
+
+// IsShell reports whether an expansion is a shell alias
+func IsShell(expansion string) bool {
+	return strings.HasPrefix(expansion, "!")
+}
+
+// Expand replaces a leading alias in args with its expansion.
+// It returns the expanded arguments and true if args[0] is an alias.
+// For shell aliases the result is an sh invocation: sh -c <script> -- args...
+func Expand(aliases map[string]string, args []string) ([]string, bool, error) {
+	if len(args) == 0 {
+		return args, false, nil
+	}
+
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, false, nil
+	}
+	rest := args[1:]
+
+	if IsShell(expansion) {
+		script := strings.TrimPrefix(expansion, "!")
+		return append([]string{"sh", "-c", script, "--"}, rest...), true, nil
+	}
+
+	// Split first so arguments substituted for $N stay single arguments
+	result, err := SplitArgs(expansion)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid alias %q: %w", args[0], err)
+	}
+
+	// Substitute $N placeholders, remembering which args were consumed
+	used := make(map[int]bool)
+	var missing int
+	for i, arg := range result {
+		result[i] = placeholderRegex.ReplaceAllStringFunc(arg, func(m string) string {
+			n, _ := strconv.Atoi(m[1:])
+			if n < 1 || n > len(rest) {
+				if n > missing {
+					missing = n
+				}
+				return m
+			}
+			used[n] = true
+			return rest[n-1]
+		})
+	}
+	if missing > 0 {
+		return nil, true, fmt.Errorf("alias %q requires at least %d argument(s)", args[0], missing)
+	}
+
+	for i, arg := range rest {
+		if !used[i+1] {
+			result = append(result, arg)
+		}
+	}
+
+	return result, true, nil
+}
+
+// SplitArgs splits a command line into arguments, honoring single quotes,
+// double quotes and backslash escapes like a POSIX shell (without expansion).
+func SplitArgs(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				current.WriteRune(runes[i])
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			}
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,187 @@
+package alias
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExpand(t *testing.T) {
+	aliases := map[string]string{
+		"mine":  "mr list --author @me --json",
+		"co":    "mr checkout $1",
+		"swap":  "mr create --source $2 --target $1",
+		"title": `mr create --title "quick fix"`,
+		"fix":   `mr create --title "fix: $1"`,
+		"ids":   `!gf mr list --json | jq -r ".[].localId"`,
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		want      []string
+		wantAlias bool
+		wantErr   bool
+	}{
+		{
+			name: "not an alias",
+			args: []string{"mr", "list"},
+			want: []string{"mr", "list"},
+		},
+		{
+			name: "empty args",
+			args: []string{},
+			want: []string{},
+		},
+		{
+			name:      "simple alias",
+			args:      []string{"mine"},
+			want:      []string{"mr", "list", "--author", "@me", "--json"},
+			wantAlias: true,
+		},
+		{
+			name:      "extra args appended",
+			args:      []string{"mine", "-L", "5"},
+			want:      []string{"mr", "list", "--author", "@me", "--json", "-L", "5"},
+			wantAlias: true,
+		},
+		{
+			name:      "placeholder",
+			args:      []string{"co", "42"},
+			want:      []string{"mr", "checkout", "42"},
+			wantAlias: true,
+		},
+		{
+			name:      "placeholder with extra args",
+			args:      []string{"co", "42", "--force"},
+			want:      []string{"mr", "checkout", "42", "--force"},
+			wantAlias: true,
+		},
+		{
+			name:      "placeholders out of order",
+			args:      []string{"swap", "main", "feature"},
+			want:      []string{"mr", "create", "--source", "feature", "--target", "main"},
+			wantAlias: true,
+		},
+		{
+			name:      "missing placeholder argument",
+			args:      []string{"swap", "main"},
+			wantAlias: true,
+			wantErr:   true,
+		},
+		{
+			name:      "quoted expansion",
+			args:      []string{"title"},
+			want:      []string{"mr", "create", "--title", "quick fix"},
+			wantAlias: true,
+		},
+		{
+			name:      "placeholder with spaces and quotes",
+			args:      []string{"co", `my "odd" branch`},
+			want:      []string{"mr", "checkout", `my "odd" branch`},
+			wantAlias: true,
+		},
+		{
+			name:      "placeholder inside quoted argument",
+			args:      []string{"fix", "login page"},
+			want:      []string{"mr", "create", "--title", "fix: login page"},
+			wantAlias: true,
+		},
+		{
+			name:      "shell alias",
+			args:      []string{"ids", "x"},
+			want:      []string{"sh", "-c", `gf mr list --json | jq -r ".[].localId"`, "--", "x"},
+			wantAlias: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isAlias, err := Expand(aliases, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expand(%v) should return error", tt.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expand(%v) unexpected error: %v", tt.args, err)
+			}
+			if isAlias != tt.wantAlias {
+				t.Errorf("Expand(%v) alias = %v, want %v", tt.args, isAlias, tt.wantAlias)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expand(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"mr list", []string{"mr", "list"}, false},
+		{"  mr   list  ", []string{"mr", "list"}, false},
+		{`mr create -t "a b"`, []string{"mr", "create", "-t", "a b"}, false},
+		{`mr create -t 'a "b"'`, []string{"mr", "create", "-t", `a "b"`}, false},
+		{`a\ b`, []string{"a b"}, false},
+		{`""`, []string{""}, false},
+		{`"unterminated`, nil, true},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := SplitArgs(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SplitArgs(%q) should return error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitArgs(%q) unexpected error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitArgs(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	root := &cobra.Command{Use: "gf"}
+	root.AddCommand(&cobra.Command{Use: "mr", Aliases: []string{"merge-request"}})
+	root.AddCommand(&cobra.Command{Use: "issue", Aliases: []string{"i"}})
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"mine", false},
+		{"co", false},
+		{"mr", true},
+		{"merge-request", true},
+		{"i", true},
+		{"help", true},
+		{"", true},
+		{"-x", true},
+		{"two words", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateName(root, tt.name)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateName(%q) should return error", tt.name)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateName(%q) unexpected error: %v", tt.name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	json bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List command aliases",
+		Long:    `List all configured command aliases.`,
+		Example: `  # List aliases
+  gf alias list
+
+  # Output as JSON
+  gf alias list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runList(opts *listOptions) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if opts.json {
+		aliases := cfg.Aliases
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+		data, err := json.MarshalIndent(aliases, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(cfg.Aliases) == 0 {
+		fmt.Println("No aliases configured")
+		fmt.Println("Use 'gf alias set <name> <expansion>' to add one")
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s: %s\n", name, cfg.Aliases[name])
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package alias
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/cmdutil"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/spf13/cobra"
+)
+
+type setOptions struct {
+	shell   bool
+	clobber bool
+}
+
+func newSetCmd() *cobra.Command {
+	opts := &setOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set <name> <expansion>",
+		Short: "Create a shortcut for a gf command",
+		Long: `Create a shortcut for a gf command.
+
+The expansion is a gf command line without the leading "gf". Quote it
+so your shell passes it as a single argument. Use $1, $2, ... to place
+arguments; arguments not referenced by a placeholder are appended.
+
+Prefix the expansion with "!" (or use --shell) to run it through sh,
+which allows pipes and other commands. Alias names cannot shadow
+built-in commands.`,
+		Example: `  # My open merge requests as JSON
+  gf alias set mine 'mr list --author @me --json'
+
+  # Positional placeholders
+  gf alias set co 'mr checkout $1'
+
+  # Shell alias with a pipe
+  gf alias set mr-ids --shell 'gf mr list --json | jq -r ".[].localId"'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(cmd, opts, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.shell, "shell", "s", false, "Run the expansion through sh")
+	cmd.Flags().BoolVar(&opts.clobber, "clobber", false, "Overwrite an existing alias")
+
+	return cmd
+}
+
+func runSet(cmd *cobra.Command, opts *setOptions, name, expansion string) error {
+	if err := validateName(cmd.Root(), name); err != nil {
+		return err
+	}
+
+	expansion = strings.TrimSpace(expansion)
+	if opts.shell && !IsShell(expansion) {
+		expansion = "!" + expansion
+	}
+	if expansion == "" || expansion == "!" {
+		return fmt.Errorf("expansion cannot be empty")
+	}
+
+	if !IsShell(expansion) {
+		if err := validateExpansion(cmd.Root(), expansion); err != nil {
+			return err
+		}
+	}
+
+	var exists bool
+	err := config.Update(func(cfg *config.Config) error {
+		var existing string
+		existing, exists = cfg.Aliases[name]
+		if exists && !opts.clobber {
+			return fmt.Errorf("alias %q already exists (%s)\nUse --clobber to overwrite", name, existing)
+		}
+		cfg.SetAlias(name, expansion)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		fmt.Printf("✓ Changed alias %s: %s\n", name, expansion)
+	} else {
+		fmt.Printf("✓ Added alias %s: %s\n", name, expansion)
+	}
+	return nil
+}
+
+// validateName checks that an alias name is usable and does not shadow a built-in command
+func validateName(root *cobra.Command, name string) error {
+	if name == "" {
+		return fmt.Errorf("alias name cannot be empty")
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("alias name cannot start with '-'")
+	}
+	if strings.ContainsAny(name, " \t\n\"'$!") {
+		return fmt.Errorf("invalid alias name: %q", name)
+	}
+	if cmdutil.IsBuiltin(root, name) {
+		return fmt.Errorf("%q is a built-in command and cannot be used as an alias", name)
+	}
+	return nil
+}
+
+// validateExpansion checks that a non-shell expansion starts with a gf command
+func validateExpansion(root *cobra.Command, expansion string) error {
+	args, err := SplitArgs(expansion)
+	if err != nil {
+		return fmt.Errorf("invalid expansion: %w", err)
+	}
+	if len(args) == 0 || !cmdutil.IsBuiltin(root, args[0]) {
+		return fmt.Errorf("expansion must start with a gf command, got %q\nUse --shell for shell commands", expansion)
+	}
+	return nil
+}
@@ -101,7 +101,7 @@ func runAPI(opts *apiOptions, endpoint string) error {
 		hostname = config.DefaultHost()
 	}
 
-	client := api.NewClient(config.BaseURL(hostname), token)
+	client := api.NewClientForHost(cfg, hostname, token)
 
 	// Build request body
 	var body any
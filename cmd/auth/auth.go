@@ -15,6 +15,8 @@ func NewCmdAuth() *cobra.Command {
 	cmd.AddCommand(newLoginCmd())
 	cmd.AddCommand(newLogoutCmd())
 	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newTokenCmd())
+	cmd.AddCommand(newRefreshCmd())
 
 	return cmd
 }
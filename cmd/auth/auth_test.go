@@ -2,6 +2,7 @@ package auth
 
 import (
 	"testing"
+	"time"
 )
 
 func TestAuthCmd_SubCommands(t *testing.T) {
@@ -12,6 +13,8 @@ func TestAuthCmd_SubCommands(t *testing.T) {
 		"login",
 		"logout",
 		"status",
+		"token",
+		"refresh",
 	}
 
 	for _, name := range subCommands {
@@ -63,3 +66,43 @@ func TestLoginCmd_Flags(t *testing.T) {
 		}
 	}
 }
+
+func TestParseExpiresAt(t *testing.T) {
+	got, err := parseExpiresAt("2026-12-31")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 12, 31, 23, 59, 59, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("parseExpiresAt(2026-12-31) = %v, want %v", got, want)
+	}
+
+	got, err = parseExpiresAt("2026-12-31T10:00:00Z")
+	if err != nil || !got.Equal(time.Date(2026, 12, 31, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseExpiresAt(RFC3339) = %v, %v", got, err)
+	}
+
+	if got, err := parseExpiresAt(""); got != nil || err != nil {
+		t.Errorf("parseExpiresAt(\"\") = %v, %v, want nil, nil", got, err)
+	}
+	if _, err := parseExpiresAt("31.12.2026"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"d3a84de8-7738-4018", "d3a8••••••••"},
+		{"short", "••••••••"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := maskToken(tt.token); got != tt.want {
+			t.Errorf("maskToken(%q) = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
@@ -3,9 +3,11 @@ package auth
 import (
 	"bufio"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -14,9 +16,11 @@ import (
 )
 
 type loginOptions struct {
-	hostname string
-	token    string
-	stdin    bool
+	hostname  string
+	token     string
+	stdin     bool
+	warmupURL string
+	expiresAt string
 }
 
 func newLoginCmd() *cobra.Command {
@@ -39,7 +43,13 @@ The token can be obtained from GitFlic settings:
   gf auth login --hostname git.company.com
 
   # Login from CI (read token from stdin)
-  echo $GF_TOKEN | gf auth login --stdin`,
+  echo $GF_TOKEN | gf auth login --stdin
+
+  # Login to a self-hosted instance behind DDoS-Guard
+  gf auth login --hostname git.company.com --warmup-url https://git.company.com/
+
+  # Record token expiry to get warnings in 'gf auth status'
+  gf auth login --expires-at 2026-12-31`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLogin(opts)
 		},
@@ -48,76 +58,141 @@ The token can be obtained from GitFlic settings:
 	cmd.Flags().StringVarP(&opts.hostname, "hostname", "H", config.DefaultHost(), "GitFlic hostname")
 	cmd.Flags().StringVarP(&opts.token, "token", "t", "", "Access token")
 	cmd.Flags().BoolVar(&opts.stdin, "stdin", false, "Read token from stdin")
+	cmd.Flags().StringVar(&opts.warmupURL, "warmup-url", "", "URL visited to obtain bot-protection cookies (for protected self-hosted instances)")
+	cmd.Flags().StringVar(&opts.expiresAt, "expires-at", "", "Token expiry date (YYYY-MM-DD), used for expiry warnings")
 
 	return cmd
 }
 
 func runLogin(opts *loginOptions) error {
+	expiresAt, err := parseExpiresAt(opts.expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if opts.warmupURL != "" {
+		if u, err := url.Parse(opts.warmupURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid warmup URL: %s", opts.warmupURL)
+		}
+	}
+
+	var token string
+	if opts.token != "" && !opts.stdin {
+		token = opts.token
+	} else {
+		if !opts.stdin {
+			fmt.Printf("GitFlic hostname: %s\n", opts.hostname)
+		}
+		token, err = readToken(opts.stdin, "Paste your access token: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Warn about internal/private hostnames (but allow for self-hosted instances)
+	if config.IsInternalHost(opts.hostname) {
+		fmt.Fprintf(os.Stderr, "Warning: %s resolves to an internal IP address\n", opts.hostname)
+	}
+
+	user, err := saveToken(opts.hostname, token, opts.warmupURL, expiresAt, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Logged in as %s to %s\n", user.Username, opts.hostname)
+	return nil
+}
+
+// readToken reads a token from stdin or, interactively, without echo
+func readToken(stdin bool, prompt string) (string, error) {
 	var token string
-	var err error
 
-	if opts.stdin {
+	if stdin {
 		// Read token from stdin
 		reader := bufio.NewReader(os.Stdin)
-		token, err = reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("failed to read token from stdin: %w", err)
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read token from stdin: %w", err)
 		}
-		token = strings.TrimSpace(token)
-	} else if opts.token != "" {
-		token = opts.token
+		token = line
 	} else {
-		// Interactive mode
-		fmt.Printf("GitFlic hostname: %s\n", opts.hostname)
-		fmt.Print("Paste your access token: ")
+		fmt.Print(prompt)
 
 		// Read password without echo
 		tokenBytes, err := term.ReadPassword(int(syscall.Stdin))
 		if err != nil {
-			return fmt.Errorf("failed to read token: %w", err)
+			return "", fmt.Errorf("failed to read token: %w", err)
 		}
 		fmt.Println() // newline after hidden input
 		token = string(tokenBytes)
 	}
 
+	token = strings.TrimSpace(token)
 	if token == "" {
-		return fmt.Errorf("token cannot be empty")
-	}
-
-	// Warn about internal/private hostnames (but allow for self-hosted instances)
-	if config.IsInternalHost(opts.hostname) {
-		fmt.Fprintf(os.Stderr, "Warning: %s resolves to an internal IP address\n", opts.hostname)
+		return "", fmt.Errorf("token cannot be empty")
 	}
+	return token, nil
+}
 
+// saveToken verifies token against hostname and stores it, making hostname
+// the active host if activate is set. An empty warmupURL keeps the one
+// already configured for the host.
+func saveToken(hostname, token, warmupURL string, expiresAt *time.Time, activate bool) (*api.User, error) {
 	// Verify token by calling /user/me
-	baseURL := config.BaseURL(opts.hostname)
-	client := api.NewClient(baseURL, token)
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	client := api.NewClientForHost(cfg, hostname, token)
+	if warmupURL != "" {
+		client.SetWarmupURL(warmupURL)
+	}
 
 	user, err := client.Users().Me()
 	if err != nil {
-		if api.IsUnauthorized(err) {
-			return fmt.Errorf("invalid token")
+		if api.IsUnauthorized(err) || api.IsTokenInvalid(err) {
+			return nil, fmt.Errorf("invalid token")
 		}
-		return fmt.Errorf("failed to verify token: %w", err)
+		return nil, fmt.Errorf("failed to verify token: %w", err)
 	}
 
 	// Save to config
-	cfg, err := config.Load()
+	err = config.Update(func(cfg *config.Config) error {
+		if existing := cfg.GetHost(hostname); existing != nil && warmupURL == "" {
+			warmupURL = existing.WarmupURL
+		}
+		cfg.SetHost(hostname, &config.Host{
+			Token:     token,
+			User:      user.Username,
+			Protocol:  "https",
+			WarmupURL: warmupURL,
+			ExpiresAt: expiresAt,
+		})
+		if activate {
+			cfg.ActiveHost = hostname
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to save config: %w", err)
 	}
 
-	cfg.SetHost(opts.hostname, &config.Host{
-		Token:    token,
-		User:     user.Username,
-		Protocol: "https",
-	})
-	cfg.ActiveHost = opts.hostname
+	return user, nil
+}
 
-	if err := config.Save(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
+// parseExpiresAt parses a token expiry date (YYYY-MM-DD or RFC 3339)
+func parseExpiresAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
 	}
-
-	fmt.Printf("✓ Logged in as %s to %s\n", user.Username, opts.hostname)
-	return nil
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry date %q: use YYYY-MM-DD", value)
+	}
+	// A token dated YYYY-MM-DD is usable through the end of that day
+	t = t.Add(24*time.Hour - time.Second)
+	return &t, nil
 }
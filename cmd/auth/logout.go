@@ -41,60 +41,57 @@ This will remove the stored token from the configuration file.`,
 }
 
 func runLogout(opts *logoutOptions) error {
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	if opts.all {
-		// Remove all hosts
-		count := len(cfg.Hosts)
-		if count == 0 {
-			fmt.Println("Not logged in to any hosts")
+	var hostname string
+	var count int
+
+	err := config.Update(func(cfg *config.Config) error {
+		if opts.all {
+			// Remove all hosts
+			count = len(cfg.Hosts)
+			cfg.Hosts = make(map[string]*config.Host)
+			cfg.ActiveHost = config.DefaultHost()
 			return nil
 		}
 
-		cfg.Hosts = make(map[string]*config.Host)
-		cfg.ActiveHost = config.DefaultHost()
+		// Determine which host to logout from
+		hostname = opts.hostname
+		if hostname == "" {
+			hostname = cfg.ActiveHost
+		}
+		if hostname == "" {
+			hostname = config.DefaultHost()
+		}
 
-		if err := config.Save(cfg); err != nil {
-			return fmt.Errorf("failed to save config: %w", err)
+		// Check if logged in
+		if cfg.GetHost(hostname) == nil {
+			return fmt.Errorf("not logged in to %s", hostname)
 		}
 
-		fmt.Printf("Logged out from %d host(s)\n", count)
+		// Remove the host
+		delete(cfg.Hosts, hostname)
+
+		// If this was the active host, set a new active host
+		if cfg.ActiveHost == hostname {
+			cfg.ActiveHost = config.DefaultHost()
+			// If we have other hosts, use one of them
+			for h := range cfg.Hosts {
+				cfg.ActiveHost = h
+				break
+			}
+		}
 		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Determine which host to logout from
-	hostname := opts.hostname
-	if hostname == "" {
-		hostname = cfg.ActiveHost
-	}
-	if hostname == "" {
-		hostname = config.DefaultHost()
-	}
-
-	// Check if logged in
-	host := cfg.GetHost(hostname)
-	if host == nil {
-		return fmt.Errorf("not logged in to %s", hostname)
-	}
-
-	// Remove the host
-	delete(cfg.Hosts, hostname)
-
-	// If this was the active host, set a new active host
-	if cfg.ActiveHost == hostname {
-		cfg.ActiveHost = config.DefaultHost()
-		// If we have other hosts, use one of them
-		for h := range cfg.Hosts {
-			cfg.ActiveHost = h
-			break
+	if opts.all {
+		if count == 0 {
+			fmt.Println("Not logged in to any hosts")
+			return nil
 		}
-	}
-
-	if err := config.Save(cfg); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
+		fmt.Printf("Logged out from %d host(s)\n", count)
+		return nil
 	}
 
 	fmt.Printf("Logged out of %s\n", hostname)
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/spf13/cobra"
+)
+
+type refreshOptions struct {
+	hostname  string
+	stdin     bool
+	expiresAt string
+}
+
+func newRefreshCmd() *cobra.Command {
+	opts := &refreshOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Replace the token for a host",
+		Long: `Replace the stored token for a host with a new one.
+
+GitFlic tokens cannot be rotated through the API. Create a new token in
+Profile Settings → API Tokens, then paste it here. The new token is
+verified before it replaces the old one; the host's other settings are kept.
+Revoke the old token in GitFlic afterwards.`,
+		Example: `  # Rotate the token of the active host
+  gf auth refresh
+
+  # Rotate and record the new expiry date
+  gf auth refresh --hostname git.company.com --expires-at 2027-06-30
+
+  # Non-interactive rotation
+  echo $NEW_TOKEN | gf auth refresh --stdin`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRefresh(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.hostname, "hostname", "H", "", "GitFlic hostname (default: active host)")
+	cmd.Flags().BoolVar(&opts.stdin, "stdin", false, "Read token from stdin")
+	cmd.Flags().StringVar(&opts.expiresAt, "expires-at", "", "New token expiry date (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func runRefresh(opts *refreshOptions) error {
+	expiresAt, err := parseExpiresAt(opts.expiresAt)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	hostname := opts.hostname
+	if hostname == "" {
+		hostname = cfg.ActiveHost
+	}
+	if hostname == "" {
+		hostname = config.DefaultHost()
+	}
+
+	host := cfg.GetHost(hostname)
+	if host == nil {
+		return fmt.Errorf("not logged in to %s. Run 'gf auth login -H %s'", hostname, hostname)
+	}
+
+	if !opts.stdin {
+		fmt.Printf("Refreshing token for %s (%s)\n", hostname, host.User)
+		fmt.Println("Create a new token in Profile Settings → API Tokens.")
+	}
+	token, err := readToken(opts.stdin, "Paste the new access token: ")
+	if err != nil {
+		return err
+	}
+	if token == host.Token {
+		return fmt.Errorf("new token is the same as the current one")
+	}
+
+	user, err := saveToken(hostname, token, "", expiresAt, false)
+	if err != nil {
+		return err
+	}
+
+	if user.Username != host.User && host.User != "" {
+		fmt.Printf("! Token belongs to %s (was %s)\n", user.Username, host.User)
+	}
+	fmt.Printf("✓ Token refreshed for %s on %s\n", user.Username, hostname)
+	fmt.Println("  Revoke the old token in GitFlic settings.")
+	return nil
+}
@@ -1,15 +1,42 @@
 package auth
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"time"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
 	"github.com/spf13/cobra"
 )
 
+// expiryWarning is how long before token expiry gf starts warning
+const expiryWarning = 14 * 24 * time.Hour
+
 type statusOptions struct {
 	hostname string
+	json     bool
+}
+
+// hostStatus is the result of checking a single host
+type hostStatus struct {
+	Host        string     `json:"host"`
+	Active      bool       `json:"active"`
+	Valid       bool       `json:"valid"`
+	Error       string     `json:"error,omitempty"`
+	User        string     `json:"user,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	Token       string     `json:"token"`
+	TokenSource string     `json:"token_source"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Expired     bool       `json:"expired,omitempty"`
+	ExpiresSoon bool       `json:"expires_soon,omitempty"`
+	Protected   bool       `json:"protected"`
+	Cookies     int        `json:"protection_cookies"`
+	CookiesExp  *time.Time `json:"protection_cookies_expire_at,omitempty"`
 }
 
 func newStatusCmd() *cobra.Command {
@@ -18,18 +45,28 @@ func newStatusCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "View authentication status",
-		Long:  `View authentication status for configured GitFlic hosts.`,
+		Long: `View authentication status for configured GitFlic hosts.
+
+Each token is verified against the API. The command warns when a token
+expires within 14 days (the expiry date is recorded with
+'gf auth login --expires-at') and exits with code 1 if any checked
+token is invalid or expired. GitFlic tokens carry the permissions of
+their owner; the API does not expose per-token scopes.`,
 		Example: `  # Check status for all hosts
   gf auth status
 
   # Check status for specific host
-  gf auth status --hostname git.company.com`,
+  gf auth status --hostname git.company.com
+
+  # Machine-readable output
+  gf auth status --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.hostname, "hostname", "H", "", "Check specific hostname")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
 
 	return cmd
 }
@@ -40,59 +77,188 @@ func runStatus(opts *statusOptions) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	if len(cfg.Hosts) == 0 {
+	activeHost := cfg.ActiveHost
+	if activeHost == "" {
+		activeHost = config.DefaultHost()
+	}
+	envToken := os.Getenv("GF_TOKEN")
+
+	// Collect hosts to check
+	var hostnames []string
+	if opts.hostname != "" {
+		if cfg.GetHost(opts.hostname) == nil && !(envToken != "" && opts.hostname == activeHost) {
+			if opts.json {
+				printStatusJSON([]hostStatus{{Host: opts.hostname, Error: "Not logged in"}})
+				return api.NewExitError(1)
+			}
+			fmt.Printf("%s\n  ✗ Not logged in\n", opts.hostname)
+			return api.NewExitError(1)
+		}
+		hostnames = []string{opts.hostname}
+	} else {
+		for hostname := range cfg.Hosts {
+			hostnames = append(hostnames, hostname)
+		}
+		if envToken != "" && cfg.GetHost(activeHost) == nil {
+			hostnames = append(hostnames, activeHost)
+		}
+		sort.Strings(hostnames)
+	}
+
+	if len(hostnames) == 0 {
+		if opts.json {
+			return printStatusJSON([]hostStatus{})
+		}
 		fmt.Println("Not logged in to any GitFlic hosts.")
 		fmt.Println("Run 'gf auth login' to authenticate.")
 		return nil
 	}
 
-	// If specific hostname requested, check only that
-	if opts.hostname != "" {
-		host := cfg.GetHost(opts.hostname)
+	var statuses []hostStatus
+	failed := false
+	for _, hostname := range hostnames {
+		host := cfg.GetHost(hostname)
 		if host == nil {
-			fmt.Printf("%s\n  ✗ Not logged in\n", opts.hostname)
-			return nil
+			host = &config.Host{}
+		}
+		token, source := host.Token, "config"
+		// GF_TOKEN overrides the config token for the active host
+		if envToken != "" && hostname == activeHost {
+			token, source = envToken, "GF_TOKEN"
+		}
+
+		st := checkHost(cfg, hostname, host, token)
+		st.Active = hostname == activeHost
+		st.TokenSource = source
+		if !st.Valid || st.Expired {
+			failed = true
 		}
-		return checkHost(opts.hostname, host)
+		statuses = append(statuses, st)
 	}
 
-	// Check all configured hosts
-	for hostname, host := range cfg.Hosts {
-		if err := checkHost(hostname, host); err != nil {
-			fmt.Printf("  ✗ Error: %s\n", err)
+	if opts.json {
+		if err := printStatusJSON(statuses); err != nil {
+			return err
+		}
+	} else {
+		for i, st := range statuses {
+			if i > 0 {
+				fmt.Println()
+			}
+			printHostStatus(st)
 		}
-		fmt.Println()
 	}
 
+	if failed {
+		return api.NewExitError(1)
+	}
 	return nil
 }
 
-func checkHost(hostname string, host *config.Host) error {
-	fmt.Println(hostname)
+// checkHost verifies token against hostname and collects token details
+func checkHost(cfg *config.Config, hostname string, host *config.Host, token string) hostStatus {
+	st := hostStatus{
+		Host:      hostname,
+		Token:     maskToken(token),
+		ExpiresAt: host.ExpiresAt,
+		Protected: cfg.WarmupURL(hostname) != "",
+	}
 
-	// Try to verify token
-	baseURL := config.BaseURL(hostname)
-	client := api.NewClient(baseURL, host.Token)
+	if host.ExpiresAt != nil {
+		remaining := time.Until(*host.ExpiresAt)
+		st.Expired = remaining <= 0
+		st.ExpiresSoon = !st.Expired && remaining < expiryWarning
+	}
 
+	// Try to verify token
+	client := api.NewClientForHost(cfg, hostname, token)
 	user, err := client.Users().Me()
 	if err != nil {
-		if api.IsUnauthorized(err) {
-			fmt.Println("  ✗ Token expired or invalid")
-			return nil
+		if api.IsUnauthorized(err) || api.IsTokenInvalid(err) {
+			st.Error = "Token expired or invalid"
+		} else {
+			st.Error = fmt.Sprintf("Could not verify: %s", err)
 		}
-		fmt.Printf("  ✗ Could not verify: %s\n", err)
-		return nil
+	} else {
+		st.Valid = true
+		st.User = user.Username
+		st.UserID = user.ID
+		st.Email = user.Email
 	}
 
-	fmt.Printf("  ✓ Logged in as %s\n", user.Username)
+	if store := client.CookieStore(); store != nil {
+		count, expires := store.Valid()
+		st.Cookies = count
+		if count > 0 && !expires.IsZero() {
+			st.CookiesExp = &expires
+		}
+	}
 
-	// Show masked token (only first 4 chars for security)
-	token := host.Token
-	if len(token) > 8 {
-		fmt.Printf("  ✓ Token: %s••••••••\n", token[:4])
-	} else if len(token) > 0 {
-		fmt.Printf("  ✓ Token: ••••••••\n")
+	return st
+}
+
+func printHostStatus(st hostStatus) {
+	if st.Active {
+		fmt.Printf("%s (active)\n", st.Host)
+	} else {
+		fmt.Println(st.Host)
 	}
 
+	if st.Valid {
+		fmt.Printf("  ✓ Logged in as %s\n", st.User)
+	} else {
+		fmt.Printf("  ✗ %s\n", st.Error)
+	}
+
+	if st.Token != "" {
+		if st.TokenSource == "GF_TOKEN" {
+			fmt.Printf("  ✓ Token: %s (from GF_TOKEN)\n", st.Token)
+		} else {
+			fmt.Printf("  ✓ Token: %s\n", st.Token)
+		}
+	}
+
+	if st.ExpiresAt != nil {
+		date := st.ExpiresAt.Local().Format("2006-01-02")
+		switch {
+		case st.Expired:
+			fmt.Printf("  ✗ Token expired on %s. Run 'gf auth refresh -H %s'\n", date, st.Host)
+		case st.ExpiresSoon:
+			days := int(time.Until(*st.ExpiresAt).Hours() / 24)
+			fmt.Printf("  ! Token expires in %d day(s) on %s. Run 'gf auth refresh -H %s'\n", days, date, st.Host)
+		default:
+			fmt.Printf("  ✓ Token expires on %s\n", date)
+		}
+	}
+
+	switch {
+	case st.Cookies == 0 && !st.Protected:
+		fmt.Println("  ✓ Protection cookies: not required (no warmup URL for this host)")
+	case st.Cookies == 0:
+		fmt.Printf("  ✗ Protection cookies: none valid; requests may be blocked. Check --warmup-url with 'gf auth login -H %s'\n", st.Host)
+	case st.CookiesExp == nil:
+		fmt.Printf("  ✓ Protection cookies: %d (session)\n", st.Cookies)
+	default:
+		fmt.Printf("  ✓ Protection cookies: %d, valid until %s\n", st.Cookies, st.CookiesExp.Local().Format("2006-01-02 15:04"))
+	}
+}
+
+func printStatusJSON(statuses []hostStatus) error {
+	data, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
 	return nil
 }
+
+// maskToken shows only the first 4 chars of a token for security
+func maskToken(token string) string {
+	if len(token) > 8 {
+		return token[:4] + "••••••••"
+	}
+	if len(token) > 0 {
+		return "••••••••"
+	}
+	return ""
+}
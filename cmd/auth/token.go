@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/spf13/cobra"
+)
+
+type tokenOptions struct {
+	hostname string
+}
+
+func newTokenCmd() *cobra.Command {
+	opts := &tokenOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Print the authentication token",
+		Long: `Print the authentication token gf uses for a host.
+
+The token is written to stdout without decoration, so it can be piped
+into other tools. GF_TOKEN takes precedence for the active host.`,
+		Example: `  # Use the token with curl
+  curl -H "Authorization: token $(gf auth token)" https://api.gitflic.ru/user/me
+
+  # Token for a specific host
+  gf auth token --hostname git.company.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runToken(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.hostname, "hostname", "H", "", "GitFlic hostname (default: active host)")
+
+	return cmd
+}
+
+func runToken(opts *tokenOptions) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	activeHost := cfg.ActiveHost
+	if activeHost == "" {
+		activeHost = config.DefaultHost()
+	}
+	hostname := opts.hostname
+	if hostname == "" {
+		hostname = activeHost
+	}
+
+	token := ""
+	if env := os.Getenv("GF_TOKEN"); env != "" && hostname == activeHost {
+		token = env
+	} else if host := cfg.GetHost(hostname); host != nil {
+		token = host.Token
+	}
+
+	if token == "" {
+		return fmt.Errorf("not logged in to %s. Run 'gf auth login -H %s'", hostname, hostname)
+	}
+
+	fmt.Println(token)
+	return nil
+}
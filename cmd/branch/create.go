@@ -79,7 +79,7 @@ func runCreate(opts *createOptions, name string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get ref (default branch if not specified)
 	ref := opts.ref
@@ -27,7 +27,7 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <name>",
+		Use:   "delete [<name>]",
 		Short: "Delete a branch",
 		Long: `Delete a branch from the repository.
 
@@ -44,9 +44,13 @@ does not support branch deletion.`,
 
   # Specify remote explicitly
   gf branch delete feature/old-feature --remote origin`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(opts, args[0])
+			name, err := branchNameArg(args, opts.repo)
+			if err != nil {
+				return err
+			}
+			return runDelete(opts, name)
 		},
 	}
 
@@ -75,7 +79,7 @@ func runDelete(opts *deleteOptions, name string) error {
 		if err == nil {
 			token, err := cfg.Token()
 			if err == nil {
-				client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+				client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 				branch, err := client.Branches().Get(repo.Owner, repo.Name, name)
 				if err != nil {
 					if api.IsNotFound(err) {
@@ -60,7 +60,7 @@ func runList(opts *listOptions) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch branches
 	branches, err := client.Branches().List(repo.Owner, repo.Name)
@@ -0,0 +1,87 @@
+package branch
+
+import (
+	"fmt"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
+)
+
+// branchNameArg returns the branch argument of a command. Without one,
+// the user picks one of the branches other than the default branch.
+func branchNameArg(args []string, repoFlag string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if !prompt.Enabled() {
+		return "", fmt.Errorf("branch name required when not running interactively")
+	}
+
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return "", fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return "", fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	branches, err := client.Branches().List(repo.Owner, repo.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var names []string
+	var items []prompt.Item
+	for _, b := range branches {
+		if b.IsDefault {
+			continue
+		}
+		label := b.Name
+		if b.Protected {
+			label += " (protected)"
+		}
+		if b.Merged {
+			label += " (merged)"
+		}
+
+		preview := ""
+		if c := b.LastCommit; c != nil {
+			message := c.ShortMessage
+			if message == "" {
+				message = c.Message
+			}
+			hash := c.Hash
+			if len(hash) > 7 {
+				hash = hash[:7]
+			}
+			preview = fmt.Sprintf("%s %s", hash, message)
+			if c.AuthorIdent != nil {
+				preview += fmt.Sprintf("\n%s · %s", c.AuthorIdent.Name, output.FormatRelativeTime(c.AuthorIdent.When))
+			}
+		}
+
+		names = append(names, b.Name)
+		items = append(items, prompt.Item{Label: label, Preview: preview})
+	}
+	if len(items) == 0 {
+		return "", fmt.Errorf("no branches other than the default branch in %s", repo.FullName())
+	}
+
+	i, err := prompt.Select("Select a branch", items)
+	if err != nil {
+		return "", err
+	}
+	return names[i], nil
+}
@@ -59,7 +59,7 @@ func runView(opts *viewOptions, hash string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get commit
 	commit, err := client.Commits().Get(repo.Owner, repo.Name, hash)
@@ -0,0 +1,33 @@
+package extension
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdExtension returns the extension command group
+func NewCmdExtension() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "extension",
+		Aliases: []string{"ext", "extensions"},
+		Short:   "Manage gf extensions",
+		Long: `Install, list, upgrade, and remove gf extensions.
+
+An extension is an executable named gf-<name>. Running "gf <name>"
+dispatches to it when <name> is not a built-in command. Extensions are
+looked up in the extensions directory of the gf config directory
+(~/.gf/extensions by default) first, then in PATH.
+
+Extensions receive the following environment variables:
+  GF_HOST      Host of the current repository, else the active host
+  GF_API_URL   API base URL for GF_HOST
+  GF_TOKEN     Access token for GF_HOST
+  GF_REPO      Current repository (owner/name), if detected`,
+	}
+
+	cmd.AddCommand(newInstallCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newUpgradeCmd())
+	cmd.AddCommand(newRemoveCmd())
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/cmdutil"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+func newInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install <repository|directory>",
+		Short: "Install a gf extension",
+		Long: `Install a gf extension from a git repository or a local directory.
+
+The repository or directory name must start with "gf-" and contain an
+executable with the same name. Local directories are linked, so changes
+take effect immediately. A repository can be given as a git URL or as
+owner/gf-name on the active GitFlic host.`,
+		Example: `  # Install from a git URL
+  gf extension install https://gitflic.ru/project/team/gf-deploy.git
+
+  # Install from the active GitFlic host
+  gf extension install team/gf-deploy
+
+  # Install from a local directory (for development)
+  gf extension install ./gf-deploy`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInstall(cmd, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runInstall(cmd *cobra.Command, source string) error {
+	m, err := NewManager()
+	if err != nil {
+		return err
+	}
+
+	isDir := false
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		isDir = true
+	}
+
+	var ext *Extension
+	if isDir {
+		ext, err = installChecked(cmd, source, m.InstallLocal)
+	} else {
+		ext, err = installChecked(cmd, resolveURL(source), m.InstallGit)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Installed extension %s\n", ext.Name)
+	fmt.Printf("  Run it with: gf %s\n", ext.Name)
+	return nil
+}
+
+// installChecked refuses extensions that would be shadowed by built-in commands
+func installChecked(cmd *cobra.Command, source string, install func(string) (*Extension, error)) (*Extension, error) {
+	name, err := nameFromSource(source)
+	if err != nil {
+		return nil, err
+	}
+	if cmdutil.IsBuiltin(cmd.Root(), name) {
+		return nil, fmt.Errorf("%q is a built-in command, extension gf-%s would never run", name, name)
+	}
+
+	ext, err := install(source)
+	if err != nil {
+		if err == ErrAlreadyExists {
+			return nil, fmt.Errorf("extension %s is already installed\nUse 'gf extension upgrade %s' to update it", name, name)
+		}
+		return nil, fmt.Errorf("failed to install extension: %w", err)
+	}
+	return ext, nil
+}
+
+// resolveURL expands owner/gf-name shorthand to a clone URL on the active host
+func resolveURL(source string) string {
+	if strings.Contains(source, "://") || strings.HasPrefix(source, "git@") {
+		return source
+	}
+
+	parts := strings.Split(source, "/")
+	if len(parts) != 2 || git.ValidateName(parts[0]) != nil || git.ValidateName(parts[1]) != nil {
+		return source
+	}
+
+	host := config.DefaultHost()
+	if cfg, err := config.Load(); err == nil && cfg.ActiveHost != "" {
+		host = cfg.ActiveHost
+	}
+	return fmt.Sprintf("https://%s/project/%s/%s.git", host, parts[0], parts[1])
+}
@@ -0,0 +1,81 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	json bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List installed extensions",
+		Long:    `List extensions installed in ~/.gf/extensions and gf-* executables found in PATH.`,
+		Example: `  # List extensions
+  gf extension list
+
+  # Output as JSON
+  gf extension list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runList(opts *listOptions) error {
+	m, err := NewManager()
+	if err != nil {
+		return err
+	}
+
+	exts, err := m.List()
+	if err != nil {
+		return fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	if opts.json {
+		if exts == nil {
+			exts = []Extension{}
+		}
+		data, err := json.MarshalIndent(exts, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(exts) == 0 {
+		fmt.Println("No extensions installed")
+		fmt.Println("Use 'gf extension install <repository>' to add one")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-6s %s\n", "NAME", "SOURCE", "LOCATION")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, e := range exts {
+		location := e.Path
+		if e.URL != "" {
+			location = e.URL
+		} else if e.Source == SourceLocal {
+			location = e.Dir
+		}
+		fmt.Printf("%-20s %-6s %s\n", "gf "+e.Name, e.Source, location)
+	}
+
+	return nil
+}
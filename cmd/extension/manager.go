@@ -0,0 +1,364 @@
+package extension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/config"
+)
+
+const (
+	// Prefix is the required prefix for extension executables and repositories
+	Prefix = "gf-"
+
+	extensionsDir = "extensions"
+
+	// gitTimeout is the timeout for clone/pull operations
+	gitTimeout = 5 * time.Minute
+)
+
+var (
+	ErrNotFound      = errors.New("extension not found")
+	ErrAlreadyExists = errors.New("extension already installed")
+	ErrInvalidName   = errors.New("extension name must start with " + Prefix)
+)
+
+// validNameRegex validates extension names (without the gf- prefix)
+var validNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][-a-zA-Z0-9_]*$`)
+
+// Source describes where an extension was found
+type Source string
+
+const (
+	SourceGit   Source = "git"   // cloned from a git URL into the extensions directory
+	SourceLocal Source = "local" // symlinked from a local directory
+	SourcePath  Source = "PATH"  // gf-* executable found in PATH
+)
+
+// Extension is an installed gf extension
+type Extension struct {
+	Name   string `json:"name"`   // command name, without the gf- prefix
+	Path   string `json:"path"`   // path to the executable
+	Dir    string `json:"dir"`    // installation directory (empty for PATH extensions)
+	Source Source `json:"source"` // git, local or PATH
+	URL    string `json:"url,omitempty"`
+}
+
+// Manager manages extensions in the extensions directory
+type Manager struct {
+	dir string
+}
+
+// NewManager returns a manager for ~/.gf/extensions
+func NewManager() (*Manager, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{dir: filepath.Join(dir, extensionsDir)}, nil
+}
+
+// NewManagerWithDir returns a manager for a custom extensions directory
+func NewManagerWithDir(dir string) *Manager {
+	return &Manager{dir: dir}
+}
+
+// Dir returns the extensions directory
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// ValidateName checks that name (with or without prefix) is a valid extension name
+func ValidateName(name string) error {
+	name = strings.TrimPrefix(name, Prefix)
+	if !validNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid extension name: %q", name)
+	}
+	return nil
+}
+
+// executableName returns the file name of the executable for an extension
+func executableName(name string) string {
+	if runtime.GOOS == "windows" {
+		return Prefix + name + ".exe"
+	}
+	return Prefix + name
+}
+
+// Find looks up an extension by command name.
+// Installed extensions take precedence over executables in PATH.
+func (m *Manager) Find(name string) (*Extension, error) {
+	if ValidateName(name) != nil {
+		return nil, ErrNotFound
+	}
+
+	if ext, err := m.installed(name); err == nil {
+		return ext, nil
+	}
+
+	path, err := exec.LookPath(executableName(name))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return &Extension{Name: name, Path: path, Source: SourcePath}, nil
+}
+
+// installed returns an extension from the extensions directory
+func (m *Manager) installed(name string) (*Extension, error) {
+	dir := filepath.Join(m.dir, Prefix+name)
+
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	ext := &Extension{Name: name, Dir: dir, Source: SourceGit}
+	if info.Mode()&os.ModeSymlink != 0 {
+		ext.Source = SourceLocal
+		if target, err := filepath.EvalSymlinks(dir); err == nil {
+			ext.Dir = target
+		}
+	}
+
+	exe := filepath.Join(ext.Dir, executableName(name))
+	if !isExecutable(exe) {
+		return nil, fmt.Errorf("extension %s has no %s executable", name, executableName(name))
+	}
+	ext.Path = exe
+
+	if ext.Source == SourceGit {
+		ext.URL = remoteURL(ext.Dir)
+	}
+	return ext, nil
+}
+
+// List returns installed extensions followed by gf-* executables in PATH
+func (m *Manager) List() ([]Extension, error) {
+	var exts []Extension
+	seen := make(map[string]bool)
+
+	entries, err := os.ReadDir(m.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), Prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(e.Name(), Prefix)
+		ext, err := m.installed(name)
+		if err != nil {
+			continue
+		}
+		exts = append(exts, *ext)
+		seen[name] = true
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := strings.TrimSuffix(e.Name(), ".exe")
+			if !strings.HasPrefix(name, Prefix) {
+				continue
+			}
+			name = strings.TrimPrefix(name, Prefix)
+			if seen[name] || ValidateName(name) != nil {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			exts = append(exts, Extension{Name: name, Path: path, Source: SourcePath})
+			seen[name] = true
+		}
+	}
+
+	sort.SliceStable(exts, func(i, j int) bool {
+		return exts[i].Name < exts[j].Name
+	})
+	return exts, nil
+}
+
+// InstallLocal installs an extension by symlinking a local directory.
+// The directory name must start with gf- and contain a matching executable.
+func (m *Manager) InstallLocal(dir string) (*Extension, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := nameFromBase(filepath.Base(abs))
+	if err != nil {
+		return nil, err
+	}
+
+	if !isExecutable(filepath.Join(abs, executableName(name))) {
+		return nil, fmt.Errorf("no executable %s found in %s", executableName(name), abs)
+	}
+
+	target := filepath.Join(m.dir, Prefix+name)
+	if _, err := os.Lstat(target); err == nil {
+		return nil, ErrAlreadyExists
+	}
+
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return nil, err
+	}
+	if err := os.Symlink(abs, target); err != nil {
+		return nil, fmt.Errorf("failed to link extension: %w", err)
+	}
+
+	return m.installed(name)
+}
+
+// InstallGit installs an extension by cloning a git repository.
+// The repository name must start with gf- and contain a matching executable.
+func (m *Manager) InstallGit(url string) (*Extension, error) {
+	name, err := nameFromURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(m.dir, Prefix+name)
+	if _, err := os.Lstat(target); err == nil {
+		return nil, ErrAlreadyExists
+	}
+
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return nil, err
+	}
+
+	if err := runGit("", "clone", "--", url, target); err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+
+	ext, err := m.installed(name)
+	if err != nil {
+		os.RemoveAll(target)
+		return nil, err
+	}
+	return ext, nil
+}
+
+// Upgrade pulls the latest changes for a git-installed extension
+func (m *Manager) Upgrade(name string) error {
+	ext, err := m.installed(strings.TrimPrefix(name, Prefix))
+	if err != nil {
+		return err
+	}
+	if ext.Source != SourceGit {
+		return fmt.Errorf("extension %s is a local directory, nothing to upgrade", ext.Name)
+	}
+	return runGit(ext.Dir, "pull", "--ff-only")
+}
+
+// Remove uninstalls an extension from the extensions directory
+func (m *Manager) Remove(name string) error {
+	name = strings.TrimPrefix(name, Prefix)
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	target := filepath.Join(m.dir, Prefix+name)
+	info, err := os.Lstat(target)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	// For symlinked local extensions remove only the link
+	if info.Mode()&os.ModeSymlink != 0 {
+		return os.Remove(target)
+	}
+	return os.RemoveAll(target)
+}
+
+// nameFromSource returns the extension name for a local directory or git URL
+func nameFromSource(source string) (string, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return "", err
+		}
+		return nameFromBase(filepath.Base(abs))
+	}
+	return nameFromURL(source)
+}
+
+// nameFromURL extracts the extension name from a git URL
+// (https://host/owner/gf-deploy.git -> deploy)
+func nameFromURL(url string) (string, error) {
+	base := strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	if i := strings.LastIndexAny(base, "/:"); i >= 0 {
+		base = base[i+1:]
+	}
+	return nameFromBase(base)
+}
+
+// nameFromBase strips the gf- prefix from a repository or directory name
+func nameFromBase(base string) (string, error) {
+	if !strings.HasPrefix(base, Prefix) {
+		return "", fmt.Errorf("%w (got %q)", ErrInvalidName, base)
+	}
+	name := strings.TrimPrefix(base, Prefix)
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// remoteURL returns the origin URL of a cloned extension
+func remoteURL(dir string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// runGit runs a git command, streaming output to stderr
+func runGit(dir string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.New("git command timed out")
+		}
+		return err
+	}
+	return nil
+}
+
+// isExecutable reports whether path is a regular executable file
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode()&0111 != 0
+}
@@ -0,0 +1,162 @@
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// writeExtension creates a directory with a gf-<name> executable
+func writeExtension(t *testing.T, parent, name string) string {
+	t.Helper()
+	dir := filepath.Join(parent, Prefix+name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	exe := filepath.Join(dir, executableName(name))
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\necho ok\n"), 0755); err != nil {
+		t.Fatalf("failed to write executable: %v", err)
+	}
+	return dir
+}
+
+func TestNameFromURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"https://gitflic.ru/project/team/gf-deploy.git", "deploy", false},
+		{"https://gitflic.ru/project/team/gf-deploy", "deploy", false},
+		{"https://gitflic.ru/project/team/gf-deploy/", "deploy", false},
+		{"git@gitflic.ru:team/gf-lint.git", "lint", false},
+		{"https://gitflic.ru/project/team/deploy.git", "", true},
+		{"https://gitflic.ru/project/team/gf-.git", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := nameFromURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("nameFromURL(%q) should return error", tt.url)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nameFromURL(%q) unexpected error: %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("nameFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_InstallLocalFindRemove(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on Windows")
+	}
+
+	t.Setenv("PATH", "")
+	src := writeExtension(t, t.TempDir(), "deploy")
+	m := NewManagerWithDir(filepath.Join(t.TempDir(), "extensions"))
+
+	ext, err := m.InstallLocal(src)
+	if err != nil {
+		t.Fatalf("InstallLocal failed: %v", err)
+	}
+	if ext.Name != "deploy" || ext.Source != SourceLocal {
+		t.Errorf("InstallLocal = %+v, want local deploy extension", ext)
+	}
+
+	if _, err := m.InstallLocal(src); err != ErrAlreadyExists {
+		t.Errorf("second InstallLocal error = %v, want ErrAlreadyExists", err)
+	}
+
+	found, err := m.Find("deploy")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if found.Path != filepath.Join(src, executableName("deploy")) {
+		t.Errorf("Find path = %q", found.Path)
+	}
+
+	exts, err := m.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(exts) != 1 || exts[0].Name != "deploy" {
+		t.Errorf("List = %+v, want [deploy]", exts)
+	}
+
+	if err := m.Upgrade("deploy"); err == nil {
+		t.Error("Upgrade of local extension should return error")
+	}
+
+	if err := m.Remove("deploy"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("Remove deleted the linked source directory: %v", err)
+	}
+	if _, err := m.Find("deploy"); err != ErrNotFound {
+		t.Errorf("Find after Remove error = %v, want ErrNotFound", err)
+	}
+	if err := m.Remove("deploy"); err != ErrNotFound {
+		t.Errorf("second Remove error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestManager_InstallLocal_Invalid(t *testing.T) {
+	m := NewManagerWithDir(filepath.Join(t.TempDir(), "extensions"))
+
+	// Directory without gf- prefix
+	plain := filepath.Join(t.TempDir(), "deploy")
+	if err := os.MkdirAll(plain, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.InstallLocal(plain); err == nil {
+		t.Error("InstallLocal without gf- prefix should return error")
+	}
+
+	// Directory without executable
+	empty := filepath.Join(t.TempDir(), "gf-empty")
+	if err := os.MkdirAll(empty, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.InstallLocal(empty); err == nil {
+		t.Error("InstallLocal without executable should return error")
+	}
+}
+
+func TestManager_FindInPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bits are not used on Windows")
+	}
+
+	bin := t.TempDir()
+	exe := filepath.Join(bin, "gf-hello")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	m := NewManagerWithDir(filepath.Join(t.TempDir(), "extensions"))
+
+	ext, err := m.Find("hello")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if ext.Source != SourcePath || ext.Path != exe {
+		t.Errorf("Find = %+v, want PATH extension at %s", ext, exe)
+	}
+
+	if _, err := m.Find("missing"); err != ErrNotFound {
+		t.Errorf("Find(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := m.Find("../hello"); err != ErrNotFound {
+		t.Errorf("Find(../hello) error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,44 @@
+package extension
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove an installed extension",
+		Long: `Remove an extension from ~/.gf/extensions.
+
+For extensions installed from a local directory only the link is removed.
+Executables found in PATH are not managed by gf.`,
+		Example: `  # Remove an extension
+  gf extension remove deploy`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runRemove(name string) error {
+	m, err := NewManager()
+	if err != nil {
+		return err
+	}
+
+	if err := m.Remove(name); err != nil {
+		if err == ErrNotFound {
+			return fmt.Errorf("extension %s is not installed in %s", name, m.Dir())
+		}
+		return fmt.Errorf("failed to remove extension: %w", err)
+	}
+
+	fmt.Printf("✓ Removed extension %s\n", name)
+	return nil
+}
@@ -0,0 +1,89 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+type upgradeOptions struct {
+	all bool
+}
+
+func newUpgradeCmd() *cobra.Command {
+	opts := &upgradeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade <name>",
+		Short: "Upgrade installed extensions",
+		Long: `Pull the latest version of extensions installed from git.
+
+Extensions linked from local directories and found in PATH are skipped.`,
+		Example: `  # Upgrade one extension
+  gf extension upgrade deploy
+
+  # Upgrade all extensions
+  gf extension upgrade --all`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.all && len(args) == 0 {
+				return fmt.Errorf("specify an extension name or use --all")
+			}
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return runUpgrade(opts, name)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Upgrade all extensions")
+
+	return cmd
+}
+
+func runUpgrade(opts *upgradeOptions, name string) error {
+	m, err := NewManager()
+	if err != nil {
+		return err
+	}
+
+	if !opts.all {
+		fmt.Fprintf(os.Stderr, "Upgrading %s...\n", name)
+		if err := m.Upgrade(name); err != nil {
+			return fmt.Errorf("failed to upgrade %s: %w", name, err)
+		}
+		fmt.Printf("✓ Upgraded extension %s\n", name)
+		return nil
+	}
+
+	exts, err := m.List()
+	if err != nil {
+		return fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	failed := 0
+	upgraded := 0
+	for _, e := range exts {
+		if e.Source != SourceGit {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Upgrading %s...\n", e.Name)
+		if err := m.Upgrade(e.Name); err != nil {
+			fmt.Printf("✗ %s: %v\n", e.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Upgraded extension %s\n", e.Name)
+		upgraded++
+	}
+
+	if upgraded == 0 && failed == 0 {
+		fmt.Println("No extensions installed from git")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to upgrade %d extension(s)", failed)
+	}
+	return nil
+}
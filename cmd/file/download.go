@@ -65,7 +65,7 @@ func runDownload(opts *downloadOptions, path string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get ref (default branch if not specified)
 	ref := opts.ref
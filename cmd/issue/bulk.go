@@ -0,0 +1,447 @@
+package issue
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/bulk"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// issuePageSize is the page size used to fetch all matching issues
+const issuePageSize = 100
+
+type bulkOptions struct {
+	state           string
+	limit           int
+	repo            string
+	stdin           bool
+	close           bool
+	reopen          bool
+	addLabels       []string
+	removeLabels    []string
+	addAssignees    []string
+	removeAssignees []string
+	comment         string
+	concurrency     int
+	dryRun          bool
+	yes             bool
+}
+
+// bulkActions are the resolved changes applied to every issue
+type bulkActions struct {
+	close           bool
+	reopen          bool
+	addLabels       []api.Label
+	removeLabels    []string
+	addAssignees    []api.User
+	removeAssignees []api.User
+	comment         string
+}
+
+func newBulkCmd() *cobra.Command {
+	opts := &bulkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "bulk [<id>...]",
+		Short: "Change many issues at once",
+		Long: `Close, reopen, label, assign or comment on many issues at once.
+
+Issues are selected with the filters of 'gf issue list', by ID, or by IDs
+read from stdin with --stdin (one per line). The selected issues are
+listed and must be confirmed before anything changes; --dry-run only
+lists them.
+
+Issues are processed concurrently. Failures do not stop the run; they are
+listed at the end and gf exits with status 1.`,
+		Example: `  # Close all open issues after confirmation
+  gf issue bulk --close
+
+  # Label and assign issues by ID
+  gf issue bulk 3 7 9 --add-label bug --add-assignee @me
+
+  # Comment on closed issues without confirmation
+  gf issue bulk --state closed --comment "Fixed in 2.0" --yes
+
+  # IDs from another command
+  gf issue list --json | jq '.[].localId' | gf issue bulk --stdin --add-label triage --yes
+
+  # Only show what would change
+  gf issue bulk --state all --remove-label stale --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validate(); err != nil {
+				return err
+			}
+			if (len(args) > 0 || opts.stdin) && (cmd.Flags().Changed("state") || cmd.Flags().Changed("limit")) {
+				return fmt.Errorf("filters cannot be combined with IDs")
+			}
+			if len(args) > 0 && opts.stdin {
+				return fmt.Errorf("IDs cannot be combined with --stdin")
+			}
+			if opts.stdin && !opts.yes && !opts.dryRun {
+				return fmt.Errorf("--yes is required with --stdin, since stdin cannot be used for confirmation")
+			}
+			return runBulk(opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.state, "state", "s", "open", "Filter by state: open, closed, all")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "L", 0, "Maximum number of issues")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.stdin, "stdin", false, "Read issue IDs from stdin")
+	cmd.Flags().BoolVar(&opts.close, "close", false, "Close the issues")
+	cmd.Flags().BoolVar(&opts.reopen, "reopen", false, "Reopen the issues")
+	cmd.Flags().StringSliceVar(&opts.addLabels, "add-label", nil, "Add labels")
+	cmd.Flags().StringSliceVar(&opts.removeLabels, "remove-label", nil, "Remove labels")
+	cmd.Flags().StringSliceVar(&opts.addAssignees, "add-assignee", nil, "Add assignees (username or @me)")
+	cmd.Flags().StringSliceVar(&opts.removeAssignees, "remove-assignee", nil, "Remove assignees (username or @me)")
+	cmd.Flags().StringVar(&opts.comment, "comment", "", "Add a comment")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "j", bulk.DefaultConcurrency, "Number of issues changed at once")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "List the selected issues without changing them")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation")
+	cmd.MarkFlagsMutuallyExclusive("close", "reopen")
+
+	return cmd
+}
+
+// validate checks the filters and the requested actions
+func (opts *bulkOptions) validate() error {
+	switch opts.state {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid state %q: use open, closed or all", opts.state)
+	}
+	if !opts.close && !opts.reopen && len(opts.addLabels) == 0 && len(opts.removeLabels) == 0 &&
+		len(opts.addAssignees) == 0 && len(opts.removeAssignees) == 0 && strings.TrimSpace(opts.comment) == "" {
+		return fmt.Errorf("nothing to do: use --close, --reopen, --add-label, --remove-label, --add-assignee, --remove-assignee or --comment")
+	}
+	if opts.concurrency < 1 || opts.concurrency > bulk.MaxConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", bulk.MaxConcurrency)
+	}
+	return nil
+}
+
+func runBulk(opts *bulkOptions, args []string) error {
+	// Get repository
+	repo, err := git.ResolveRepo(opts.repo, config.DefaultHost())
+	if err != nil {
+		return fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	// Load config and create client
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	var items []bulk.Item
+	switch {
+	case opts.stdin:
+		ids, err := bulk.ReadIDs(os.Stdin)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			items = append(items, bulk.Item{ID: id})
+		}
+	case len(args) > 0:
+		seen := make(map[int]bool)
+		for _, arg := range args {
+			id, err := bulk.ParseID(arg)
+			if err != nil {
+				return err
+			}
+			if !seen[id] {
+				seen[id] = true
+				items = append(items, bulk.Item{ID: id})
+			}
+		}
+	default:
+		issues, err := listAllIssues(client, repo.Owner, repo.Name, opts.state, opts.limit)
+		if err != nil {
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range issues {
+			items = append(items, bulk.Item{ID: issue.LocalID, Title: issue.Title})
+		}
+	}
+
+	if len(items) == 0 {
+		if opts.stdin {
+			fmt.Println("No issue IDs on stdin")
+		} else {
+			fmt.Printf("No %s issues in %s\n", opts.state, repo.FullName())
+		}
+		return nil
+	}
+
+	// Resolve users and labels once, so typos fail before anything changes
+	actions := &bulkActions{
+		close:        opts.close,
+		reopen:       opts.reopen,
+		removeLabels: opts.removeLabels,
+		comment:      strings.TrimSpace(opts.comment),
+	}
+	if actions.addAssignees, err = resolveUsers(client, opts.addAssignees); err != nil {
+		return err
+	}
+	if actions.removeAssignees, err = resolveUsers(client, opts.removeAssignees); err != nil {
+		return err
+	}
+	if actions.addLabels, err = resolveLabels(client, repo.Owner, repo.Name, opts.addLabels); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d issues in %s:\n", len(items), repo.FullName())
+	bulk.WritePreview(os.Stdout, items)
+	fmt.Printf("\nActions: %s\n", actions.describe())
+
+	if opts.dryRun {
+		fmt.Println("\nDry run: no changes made")
+		return nil
+	}
+
+	if !opts.yes {
+		fmt.Printf("\nApply to %d issues? [y/N] ", len(items))
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+	fmt.Println()
+
+	failures := bulk.Run(items, opts.concurrency, os.Stdout, func(item bulk.Item) error {
+		return actions.apply(client, repo.Owner, repo.Name, item.ID)
+	})
+	bulk.WriteSummary(os.Stdout, len(items), failures)
+	if len(failures) > 0 {
+		return api.NewExitError(1)
+	}
+	return nil
+}
+
+// listAllIssues fetches issues in state page by page, up to limit (0 for
+// all of them)
+func listAllIssues(client *api.Client, owner, project, state string, limit int) ([]api.Issue, error) {
+	var all []api.Issue
+	for page := 0; ; page++ {
+		issues, more, err := client.Issues().ListPage(owner, project, &api.IssueListOptions{
+			State:   state,
+			Page:    page,
+			PerPage: issuePageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		if !more {
+			return all, nil
+		}
+	}
+}
+
+// resolveUsers looks up users by alias, resolving @me to the
+// authenticated user
+func resolveUsers(client *api.Client, aliases []string) ([]api.User, error) {
+	users := make([]api.User, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimPrefix(strings.TrimSpace(alias), "@")
+		if alias == "" {
+			continue
+		}
+
+		var user *api.User
+		var err error
+		if strings.EqualFold(alias, "me") {
+			user, err = client.Users().Me()
+		} else {
+			user, err = client.Users().Get(alias)
+		}
+		if err != nil {
+			if api.IsNotFound(err) {
+				return nil, fmt.Errorf("user @%s not found", alias)
+			}
+			return nil, fmt.Errorf("failed to look up @%s: %w", alias, err)
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+// resolveLabels maps label titles to the project's labels (case-insensitive)
+func resolveLabels(client *api.Client, owner, project string, titles []string) ([]api.Label, error) {
+	if len(titles) == 0 {
+		return nil, nil
+	}
+
+	available, err := client.Projects().Labels(owner, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	labels := make([]api.Label, 0, len(titles))
+	for _, title := range titles {
+		var found *api.Label
+		for i := range available {
+			if strings.EqualFold(available[i].Title, strings.TrimSpace(title)) {
+				found = &available[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("label %q not found in %s/%s", title, owner, project)
+		}
+		labels = append(labels, *found)
+	}
+	return labels, nil
+}
+
+// describe lists the actions for the preview, in the order they are applied
+func (a *bulkActions) describe() string {
+	var parts []string
+	if a.comment != "" {
+		parts = append(parts, "comment")
+	}
+	if len(a.addLabels) > 0 {
+		titles := make([]string, len(a.addLabels))
+		for i, l := range a.addLabels {
+			titles[i] = l.Title
+		}
+		parts = append(parts, "add labels "+strings.Join(titles, ", "))
+	}
+	if len(a.removeLabels) > 0 {
+		parts = append(parts, "remove labels "+strings.Join(a.removeLabels, ", "))
+	}
+	if len(a.addAssignees) > 0 {
+		parts = append(parts, "assign "+formatUsers(a.addAssignees))
+	}
+	if len(a.removeAssignees) > 0 {
+		parts = append(parts, "unassign "+formatUsers(a.removeAssignees))
+	}
+	if a.close {
+		parts = append(parts, "close")
+	}
+	if a.reopen {
+		parts = append(parts, "reopen")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// apply makes the changes to one issue. Closing a closed or reopening an
+// open issue is not an error.
+func (a *bulkActions) apply(client *api.Client, owner, project string, id int) error {
+	issue, err := client.Issues().Get(owner, project, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("not found")
+		}
+		return err
+	}
+
+	if a.comment != "" {
+		if _, err := client.Issues().CreateComment(owner, project, id, a.comment); err != nil {
+			return fmt.Errorf("failed to comment: %w", err)
+		}
+	}
+
+	req := &api.UpdateIssueRequest{}
+	changed := false
+	if len(a.addAssignees) > 0 || len(a.removeAssignees) > 0 {
+		ids := mergeIDs(userIDs(issue.Assignees), userIDs(a.addAssignees), userIDs(a.removeAssignees))
+		req.AssignedUsers = &ids
+		changed = true
+	}
+	if len(a.addLabels) > 0 || len(a.removeLabels) > 0 {
+		// Removing only needs labels present on the issue
+		var remove []string
+		for _, title := range a.removeLabels {
+			for _, l := range issue.Labels {
+				if strings.EqualFold(l.Title, title) {
+					remove = append(remove, l.ID)
+				}
+			}
+		}
+		ids := mergeIDs(labelIDs(issue.Labels), labelIDs(a.addLabels), remove)
+		req.Labels = &ids
+		changed = true
+	}
+	if changed {
+		if _, err := client.Issues().Update(owner, project, id, req); err != nil {
+			return fmt.Errorf("failed to update: %w", err)
+		}
+	}
+
+	switch {
+	case a.close && issue.State() == "open":
+		if err := client.Issues().Close(owner, project, id); err != nil {
+			return fmt.Errorf("failed to close: %w", err)
+		}
+	case a.reopen && issue.State() == "closed":
+		if err := client.Issues().Reopen(owner, project, id); err != nil {
+			return fmt.Errorf("failed to reopen: %w", err)
+		}
+	}
+	return nil
+}
+
+// mergeIDs returns current plus add minus remove, without duplicates
+func mergeIDs(current, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, id := range remove {
+		removed[id] = true
+	}
+
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(current)+len(add))
+	for _, id := range append(append([]string{}, current...), add...) {
+		if id == "" || removed[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func userIDs(users []api.User) []string {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+func labelIDs(labels []api.Label) []string {
+	ids := make([]string, len(labels))
+	for i, l := range labels {
+		ids[i] = l.ID
+	}
+	return ids
+}
+
+// formatUsers formats users as "@alice, @bob"
+func formatUsers(users []api.User) string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = "@" + u.Username
+	}
+	return strings.Join(names, ", ")
+}
@@ -2,7 +2,6 @@ package issue
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -18,16 +17,16 @@ func newCloseCmd() *cobra.Command {
 	opts := &closeOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "close <id>",
+		Use:   "close [<id>]",
 		Short: "Close an issue",
 		Long:  `Close an issue.`,
 		Example: `  # Close issue #42
   gf issue close 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
+			id, err := issueIDArg(args, opts.repo, "open")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runClose(opts, id)
 		},
@@ -56,7 +55,7 @@ func runClose(opts *closeOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Close issue
 	if err := client.Issues().Close(repo.Owner, repo.Name, id); err != nil {
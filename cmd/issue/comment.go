@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -23,7 +22,7 @@ func newCommentCmd() *cobra.Command {
 	opts := &commentOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "comment <id>",
+		Use:   "comment [<id>]",
 		Short: "Add a comment to an issue",
 		Long: `Add a comment to an issue.
 
@@ -36,11 +35,11 @@ Without --body flag, opens an interactive prompt for the comment text.`,
 
   # Pipe comment from stdin
   echo "Fixed in v1.2" | gf issue comment 42 --body -`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := issueIDArg(args, opts.repo, "open")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runComment(opts, id)
 		},
@@ -70,7 +69,7 @@ func runComment(opts *commentOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get issue info first
 	issue, err := client.Issues().Get(repo.Owner, repo.Name, id)
@@ -121,16 +120,16 @@ func newCommentsCmd() *cobra.Command {
 	}{}
 
 	cmd := &cobra.Command{
-		Use:   "comments <id>",
+		Use:   "comments [<id>]",
 		Short: "List comments on an issue",
 		Long:  `List all comments on an issue.`,
 		Example: `  # List comments
   gf issue comments 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := issueIDArg(args, opts.repo, "all")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runComments(opts.repo, id)
 		},
@@ -159,7 +158,7 @@ func runComments(repoFlag string, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get issue info first
 	issue, err := client.Issues().Get(repo.Owner, repo.Name, id)
@@ -65,7 +65,7 @@ func runCreate(opts *createOptions) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Interactive mode if title not provided
 	title := opts.title
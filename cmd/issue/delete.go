@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -22,7 +21,7 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <id>",
+		Use:   "delete [<id>]",
 		Short: "Delete an issue",
 		Long: `Delete an issue from the repository.
 
@@ -35,11 +34,11 @@ Warning: This action cannot be undone.`,
 
   # Delete issue without confirmation
   gf issue delete 42 --force`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := issueIDArg(args, opts.repo, "all")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runDeleteIssue(opts, id)
 		},
@@ -69,7 +68,7 @@ func runDeleteIssue(opts *deleteOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if issue exists
 	issue, err := client.Issues().Get(repo.Owner, repo.Name, id)
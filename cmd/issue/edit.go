@@ -2,8 +2,6 @@ package issue
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -21,7 +19,7 @@ func newEditCmd() *cobra.Command {
 	opts := &editOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "edit <id>",
+		Use:   "edit [<id>]",
 		Short: "Edit an issue",
 		Long: `Edit an existing issue.
 
@@ -31,11 +29,11 @@ You can update the title and description.`,
 
   # Edit issue description
   gf issue edit 42 --description "Updated description"`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := issueIDArg(args, opts.repo, "open")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runEdit(opts, id)
 		},
@@ -66,7 +64,7 @@ func runEdit(opts *editOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if issue exists
 	_, err = client.Issues().Get(repo.Owner, repo.Name, id)
@@ -19,6 +19,7 @@ func NewCmdIssue() *cobra.Command {
 	cmd.AddCommand(newCloseCmd())
 	cmd.AddCommand(newReopenCmd())
 	cmd.AddCommand(newEditCmd())
+	cmd.AddCommand(newBulkCmd())
 	cmd.AddCommand(newDeleteCmd())
 	cmd.AddCommand(newCommentCmd())
 	cmd.AddCommand(newCommentsCmd())
@@ -2,8 +2,6 @@ package issue
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -15,16 +13,16 @@ func newReopenCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "reopen <id>",
+		Use:   "reopen [<id>]",
 		Short: "Reopen a closed issue",
 		Long:  `Reopen a previously closed issue.`,
 		Example: `  # Reopen issue
   gf issue reopen 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := issueIDArg(args, repo, "closed")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runReopen(repo, id)
 		},
@@ -53,7 +51,7 @@ func runReopen(repoFlag string, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if issue exists
 	issue, err := client.Issues().Get(repo.Owner, repo.Name, id)
@@ -0,0 +1,69 @@
+package issue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
+)
+
+// issueIDArg parses the optional issue ID argument of a command. Without
+// one, the user picks an issue in state (open or closed) interactively.
+func issueIDArg(args []string, repoFlag, state string) (int, error) {
+	if len(args) > 0 {
+		id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid issue ID: %s", args[0])
+		}
+		return id, nil
+	}
+	if !prompt.Enabled() {
+		return 0, fmt.Errorf("issue ID required when not running interactively")
+	}
+
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return 0, fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return 0, fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	issues, err := client.Issues().List(repo.Owner, repo.Name, &api.IssueListOptions{State: state})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list issues: %w", err)
+	}
+	if len(issues) == 0 {
+		return 0, fmt.Errorf("no %s issues in %s", state, repo.FullName())
+	}
+
+	items := make([]prompt.Item, len(issues))
+	for i, issue := range issues {
+		items[i].Label = fmt.Sprintf("#%d %s", issue.LocalID, issue.Title)
+		items[i].Preview = fmt.Sprintf("%s · @%s · updated %s", issue.State(), issue.Author.Username,
+			output.FormatRelativeTime(issue.UpdatedAt.Time))
+		if desc := strings.TrimSpace(issue.Description); desc != "" {
+			items[i].Preview += "\n\n" + desc
+		}
+	}
+
+	i, err := prompt.Select("Select an issue", items)
+	if err != nil {
+		return 0, err
+	}
+	return issues[i].LocalID, nil
+}
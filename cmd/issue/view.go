@@ -3,8 +3,6 @@ package issue
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/browser"
@@ -24,7 +22,7 @@ func newViewCmd() *cobra.Command {
 	opts := &viewOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "view <id>",
+		Use:   "view [<id>]",
 		Short: "View an issue",
 		Long:  `Display the details of an issue.`,
 		Example: `  # View issue #42
@@ -35,12 +33,11 @@ func newViewCmd() *cobra.Command {
 
   # Open in browser
   gf issue view 42 --web`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Support both "42" and "#42" formats
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := issueIDArg(args, opts.repo, "all")
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %s", args[0])
+				return err
 			}
 			return runView(opts, id)
 		},
@@ -71,7 +68,7 @@ func runView(opts *viewOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch issue
 	issue, err := client.Issues().Get(repo.Owner, repo.Name, id)
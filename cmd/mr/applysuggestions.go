@@ -0,0 +1,350 @@
+package mr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/fileutil"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+type applySuggestionsOptions struct {
+	repo     string
+	yes      bool
+	dryRun   bool
+	all      bool
+	message  string
+	resolve  bool
+	reply    string
+	noCommit bool
+}
+
+func newApplySuggestionsCmd() *cobra.Command {
+	opts := &applySuggestionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply-suggestions [<id> | <branch> | <url>]",
+		Short: "Apply code suggestions from review comments",
+		Long: "Apply suggestion blocks from inline review comments to the checked-out\n" +
+			"source branch and commit them.\n\n" +
+			"A suggestion is a fenced code block in an inline comment:\n\n" +
+			"  ```suggestion\n" +
+			"  replacement for the commented line\n" +
+			"  ```\n\n" +
+			"Use ```suggestion:-2+1 to replace 2 lines above and 1 line below the\n" +
+			"commented line as well. Each suggestion is shown as a patch and applied\n" +
+			"after confirmation; --yes applies all of them.\n\n" +
+			"Without an ID, the merge request of the current branch is used.",
+		Example: `  # Pick suggestions to apply interactively
+  gf mr apply-suggestions 12
+
+  # Apply everything, then resolve the threads
+  gf mr apply-suggestions 12 --yes --resolve
+
+  # Only show the suggestions
+  gf mr apply-suggestions 12 --dry-run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			return runApplySuggestions(opts, id)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Apply all suggestions without asking")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show suggestions without applying them")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include suggestions from resolved threads")
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Commit message")
+	cmd.Flags().BoolVar(&opts.noCommit, "no-commit", false, "Change the files but do not commit")
+	cmd.Flags().BoolVar(&opts.resolve, "resolve", false, "Resolve the threads of applied suggestions")
+	cmd.Flags().StringVar(&opts.reply, "reply", "", "Reply to the threads of applied suggestions")
+
+	return cmd
+}
+
+func runApplySuggestions(opts *applySuggestionsOptions, id int) error {
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	threads, err := client.MergeRequests().ListDiscussionThreads(repo.Owner, repo.Name, mr.LocalID)
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+	suggestions := collectSuggestions(threads, opts.all)
+	if len(suggestions) == 0 {
+		fmt.Printf("No suggestions on MR #%d\n", mr.LocalID)
+		return nil
+	}
+
+	if !opts.dryRun {
+		if err := checkSuggestionTree(mr, suggestions); err != nil {
+			return err
+		}
+	}
+	top, err := git.TopLevel()
+	if err != nil {
+		return err
+	}
+
+	// Pick suggestions, reading files as they are now
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	color := isTTY && !api.NoColor()
+	interactive := !opts.yes && !opts.dryRun
+	if interactive && !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("stdin is not a terminal; use --yes to apply all suggestions")
+	}
+
+	files := make(map[string]string)
+	reader := bufio.NewReader(os.Stdin)
+	var selected []*suggestion
+	for _, s := range suggestions {
+		if strings.HasPrefix(s.Path, "-") || !filepath.IsLocal(filepath.FromSlash(s.Path)) {
+			fmt.Fprintf(os.Stderr, "! Skipping #%d: invalid path %s\n", s.ID, s.Path)
+			continue
+		}
+		content, ok := files[s.Path]
+		if !ok {
+			data, err := os.ReadFile(filepath.Join(top, filepath.FromSlash(s.Path)))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "! Skipping #%d: %v\n", s.ID, err)
+				continue
+			}
+			content = string(data)
+			files[s.Path] = content
+		}
+		lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+		if s.Start < 1 || s.End > len(lines) {
+			fmt.Fprintf(os.Stderr, "! Skipping #%d: %s is outside the file\n", s.ID, s.location())
+			continue
+		}
+
+		fmt.Println()
+		writeSuggestion(os.Stdout, s, lines[s.Start-1:s.End], color)
+		if !interactive {
+			selected = append(selected, s)
+			continue
+		}
+
+		fmt.Printf("Apply this suggestion? [y/N/a/q] ")
+		answer, _ := reader.ReadString('\n')
+		switch strings.TrimSpace(strings.ToLower(answer)) {
+		case "y", "yes":
+			selected = append(selected, s)
+		case "a", "all":
+			selected = append(selected, s)
+			// Apply the rest without asking, still showing them
+			interactive = false
+		case "q", "quit":
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if opts.dryRun {
+		fmt.Printf("\n%d suggestion(s) on MR #%d\n", len(suggestions), mr.LocalID)
+		return nil
+	}
+	if len(selected) == 0 {
+		fmt.Println("\nNo suggestions applied.")
+		return nil
+	}
+
+	applied, err := writeSuggestions(top, files, selected)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return fmt.Errorf("no suggestions could be applied")
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, s := range applied {
+		if !seen[s.Path] {
+			seen[s.Path] = true
+			paths = append(paths, filepath.Join(top, filepath.FromSlash(s.Path)))
+		}
+	}
+
+	fmt.Println()
+	if opts.noCommit {
+		fmt.Printf("✓ Applied %d suggestion(s) to %d file(s)\n", len(applied), len(paths))
+	} else {
+		message := opts.message
+		if message == "" {
+			message = suggestionCommitMessage(applied, fmt.Sprintf("%s!%d", repo.FullName(), mr.LocalID))
+		}
+		if err := git.CommitFiles(message, paths); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Committed %d suggestion(s) to %s\n", len(applied), mr.SourceBranch.Title)
+	}
+
+	failed := 0
+	if opts.reply != "" || opts.resolve {
+		failed = answerThreads(client, repo, mr.LocalID, applied, opts.reply, opts.resolve)
+	}
+
+	if !opts.noCommit {
+		fmt.Println("Run 'git push' to update the merge request")
+	}
+	if failed > 0 {
+		return api.NewExitError(1)
+	}
+	return nil
+}
+
+// checkSuggestionTree makes sure suggestions are applied to the source
+// branch of mr and do not mix with uncommitted changes
+func checkSuggestionTree(mr *api.MergeRequest, suggestions []*suggestion) error {
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		return fmt.Errorf("could not determine current branch: %w", err)
+	}
+	if branch != mr.SourceBranch.Title {
+		return fmt.Errorf("suggestions must be applied to %s, but %s is checked out\nRun 'gf mr checkout %d' first",
+			mr.SourceBranch.Title, branch, mr.LocalID)
+	}
+
+	if head, err := git.HeadSHA(); err == nil && mr.SourceBranch.Hash != "" && head != mr.SourceBranch.Hash {
+		fmt.Fprintf(os.Stderr, "! Local %s (%s) differs from the merge request (%s); line numbers may have shifted\n",
+			branch, shortSHA(head), shortSHA(mr.SourceBranch.Hash))
+	}
+
+	top, err := git.TopLevel()
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for _, s := range suggestions {
+		if filepath.IsLocal(filepath.FromSlash(s.Path)) {
+			paths = append(paths, filepath.Join(top, filepath.FromSlash(s.Path)))
+		}
+	}
+	modified, err := git.ModifiedFiles(paths...)
+	if err != nil {
+		return fmt.Errorf("failed to check working tree: %w", err)
+	}
+	if len(modified) > 0 {
+		return fmt.Errorf("files with suggestions have uncommitted changes:\n  %s\nCommit or stash them first",
+			strings.Join(modified, "\n  "))
+	}
+	return nil
+}
+
+// writeSuggestions applies selected suggestions to the files and returns
+// the ones that were applied
+func writeSuggestions(top string, files map[string]string, selected []*suggestion) ([]*suggestion, error) {
+	byPath := make(map[string][]*suggestion)
+	var order []string
+	for _, s := range selected {
+		if _, ok := byPath[s.Path]; !ok {
+			order = append(order, s.Path)
+		}
+		byPath[s.Path] = append(byPath[s.Path], s)
+	}
+
+	var applied []*suggestion
+	for _, path := range order {
+		content, skipped, err := applySuggestions(files[path], byPath[path])
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range skipped {
+			fmt.Fprintf(os.Stderr, "! Skipping #%d: overlaps another suggestion on %s\n", s.ID, s.Path)
+		}
+
+		full := filepath.Join(top, filepath.FromSlash(path))
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(full); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := fileutil.WriteAtomic(full, []byte(content), mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		for _, s := range byPath[path] {
+			if !containsSuggestion(skipped, s) {
+				applied = append(applied, s)
+			}
+		}
+	}
+	return applied, nil
+}
+
+func containsSuggestion(list []*suggestion, s *suggestion) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// answerThreads replies to and/or resolves the threads of applied
+// suggestions and returns the number of threads that failed. Failures are
+// reported but do not undo the commit.
+func answerThreads(client *api.Client, repo *git.Repository, id int, applied []*suggestion, reply string, resolve bool) int {
+	seen := make(map[string]bool)
+	answered, failed := 0, 0
+	for _, s := range applied {
+		if seen[s.Thread] {
+			continue
+		}
+		seen[s.Thread] = true
+
+		ok := true
+		if reply != "" {
+			_, err := client.MergeRequests().ReplyDiscussion(repo.Owner, repo.Name, id, &api.ReplyDiscussionRequest{
+				DiscussionUUID: s.Thread,
+				Message:        reply,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "! Failed to reply to %s: %v\n", shortUUID(s.Thread), err)
+				ok = false
+			}
+		}
+		if resolve {
+			if _, err := client.MergeRequests().ResolveDiscussion(repo.Owner, repo.Name, id, s.Thread); err != nil {
+				fmt.Fprintf(os.Stderr, "! Failed to resolve %s: %v\n", shortUUID(s.Thread), err)
+				ok = false
+			}
+		}
+		if ok {
+			answered++
+		} else {
+			failed++
+		}
+	}
+
+	if answered > 0 {
+		switch {
+		case reply != "" && resolve:
+			fmt.Printf("✓ Replied to and resolved %d thread(s)\n", answered)
+		case resolve:
+			fmt.Printf("✓ Resolved %d thread(s)\n", answered)
+		default:
+			fmt.Printf("✓ Replied to %d thread(s)\n", answered)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,148 @@
+package mr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// approvalFetchWorkers bounds concurrent approval requests in 'gf mr list'
+const approvalFetchWorkers = 8
+
+func newApprovalsCmd() *cobra.Command {
+	opts := &struct {
+		repo string
+		json bool
+	}{}
+
+	cmd := &cobra.Command{
+		Use:   "approvals [<id> | <branch> | <url>]",
+		Short: "Show who approved a merge request",
+		Long: `Show the approvals of a merge request, how many are required and
+which reviewers have not approved yet.`,
+		Example: `  gf mr approvals 42`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			return runApprovals(opts.repo, id, opts.json)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runApprovals(repoFlag string, id int, asJSON bool) error {
+	repo, client, err := repoClient(repoFlag)
+	if err != nil {
+		return err
+	}
+
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	approvals, err := client.MergeRequests().Approvals(repo.Owner, repo.Name, id)
+	if err != nil {
+		return fmt.Errorf("failed to get approvals: %w", err)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(approvals, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\nMerge request #%d: %s\n", mr.LocalID, mr.Title)
+	status := approvals.Summary()
+	if left := approvals.Left(); left > 0 && !approvals.Approved {
+		status += fmt.Sprintf(", %d more required", left)
+	}
+	fmt.Printf("Approvals: %s\n", status)
+
+	if len(approvals.Approvals) > 0 {
+		fmt.Println()
+		for _, a := range approvals.Approvals {
+			fmt.Printf("  ✓ @%-20s %s\n", a.User.Username, output.FormatRelativeTime(a.CreatedAt))
+		}
+	}
+
+	var waiting []string
+	for i := range mr.Reviewers {
+		if !approvals.ApprovedBy(&mr.Reviewers[i]) {
+			waiting = append(waiting, "@"+mr.Reviewers[i].Username)
+		}
+	}
+	if len(waiting) > 0 {
+		fmt.Printf("\nWaiting for: %s\n", strings.Join(waiting, ", "))
+	}
+	fmt.Println()
+	return nil
+}
+
+// approvalSummaries fetches the approvals of open merge requests with
+// bounded concurrency. Merge requests whose approvals could not be
+// fetched are missing from the result.
+func approvalSummaries(client *api.Client, owner, project string, mrs []api.MergeRequest) map[int]*api.MRApprovals {
+	result := make(map[int]*api.MRApprovals)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, approvalFetchWorkers)
+
+	for _, mr := range mrs {
+		if mr.State() != "open" {
+			continue
+		}
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			a, err := client.MergeRequests().Approvals(owner, project, id)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			result[id] = a
+			mu.Unlock()
+		}(mr.LocalID)
+	}
+	wg.Wait()
+	return result
+}
+
+// approvalColumn formats approvals for the list table: "2/3", "✓1" or "-"
+func approvalColumn(a *api.MRApprovals) string {
+	if a == nil {
+		return "-"
+	}
+	mark := ""
+	if a.Satisfied() && (a.Required > 0 || len(a.Approvals) > 0) {
+		mark = "✓"
+	}
+	if a.Required > 0 {
+		return fmt.Sprintf("%s%d/%d", mark, len(a.Approvals), a.Required)
+	}
+	if len(a.Approvals) == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s%d", mark, len(a.Approvals))
+}
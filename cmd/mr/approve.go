@@ -2,8 +2,6 @@ package mr
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -12,29 +10,36 @@ import (
 )
 
 type approveOptions struct {
-	repo string
+	repo   string
+	revoke bool
 }
 
 func newApproveCmd() *cobra.Command {
 	opts := &approveOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "approve <id>",
+		Use:   "approve [<id> | <branch> | <url>]",
 		Short: "Approve a merge request",
-		Long:  `Approve a merge request for merging.`,
+		Long: `Approve a merge request for merging.
+
+Use --revoke to withdraw your approval.`,
 		Example: `  # Approve MR #42
-  gf mr approve 42`,
-		Args: cobra.ExactArgs(1),
+  gf mr approve 42
+
+  # Withdraw the approval
+  gf mr approve 42 --revoke`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runApprove(opts, id)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.revoke, "revoke", false, "Withdraw your approval")
 
 	return cmd
 }
@@ -57,7 +62,7 @@ func runApprove(opts *approveOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -68,6 +73,15 @@ func runApprove(opts *approveOptions, id int) error {
 		return fmt.Errorf("failed to get merge request: %w", err)
 	}
 
+	if opts.revoke {
+		if err := client.MergeRequests().Unapprove(repo.Owner, repo.Name, id); err != nil {
+			return fmt.Errorf("failed to revoke approval: %w", err)
+		}
+
+		fmt.Printf("✓ Revoked approval of merge request #%d: %s\n", mr.LocalID, mr.Title)
+		return nil
+	}
+
 	// Approve MR
 	if err := client.MergeRequests().Approve(repo.Owner, repo.Name, id); err != nil {
 		return fmt.Errorf("failed to approve merge request: %w", err)
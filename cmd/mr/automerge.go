@@ -0,0 +1,442 @@
+package mr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/fileutil"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+)
+
+const (
+	autoMergeDir = "automerge"
+	// pipelineStartTimeout is how long auto-merge waits for a pipeline
+	// to appear for the head commit of the source branch
+	pipelineStartTimeout = 2 * time.Minute
+)
+
+// Auto-merge statuses
+const (
+	autoMergeWaiting  = "waiting"
+	autoMergeMerged   = "merged"
+	autoMergeFailed   = "failed"
+	autoMergeCanceled = "canceled"
+	// autoMergeStopped is only reported, never saved: the state says
+	// waiting but the worker process is gone
+	autoMergeStopped = "stopped"
+)
+
+// autoMergeState is the progress of an auto-merge. It is saved to disk so
+// that 'gf mr view' can report on auto-merges running in the background.
+type autoMergeState struct {
+	PID       int       `json:"pid"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	Pipeline  int       `json:"pipeline,omitempty"`
+	SHA       string    `json:"sha"`
+	Log       string    `json:"log,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// autoMergePath returns the state file of an auto-merge of MR id in repo
+func autoMergePath(repo *git.Repository, id int) (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s_%s_%s_%d.json", repo.Host, repo.Owner, repo.Name, id)
+	name = strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(name)
+	return filepath.Join(dir, autoMergeDir, name), nil
+}
+
+// loadAutoMerge returns the last recorded auto-merge of MR id, or nil
+func loadAutoMerge(repo *git.Repository, id int) (*autoMergeState, error) {
+	path, err := autoMergePath(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var st autoMergeState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// saveAutoMerge records the progress of an auto-merge
+func saveAutoMerge(repo *git.Repository, id int, st *autoMergeState) error {
+	path, err := autoMergePath(repo, id)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	st.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fileutil.WriteAtomic(path, data, 0600)
+}
+
+// autoMerger merges a merge request once its pipeline succeeds and
+// GitFlic reports it as mergeable (required approvals are given)
+type autoMerger struct {
+	client   *api.Client
+	repo     *git.Repository
+	id       int
+	sha      string
+	interval time.Duration
+	merge    *api.MergeMRRequest
+	// report is called whenever the auto-merge makes progress
+	report func(status, message string, pipeline int)
+}
+
+// run waits for the pipeline and approvals and merges the merge request.
+// It fails if the pipeline fails, new commits are pushed to the source
+// branch or the merge request is closed meanwhile.
+func (a *autoMerger) run(ctx context.Context, mr *api.MergeRequest) error {
+	ref := mr.SourceBranch.Title
+
+	pipeline, err := a.findPipeline(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	pipeline, err = a.client.Pipelines().Watch(ctx, a.repo.Owner, a.repo.Name, pipeline.LocalID, a.interval,
+		func(p *api.Pipeline) error {
+			a.report(autoMergeWaiting, fmt.Sprintf("waiting for pipeline #%d (%s)", p.LocalID, p.NormalizedStatus()), p.LocalID)
+			_, err := a.check(ctx)
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	if !pipeline.Succeeded() {
+		return fmt.Errorf("pipeline #%d %s", pipeline.LocalID, pipeline.NormalizedStatus())
+	}
+
+	// Wait for required approvals
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		mr, err = a.check(ctx)
+		if err != nil {
+			return err
+		}
+		blocker, err := approvalBlocker(a.client, a.repo, mr)
+		if err != nil {
+			return err
+		}
+		if blocker == nil {
+			break
+		}
+		a.report(autoMergeWaiting, "pipeline passed, waiting for approvals: "+blocker.message, pipeline.LocalID)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	if err := a.client.MergeRequests().Merge(a.repo.Owner, a.repo.Name, a.id, a.merge); err != nil {
+		return fmt.Errorf("failed to merge: %w", err)
+	}
+	return nil
+}
+
+// findPipeline waits for a pipeline to be started for the head commit
+func (a *autoMerger) findPipeline(ctx context.Context, ref string) (*api.Pipeline, error) {
+	deadline := time.Now().Add(pipelineStartTimeout)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		p, err := a.client.Pipelines().LatestForCommit(ctx, a.repo.Owner, a.repo.Name, ref, a.sha)
+		if err == nil {
+			return p, nil
+		}
+		if !api.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to find pipeline: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("no pipeline was started for %s at %s; merge without --auto", ref, shortSHA(a.sha))
+		}
+
+		a.report(autoMergeWaiting, "waiting for a pipeline to start", 0)
+		if _, err := a.check(ctx); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// check re-fetches the merge request and fails if it can no longer be
+// auto-merged
+func (a *autoMerger) check(ctx context.Context) (*api.MergeRequest, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	mr, err := a.client.MergeRequests().Get(a.repo.Owner, a.repo.Name, a.id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request: %w", err)
+	}
+	if mr.State() != "open" {
+		return nil, fmt.Errorf("merge request #%d was %s meanwhile", a.id, mr.State())
+	}
+	if mr.SourceBranch.Hash != a.sha {
+		return nil, fmt.Errorf("new commits were pushed to %s (%s → %s)",
+			mr.SourceBranch.Title, shortSHA(a.sha), shortSHA(mr.SourceBranch.Hash))
+	}
+	if mr.HasConflicts {
+		return nil, fmt.Errorf("merge request #%d has conflicts", a.id)
+	}
+	return mr, nil
+}
+
+// runAutoMerge runs an auto-merge in the foreground, recording its
+// progress for 'gf mr view'
+func runAutoMerge(client *api.Client, repo *git.Repository, mr *api.MergeRequest, opts *mergeOptions, req *api.MergeMRRequest) error {
+	if mr.SourceBranch.Hash == "" {
+		return fmt.Errorf("could not determine head commit of %s", mr.SourceBranch.Title)
+	}
+
+	// Ctrl+C cancels the auto-merge
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	st := &autoMergeState{
+		PID:       os.Getpid(),
+		Status:    autoMergeWaiting,
+		SHA:       mr.SourceBranch.Hash,
+		StartedAt: time.Now(),
+	}
+	// Keep the log path recorded by the process that started the worker
+	if prev, _ := loadAutoMerge(repo, mr.LocalID); prev != nil && opts.worker {
+		st.Log = prev.Log
+	}
+
+	a := &autoMerger{
+		client:   client,
+		repo:     repo,
+		id:       mr.LocalID,
+		sha:      mr.SourceBranch.Hash,
+		interval: time.Duration(opts.interval) * time.Second,
+		merge:    req,
+		report: func(status, message string, pipeline int) {
+			if message != st.Message {
+				fmt.Printf("%s %s\n", time.Now().Format("15:04:05"), capitalizeFirst(message))
+			}
+			st.Status, st.Message = status, message
+			if pipeline > 0 {
+				st.Pipeline = pipeline
+			}
+			saveAutoMerge(repo, mr.LocalID, st)
+		},
+	}
+
+	fmt.Printf("Auto-merge enabled for #%d at %s; it will be merged when the pipeline succeeds\n",
+		mr.LocalID, shortSHA(a.sha))
+
+	err := a.run(ctx, mr)
+	switch {
+	case err == nil:
+		a.report(autoMergeMerged, "merged", 0)
+	case errors.Is(err, context.DeadlineExceeded):
+		a.report(autoMergeFailed, fmt.Sprintf("timed out after %s", opts.timeout), 0)
+		return fmt.Errorf("auto-merge of #%d timed out after %s", mr.LocalID, opts.timeout)
+	case errors.Is(err, context.Canceled):
+		a.report(autoMergeCanceled, "canceled", 0)
+		return fmt.Errorf("auto-merge of #%d canceled", mr.LocalID)
+	default:
+		a.report(autoMergeFailed, err.Error(), 0)
+		return fmt.Errorf("auto-merge of #%d aborted: %w", mr.LocalID, err)
+	}
+
+	fmt.Printf("✓ Merged merge request #%d (%s → %s)\n", mr.LocalID, mr.SourceBranch.Title, mr.TargetBranch.Title)
+	if opts.deleteBranch {
+		fmt.Printf("✓ Deleted branch %s\n", mr.SourceBranch.Title)
+	}
+	return nil
+}
+
+// startBackgroundAutoMerge runs the auto-merge in a detached gf process
+func startBackgroundAutoMerge(repo *git.Repository, mr *api.MergeRequest, opts *mergeOptions, req *api.MergeMRRequest) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate gf executable: %w", err)
+	}
+
+	statePath, err := autoMergePath(repo, mr.LocalID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(statePath), 0700); err != nil {
+		return err
+	}
+	logPath := strings.TrimSuffix(statePath, ".json") + ".log"
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+	defer logFile.Close()
+
+	args := workerArgs(repo, mr.LocalID, opts, req)
+
+	// Recorded before the worker starts; the worker then takes it over
+	st := &autoMergeState{
+		Status:    autoMergeWaiting,
+		Message:   "starting",
+		SHA:       mr.SourceBranch.Hash,
+		Log:       logPath,
+		StartedAt: time.Now(),
+	}
+	if err := saveAutoMerge(repo, mr.LocalID, st); err != nil {
+		return fmt.Errorf("failed to save auto-merge state: %w", err)
+	}
+
+	cmd := exec.Command(exe, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		st.Status, st.Message = autoMergeFailed, err.Error()
+		saveAutoMerge(repo, mr.LocalID, st)
+		return fmt.Errorf("failed to start background auto-merge: %w", err)
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+
+	fmt.Printf("✓ Auto-merge of #%d started in the background (pid %d)\n", mr.LocalID, pid)
+	fmt.Printf("  Check progress with: gf mr view %d\n", mr.LocalID)
+	fmt.Printf("  Log: %s\n", logPath)
+	return nil
+}
+
+// workerArgs returns the 'gf mr merge' arguments of the background worker.
+// --repo includes the host so the worker finds the same state file on any
+// GitFlic instance.
+func workerArgs(repo *git.Repository, id int, opts *mergeOptions, req *api.MergeMRRequest) []string {
+	args := []string{"mr", "merge", strconv.Itoa(id), "--auto", "--yes",
+		"--repo", repo.Host + "/" + repo.FullName(),
+		"--interval", strconv.Itoa(opts.interval),
+		"--timeout", opts.timeout.String(),
+		"--" + workerFlag,
+	}
+	return append(args, mergeArgs(req, opts.force)...)
+}
+
+// mergeArgs converts merge parameters back into 'gf mr merge' flags for
+// the background worker
+func mergeArgs(req *api.MergeMRRequest, force bool) []string {
+	var args []string
+	switch req.MergeMethod {
+	case api.MergeMethodSquash:
+		args = append(args, "--squash")
+	case api.MergeMethodRebase:
+		args = append(args, "--rebase")
+	case api.MergeMethodMerge:
+		args = append(args, "--merge")
+	}
+	if req.RemoveSourceBranch {
+		args = append(args, "--delete-branch")
+	}
+	if req.MergeCommitMessage != "" {
+		args = append(args, "--message="+req.MergeCommitMessage)
+	}
+	if req.SquashCommitMessage != "" {
+		args = append(args, "--squash-message="+req.SquashCommitMessage)
+	}
+	if force {
+		args = append(args, "--admin")
+	}
+	return args
+}
+
+// printAutoMerge prints the recorded auto-merge of a merge request, if any
+func printAutoMerge(repo *git.Repository, mr *api.MergeRequest) {
+	st, err := loadAutoMerge(repo, mr.LocalID)
+	if err != nil || st == nil {
+		return
+	}
+	if line := describeAutoMerge(st, mr.State()); line != "" {
+		fmt.Println(line)
+	}
+}
+
+// describeAutoMerge formats an auto-merge state for a merge request in
+// the given state. Finished auto-merges are always reported; a waiting one
+// is hidden once the MR is no longer open and reported as stopped if its
+// worker process is gone.
+func describeAutoMerge(st *autoMergeState, mrState string) string {
+	if st.Status == autoMergeWaiting {
+		// The worker stops once the MR is merged or closed
+		if mrState != "open" {
+			return ""
+		}
+		if st.PID > 0 && !processAlive(st.PID) {
+			st.Status = autoMergeStopped
+			st.Message = fmt.Sprintf("worker (pid %d) exited while %s", st.PID, st.Message)
+		}
+	}
+
+	switch st.Status {
+	case autoMergeWaiting:
+		if st.PID > 0 {
+			return fmt.Sprintf("Auto-merge: %s (pid %d, updated %s)",
+				st.Message, st.PID, output.FormatRelativeTime(st.UpdatedAt))
+		}
+		return fmt.Sprintf("Auto-merge: %s", st.Message)
+	case autoMergeMerged:
+		return fmt.Sprintf("Auto-merge: merged %s", output.FormatRelativeTime(st.UpdatedAt))
+	default:
+		return fmt.Sprintf("Auto-merge: %s — %s (%s)",
+			st.Status, st.Message, output.FormatRelativeTime(st.UpdatedAt))
+	}
+}
+
+// shortSHA returns the abbreviated form of a commit hash
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// capitalizeFirst upper-cases the first letter of a message
+func capitalizeFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
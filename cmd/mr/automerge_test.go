@@ -0,0 +1,200 @@
+package mr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+// fakeAutoMergeServer serves a merge request whose pipeline goes through
+// pipelineStatuses; headAfter changes the source branch head after that
+// many merge request fetches (0 = never)
+func fakeAutoMergeServer(t *testing.T, pipelineStatuses []string, headAfter int32, merged *int32) *httptest.Server {
+	var mrFetches, pipelinePolls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/project/owner/repo/merge-request/7":
+			n := atomic.AddInt32(&mrFetches, 1)
+			hash := "aaa111"
+			if headAfter > 0 && n > headAfter {
+				hash = "bbb222"
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"localId":      7,
+				"status":       map[string]string{"id": "OPENED"},
+				"sourceBranch": map[string]string{"title": "feature", "hash": hash},
+				"targetBranch": map[string]string{"title": "main"},
+				"canMerge":     true,
+			})
+		case "/project/owner/repo/merge-request/7/merge":
+			atomic.AddInt32(merged, 1)
+		case "/project/owner/repo/merge-request/7/approvals":
+			w.Write([]byte(`{"approvalsRequired": 1, "approvals": [{"user": {"username": "bob"}}]}`))
+		case "/project/owner/repo/cicd/pipeline":
+			w.Write([]byte(`{"_embedded": {"restPipelineModelList": [
+				{"localId": 3, "status": "RUNNING", "ref": "feature", "commitId": "aaa111"}
+			]}}`))
+		case "/project/owner/repo/cicd/pipeline/3":
+			n := atomic.AddInt32(&pipelinePolls, 1)
+			status := pipelineStatuses[min(int(n)-1, len(pipelineStatuses)-1)]
+			json.NewEncoder(w).Encode(map[string]any{"localId": 3, "status": status})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAutoMerger_Run(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		name      string
+		statuses  []string
+		headAfter int32
+		wantErr   string
+		wantMerge bool
+	}{
+		{"pipeline succeeds", []string{"PENDING", "RUNNING", "SUCCESS"}, 0, "", true},
+		{"pipeline fails", []string{"RUNNING", "FAILED"}, 0, "pipeline #3 failed", false},
+		{"new commits pushed", []string{"RUNNING", "RUNNING", "RUNNING", "SUCCESS"}, 2, "new commits were pushed to feature", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var merged int32
+			server := fakeAutoMergeServer(t, tt.statuses, tt.headAfter, &merged)
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test-token")
+			mr, err := client.MergeRequests().Get("owner", "repo", 7)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var messages []string
+			a := &autoMerger{
+				client:   client,
+				repo:     &git.Repository{Host: "example.com", Owner: "owner", Name: "repo"},
+				id:       7,
+				sha:      mr.SourceBranch.Hash,
+				interval: time.Millisecond,
+				merge:    &api.MergeMRRequest{},
+				report: func(status, message string, pipeline int) {
+					messages = append(messages, message)
+				},
+			}
+
+			err = a.run(context.Background(), mr)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if (merged > 0) != tt.wantMerge {
+				t.Errorf("merged = %d, want merge %v", merged, tt.wantMerge)
+			}
+			if len(messages) == 0 || !strings.Contains(messages[0], "pipeline #3") {
+				t.Errorf("messages = %v", messages)
+			}
+		})
+	}
+}
+
+func TestAutoMergeState_SaveLoad(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+	repo := &git.Repository{Host: "gitflic.ru", Owner: "owner", Name: "repo"}
+
+	if st, err := loadAutoMerge(repo, 7); err != nil || st != nil {
+		t.Fatalf("loadAutoMerge() = %v, %v; want nil, nil", st, err)
+	}
+
+	want := &autoMergeState{PID: 42, Status: autoMergeWaiting, Message: "waiting for pipeline #3 (running)", Pipeline: 3, SHA: "aaa111"}
+	if err := saveAutoMerge(repo, 7, want); err != nil {
+		t.Fatalf("saveAutoMerge() error: %v", err)
+	}
+
+	got, err := loadAutoMerge(repo, 7)
+	if err != nil {
+		t.Fatalf("loadAutoMerge() error: %v", err)
+	}
+	if got.PID != 42 || got.Message != want.Message || got.Pipeline != 3 || got.UpdatedAt.IsZero() {
+		t.Errorf("loadAutoMerge() = %+v", got)
+	}
+
+	// Other merge requests are not affected
+	if st, _ := loadAutoMerge(repo, 8); st != nil {
+		t.Errorf("loadAutoMerge(8) = %+v, want nil", st)
+	}
+}
+
+func TestWorkerArgs_KeepHost(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+	repo := &git.Repository{Host: "git.example.com", Owner: "owner", Name: "repo"}
+
+	args := workerArgs(repo, 7, &mergeOptions{interval: 10, timeout: time.Hour}, &api.MergeMRRequest{})
+	var repoFlag string
+	for i, arg := range args {
+		if arg == "--repo" && i+1 < len(args) {
+			repoFlag = args[i+1]
+		}
+	}
+	// The worker must resolve the repository to the same state file
+	workerRepo, err := git.ParseRepoFlag(repoFlag, "gitflic.ru")
+	if err != nil {
+		t.Fatalf("worker --repo %q: %v", repoFlag, err)
+	}
+	want, _ := autoMergePath(repo, 7)
+	if got, _ := autoMergePath(workerRepo, 7); got != want {
+		t.Errorf("worker state file = %s, want %s", got, want)
+	}
+}
+
+func TestDescribeAutoMerge(t *testing.T) {
+	// A finished child process gives a pid that is no longer running
+	cmd := exec.Command("git", "--version")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot run a child process: %v", err)
+	}
+	deadPID := cmd.Process.Pid
+
+	tests := []struct {
+		name    string
+		st      autoMergeState
+		mrState string
+		want    string
+	}{
+		{"waiting, worker running", autoMergeState{PID: os.Getpid(), Status: autoMergeWaiting, Message: "waiting for pipeline #3 (running)"}, "open", "Auto-merge: waiting for pipeline #3 (running) (pid"},
+		{"waiting, worker gone", autoMergeState{PID: deadPID, Status: autoMergeWaiting, Message: "waiting for pipeline #3 (running)"}, "open", "Auto-merge: stopped — worker (pid"},
+		{"waiting on closed MR", autoMergeState{PID: os.Getpid(), Status: autoMergeWaiting, Message: "starting"}, "closed", ""},
+		{"merged MR", autoMergeState{Status: autoMergeMerged}, "merged", "Auto-merge: merged"},
+		{"failed on closed MR", autoMergeState{Status: autoMergeFailed, Message: "pipeline #3 failed"}, "closed", "Auto-merge: failed — pipeline #3 failed"},
+	}
+	for _, tt := range tests {
+		got := describeAutoMerge(&tt.st, tt.mrState)
+		if !strings.HasPrefix(got, tt.want) || (tt.want == "") != (got == "") {
+			t.Errorf("%s: describeAutoMerge() = %q, want prefix %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMergeCmd_BackgroundRequiresAuto(t *testing.T) {
+	cmd := newMergeCmd()
+	cmd.SetArgs([]string{"7", "--background"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--background requires --auto") {
+		t.Errorf("err = %v, want --background requires --auto", err)
+	}
+}
@@ -0,0 +1,315 @@
+package mr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/bulk"
+	"github.com/spf13/cobra"
+)
+
+type bulkOptions struct {
+	listOptions
+	stdin           bool
+	close           bool
+	reopen          bool
+	addLabels       []string
+	removeLabels    []string
+	addAssignees    []string
+	removeAssignees []string
+	comment         string
+	concurrency     int
+	dryRun          bool
+	yes             bool
+}
+
+// bulkActions are the resolved changes applied to every merge request
+type bulkActions struct {
+	close           bool
+	reopen          bool
+	addLabels       []api.Label
+	removeLabels    []string
+	addAssignees    []api.User
+	removeAssignees []api.User
+	comment         string
+}
+
+func newBulkCmd() *cobra.Command {
+	opts := &bulkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "bulk [<id>...]",
+		Short: "Change many merge requests at once",
+		Long: `Close, reopen, label, assign or comment on many merge requests at once.
+
+Merge requests are selected with the filters of 'gf mr list', by ID, or by
+IDs read from stdin with --stdin (one per line). The selected merge
+requests are listed and must be confirmed before anything changes;
+--dry-run only lists them.
+
+Merge requests are processed concurrently. Failures do not stop the run;
+they are listed at the end and gf exits with status 1.`,
+		Example: `  # Close my stale draft merge requests
+  gf mr bulk --author @me --draft --close
+
+  # Label and assign merge requests by ID
+  gf mr bulk 12 13 15 --add-label backend --add-assignee alice
+
+  # Comment on every open merge request into release, without confirmation
+  gf mr bulk --target release --comment "Release is frozen" --yes
+
+  # IDs from another command
+  gf mr list --label wip --json | jq '.[].localId' | gf mr bulk --stdin --remove-label wip --yes
+
+  # Only show what would change
+  gf mr bulk --label obsolete --close --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validate(); err != nil {
+				return err
+			}
+			if (len(args) > 0 || opts.stdin) && (opts.hasFilters() || cmd.Flags().Changed("state")) {
+				return fmt.Errorf("filters cannot be combined with IDs")
+			}
+			if len(args) > 0 && opts.stdin {
+				return fmt.Errorf("IDs cannot be combined with --stdin")
+			}
+			if opts.stdin && !opts.yes && !opts.dryRun {
+				return fmt.Errorf("--yes is required with --stdin, since stdin cannot be used for confirmation")
+			}
+			return runBulk(opts, args)
+		},
+	}
+
+	addListFlags(cmd, &opts.listOptions, 0)
+	cmd.Flags().BoolVar(&opts.stdin, "stdin", false, "Read merge request IDs from stdin")
+	cmd.Flags().BoolVar(&opts.close, "close", false, "Close the merge requests")
+	cmd.Flags().BoolVar(&opts.reopen, "reopen", false, "Reopen the merge requests")
+	cmd.Flags().StringSliceVar(&opts.addLabels, "add-label", nil, "Add labels")
+	cmd.Flags().StringSliceVar(&opts.removeLabels, "remove-label", nil, "Remove labels")
+	cmd.Flags().StringSliceVar(&opts.addAssignees, "add-assignee", nil, "Add assignees (username or @me)")
+	cmd.Flags().StringSliceVar(&opts.removeAssignees, "remove-assignee", nil, "Remove assignees (username or @me)")
+	cmd.Flags().StringVar(&opts.comment, "comment", "", "Add a comment")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "j", bulk.DefaultConcurrency, "Number of merge requests changed at once")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "List the selected merge requests without changing them")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation")
+	cmd.MarkFlagsMutuallyExclusive("close", "reopen")
+
+	return cmd
+}
+
+// validate checks the filters and the requested actions
+func (opts *bulkOptions) validate() error {
+	if err := opts.listOptions.validate(); err != nil {
+		return err
+	}
+	if !opts.close && !opts.reopen && len(opts.addLabels) == 0 && len(opts.removeLabels) == 0 &&
+		len(opts.addAssignees) == 0 && len(opts.removeAssignees) == 0 && strings.TrimSpace(opts.comment) == "" {
+		return fmt.Errorf("nothing to do: use --close, --reopen, --add-label, --remove-label, --add-assignee, --remove-assignee or --comment")
+	}
+	if opts.concurrency < 1 || opts.concurrency > bulk.MaxConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", bulk.MaxConcurrency)
+	}
+	return nil
+}
+
+func runBulk(opts *bulkOptions, args []string) error {
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	var items []bulk.Item
+	switch {
+	case opts.stdin:
+		ids, err := bulk.ReadIDs(os.Stdin)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			items = append(items, bulk.Item{ID: id})
+		}
+	case len(args) > 0:
+		seen := make(map[int]bool)
+		for _, arg := range args {
+			id, err := bulk.ParseID(arg)
+			if err != nil {
+				return err
+			}
+			if !seen[id] {
+				seen[id] = true
+				items = append(items, bulk.Item{ID: id})
+			}
+		}
+	default:
+		filter, err := opts.listFilter(client)
+		if err != nil {
+			return err
+		}
+		mrs, truncated, err := client.MergeRequests().ListTruncated(repo.Owner, repo.Name, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list merge requests: %w", err)
+		}
+		// A partial selection must not pass for the complete one
+		if truncated {
+			if opts.limit == 0 {
+				return fmt.Errorf("too many merge requests to check them all; the selection would be incomplete\n" +
+					"Narrow the filters or pass --limit to change only the first matches")
+			}
+			fmt.Fprintf(os.Stderr, "! Stopped after the maximum number of pages; fewer than %d merge requests may be selected\n", opts.limit)
+		}
+		sortMergeRequests(mrs, opts.sort)
+		if opts.limit > 0 && len(mrs) > opts.limit {
+			mrs = mrs[:opts.limit]
+		}
+		for _, mr := range mrs {
+			items = append(items, bulk.Item{ID: mr.LocalID, Title: mr.Title})
+		}
+	}
+
+	if len(items) == 0 {
+		if opts.stdin {
+			fmt.Println("No merge request IDs on stdin")
+		} else {
+			fmt.Printf("No %s merge requests match your filters in %s\n", opts.state, repo.FullName())
+		}
+		return nil
+	}
+
+	// Resolve users and labels once, so typos fail before anything changes
+	actions := &bulkActions{
+		close:        opts.close,
+		reopen:       opts.reopen,
+		removeLabels: opts.removeLabels,
+		comment:      strings.TrimSpace(opts.comment),
+	}
+	users := newUserResolver(client)
+	if actions.addAssignees, err = users.resolve(opts.addAssignees); err != nil {
+		return err
+	}
+	if actions.removeAssignees, err = users.resolve(opts.removeAssignees); err != nil {
+		return err
+	}
+	if actions.addLabels, err = resolveLabels(client, repo.Owner, repo.Name, opts.addLabels); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d merge requests in %s:\n", len(items), repo.FullName())
+	bulk.WritePreview(os.Stdout, items)
+	fmt.Printf("\nActions: %s\n", actions.describe())
+
+	if opts.dryRun {
+		fmt.Println("\nDry run: no changes made")
+		return nil
+	}
+
+	if !opts.yes {
+		fmt.Printf("\nApply to %d merge requests? [y/N] ", len(items))
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+	fmt.Println()
+
+	failures := bulk.Run(items, opts.concurrency, os.Stdout, func(item bulk.Item) error {
+		return actions.apply(client, repo.Owner, repo.Name, item.ID)
+	})
+	bulk.WriteSummary(os.Stdout, len(items), failures)
+	if len(failures) > 0 {
+		return api.NewExitError(1)
+	}
+	return nil
+}
+
+// describe lists the actions for the preview, in the order they are applied
+func (a *bulkActions) describe() string {
+	var parts []string
+	if a.comment != "" {
+		parts = append(parts, "comment")
+	}
+	if len(a.addLabels) > 0 {
+		parts = append(parts, "add labels "+formatLabels(a.addLabels))
+	}
+	if len(a.removeLabels) > 0 {
+		parts = append(parts, "remove labels "+strings.Join(a.removeLabels, ", "))
+	}
+	if len(a.addAssignees) > 0 {
+		parts = append(parts, "assign "+formatUsers(a.addAssignees))
+	}
+	if len(a.removeAssignees) > 0 {
+		parts = append(parts, "unassign "+formatUsers(a.removeAssignees))
+	}
+	if a.close {
+		parts = append(parts, "close")
+	}
+	if a.reopen {
+		parts = append(parts, "reopen")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// apply makes the changes to one merge request. Closing a closed or
+// reopening an open merge request is not an error.
+func (a *bulkActions) apply(client *api.Client, owner, project string, id int) error {
+	mr, err := client.MergeRequests().Get(owner, project, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("not found")
+		}
+		return err
+	}
+	if (a.close || a.reopen) && mr.State() == "merged" {
+		return fmt.Errorf("merge request is merged")
+	}
+
+	if a.comment != "" {
+		if _, err := client.MergeRequests().CreateDiscussion(owner, project, id, &api.CreateDiscussionRequest{Message: a.comment}); err != nil {
+			return fmt.Errorf("failed to comment: %w", err)
+		}
+	}
+
+	req := &api.UpdateMRRequest{}
+	changed := false
+	if len(a.addAssignees) > 0 || len(a.removeAssignees) > 0 {
+		refs := mergeUsers(mr.Assignees, a.addAssignees, a.removeAssignees)
+		req.AssignedUsers = &refs
+		changed = true
+	}
+	if len(a.addLabels) > 0 || len(a.removeLabels) > 0 {
+		// Removing only needs labels present on the merge request
+		var remove []api.Label
+		for _, title := range a.removeLabels {
+			for _, l := range mr.Labels {
+				if strings.EqualFold(l.Title, title) {
+					remove = append(remove, l)
+				}
+			}
+		}
+		refs := mergeLabels(mr.Labels, a.addLabels, remove)
+		req.Labels = &refs
+		changed = true
+	}
+	if changed {
+		if _, err := client.MergeRequests().Update(owner, project, id, req); err != nil {
+			return fmt.Errorf("failed to update: %w", err)
+		}
+	}
+
+	switch {
+	case a.close && mr.State() == "open":
+		if err := client.MergeRequests().Close(owner, project, id); err != nil {
+			return fmt.Errorf("failed to close: %w", err)
+		}
+	case a.reopen && mr.State() == "closed":
+		if err := client.MergeRequests().Reopen(owner, project, id); err != nil {
+			return fmt.Errorf("failed to reopen: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package mr
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestBulkActionsApply(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		update   api.UpdateMRRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/project/owner/repo/merge-request/"))
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/1"):
+			w.Write([]byte(`{"localId": 1, "status": {"id": "OPEN"},
+				"assignedUsers": [{"id": "u1"}],
+				"labels": [{"id": "l1", "title": "wip"}, {"id": "l2", "title": "ui"}]}`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/2"):
+			w.Write([]byte(`{"localId": 2, "status": {"id": "MERGED"}}`))
+		case r.Method == http.MethodGet:
+			http.NotFound(w, r)
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &update)
+			w.Write([]byte(`{"localId": 1}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+
+	actions := &bulkActions{
+		close:        true,
+		addLabels:    []api.Label{{ID: "l3", Title: "backend"}},
+		removeLabels: []string{"WIP"},
+		addAssignees: []api.User{{ID: "u2"}},
+	}
+	if err := actions.apply(client, "owner", "repo", 1); err != nil {
+		t.Fatalf("apply(#1) failed: %v", err)
+	}
+	if want := []string{"GET 1", "PUT 1", "POST 1/close"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if update.Labels == nil || !reflect.DeepEqual(*update.Labels, []api.LabelRef{{ID: "l2"}, {ID: "l3"}}) {
+		t.Errorf("labels = %v, want [l2 l3]", update.Labels)
+	}
+	if update.AssignedUsers == nil || !reflect.DeepEqual(*update.AssignedUsers, []api.UserRef{{ID: "u1"}, {ID: "u2"}}) {
+		t.Errorf("assignees = %v, want [u1 u2]", update.AssignedUsers)
+	}
+
+	requests = nil
+	if err := actions.apply(client, "owner", "repo", 2); err == nil || err.Error() != "merge request is merged" {
+		t.Errorf("apply(merged) error = %v", err)
+	}
+	if err := actions.apply(client, "owner", "repo", 3); err == nil || err.Error() != "not found" {
+		t.Errorf("apply(missing) error = %v", err)
+	}
+	if want := []string{"GET 2", "GET 3"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v (nothing changed on failure)", requests, want)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -49,7 +48,7 @@ func newCheckoutCmd() *cobra.Command {
 	opts := &checkoutOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "checkout <id>",
+		Use:   "checkout [<id> | <branch> | <url>]",
 		Short: "Check out a merge request locally",
 		Long: `Check out the source branch of a merge request locally.
 
@@ -62,11 +61,11 @@ This fetches the branch from the remote and switches to it.`,
 
   # Force checkout (discard local changes)
   gf mr checkout 42 --force`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runCheckout(opts, id)
 		},
@@ -97,7 +96,7 @@ func runCheckout(opts *checkoutOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR details
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -124,7 +123,21 @@ func runCheckout(opts *checkoutOptions, id int) error {
 	}
 
 	fmt.Printf("Checking out MR #%d: %s\n", mr.LocalID, mr.Title)
-	fmt.Printf("Source branch: %s\n", remoteBranch)
+
+	// Where to fetch from: origin, or the fork's clone URL
+	fetchFrom := "origin"
+	startPoint := "origin/" + remoteBranch
+	if mr.IsCrossProject() {
+		forkURL, err := forkCloneURL(client, mr.SourceProject)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Source branch: %s (fork %s/%s)\n", remoteBranch, mr.SourceProject.Owner.Alias, mr.SourceProject.Alias)
+		fetchFrom = forkURL
+		startPoint = "FETCH_HEAD"
+	} else {
+		fmt.Printf("Source branch: %s\n", remoteBranch)
+	}
 
 	// Create context with timeout for git operations
 	ctx, cancel := context.WithTimeout(context.Background(), gitCommandTimeout)
@@ -132,7 +145,7 @@ func runCheckout(opts *checkoutOptions, id int) error {
 
 	// Fetch the branch
 	fmt.Println("Fetching from remote...")
-	fetchCmd := exec.CommandContext(ctx, "git", "fetch", "origin", remoteBranch)
+	fetchCmd := exec.CommandContext(ctx, "git", "fetch", fetchFrom, remoteBranch)
 	fetchCmd.Stdout = os.Stdout
 	fetchCmd.Stderr = os.Stderr
 	if err := fetchCmd.Run(); err != nil {
@@ -159,7 +172,7 @@ func runCheckout(opts *checkoutOptions, id int) error {
 		checkoutArgs = append(checkoutArgs, localBranch)
 	} else {
 		// Create new branch tracking remote
-		checkoutArgs = append(checkoutArgs, "-b", localBranch, "origin/"+remoteBranch)
+		checkoutArgs = append(checkoutArgs, "-b", localBranch, startPoint)
 	}
 
 	fmt.Printf("Switching to branch '%s'...\n", localBranch)
@@ -173,6 +186,55 @@ func runCheckout(opts *checkoutOptions, id int) error {
 		return fmt.Errorf("failed to checkout branch: %w", err)
 	}
 
+	// Let 'git pull' and 'git push' work against the fork branch
+	if mr.IsCrossProject() && !branchExists {
+		exec.CommandContext(ctx, "git", "config", "branch."+localBranch+".remote", fetchFrom).Run()
+		exec.CommandContext(ctx, "git", "config", "branch."+localBranch+".merge", "refs/heads/"+remoteBranch).Run()
+	}
+
 	fmt.Printf("\n✓ Checked out MR #%d on branch '%s'\n", mr.LocalID, localBranch)
 	return nil
 }
+
+// forkCloneURL returns the clone URL of a fork project, matching the
+// transport (SSH or HTTPS) used by origin
+func forkCloneURL(client *api.Client, fork *api.Project) (string, error) {
+	if fork.HTTPTransportURL == "" && fork.SSHTransportURL == "" {
+		// Merge request responses may carry only a project reference
+		if fork.Owner.Alias == "" || fork.Alias == "" {
+			return "", fmt.Errorf("source project of the merge request is unknown")
+		}
+		full, err := client.Projects().Get(fork.Owner.Alias, fork.Alias)
+		if err != nil {
+			return "", fmt.Errorf("failed to get fork %s/%s: %w", fork.Owner.Alias, fork.Alias, err)
+		}
+		fork = full
+	}
+
+	var cloneURL string
+	originURL, _ := git.RemoteURL("origin")
+	switch {
+	case git.IsSSHURL(originURL) && fork.SSHTransportURL != "":
+		cloneURL = fork.SSHTransportURL
+	case fork.HTTPTransportURL != "":
+		cloneURL = fork.HTTPTransportURL
+	default:
+		cloneURL = fork.SSHTransportURL
+	}
+
+	// Security: the URL comes from the API and is passed to git
+	if !validCloneURL(cloneURL) {
+		return "", fmt.Errorf("fork %s/%s has no valid clone URL", fork.Owner.Alias, fork.Alias)
+	}
+	return cloneURL, nil
+}
+
+// validCloneURL checks that url is an HTTPS or SSH git URL and cannot be
+// mistaken for a git option
+func validCloneURL(url string) bool {
+	if strings.ContainsAny(url, " \t\n") {
+		return false
+	}
+	return strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") ||
+		strings.HasPrefix(url, "ssh://") || strings.HasPrefix(url, "git@")
+}
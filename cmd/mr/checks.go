@@ -0,0 +1,292 @@
+package mr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Exit codes of 'gf mr checks'
+const (
+	checksExitFailed  = 1
+	checksExitPending = 8
+)
+
+// errJobFailed stops watching when --fail-fast is set
+var errJobFailed = errors.New("a job failed")
+
+type checksOptions struct {
+	repo     string
+	watch    bool
+	failFast bool
+	interval int
+	json     bool
+}
+
+func newChecksCmd() *cobra.Command {
+	opts := &checksOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "checks [<id> | <branch> | <url>]",
+		Short: "Show CI status of a merge request",
+		Long: `Show the pipeline and job status for the head commit of a merge
+request's source branch.
+
+Without an ID, the merge request of the current branch is used.
+
+Exit codes: 0 if the pipeline passed, 1 if it failed or was canceled,
+8 if it is still running (without --watch).`,
+		Example: `  # CI status of MR #12
+  gf mr checks 12
+
+  # CI status of the current branch's MR
+  gf mr checks
+
+  # Wait for the pipeline, stop at the first failed job
+  gf mr checks 12 --watch --fail-fast`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.failFast && !opts.watch {
+				return fmt.Errorf("--fail-fast requires --watch")
+			}
+			if opts.interval < 1 {
+				return fmt.Errorf("--interval must be at least 1 second")
+			}
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			return runChecks(opts, id)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVarP(&opts.watch, "watch", "w", false, "Watch until the pipeline finishes")
+	cmd.Flags().BoolVar(&opts.failFast, "fail-fast", false, "Stop watching as soon as a job fails")
+	cmd.Flags().IntVarP(&opts.interval, "interval", "i", 10, "Refresh interval in seconds for --watch")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runChecks(opts *checksOptions, id int) error {
+	// Get repository
+	repo, err := git.ResolveRepo(opts.repo, config.DefaultHost())
+	if err != nil {
+		return fmt.Errorf("could not determine repository: %w", err)
+	}
+
+	// Load config and create client
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found", id)
+		}
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pipeline, err := client.Pipelines().LatestForCommit(ctx, repo.Owner, repo.Name, mr.SourceBranch.Title, mr.SourceBranch.Hash)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("no pipelines for %s at %s", mr.SourceBranch.Title, shortSHA(mr.SourceBranch.Hash))
+		}
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	if !opts.watch {
+		jobs, err := client.Pipelines().JobsWithContext(ctx, repo.Owner, repo.Name, pipeline.LocalID)
+		if err != nil {
+			return fmt.Errorf("failed to get jobs: %w", err)
+		}
+		if err := printChecks(mr, pipeline, jobs, opts.json); err != nil {
+			return err
+		}
+		return checksExit(pipeline)
+	}
+
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	first := true
+	var jobs []api.Job
+	pipeline, err = client.Pipelines().Watch(ctx, repo.Owner, repo.Name, pipeline.LocalID,
+		time.Duration(opts.interval)*time.Second, func(p *api.Pipeline) error {
+			var err error
+			jobs, err = client.Pipelines().JobsWithContext(ctx, repo.Owner, repo.Name, p.LocalID)
+			if err != nil {
+				return fmt.Errorf("failed to get jobs: %w", err)
+			}
+
+			// JSON is only printed once the pipeline finishes
+			if !opts.json || p.IsFinished() || (opts.failFast && hasFailedJob(jobs)) {
+				if !first && !opts.json {
+					if isTTY {
+						fmt.Print("\033[H\033[2J")
+					} else {
+						fmt.Println("\n---")
+					}
+				}
+				first = false
+				if err := printChecks(mr, p, jobs, opts.json); err != nil {
+					return err
+				}
+			}
+
+			if opts.failFast && hasFailedJob(jobs) {
+				return errJobFailed
+			}
+			return nil
+		})
+	switch {
+	case ctx.Err() != nil:
+		// Keep stdout valid JSON for scripts
+		status := os.Stdout
+		if opts.json {
+			status = os.Stderr
+		}
+		fmt.Fprintln(status, "\nStopped watching.")
+		return api.NewExitError(checksExitPending)
+	case errors.Is(err, errJobFailed):
+		return api.NewExitError(checksExitFailed)
+	case err != nil:
+		return err
+	}
+
+	return checksExit(pipeline)
+}
+
+// printChecks prints the pipeline of a merge request and its jobs
+func printChecks(mr *api.MergeRequest, pipeline *api.Pipeline, jobs []api.Job, asJSON bool) error {
+	if asJSON {
+		result := struct {
+			MergeRequest int           `json:"merge_request"`
+			Pipeline     *api.Pipeline `json:"pipeline"`
+			Jobs         []api.Job     `json:"jobs"`
+		}{mr.LocalID, pipeline, jobs}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\nMerge request #%d: %s\n", mr.LocalID, mr.Title)
+	fmt.Printf("Pipeline #%d for %s (%s) - %s%s %s%s\n\n",
+		pipeline.LocalID,
+		pipeline.Ref,
+		pipeline.SHA(),
+		api.StatusColor(pipeline.Status),
+		api.StatusIcon(pipeline.Status),
+		pipeline.NormalizedStatus(),
+		api.ColorReset(),
+	)
+
+	if len(jobs) > 0 {
+		fmt.Printf("%-12s %-25s %-12s %s\n", "STAGE", "NAME", "STATUS", "DURATION")
+		fmt.Println(strings.Repeat("-", 60))
+		for _, job := range jobs {
+			status := fmt.Sprintf("%s %s", api.StatusIcon(job.Status), job.NormalizedStatus())
+			fmt.Printf("%-12s %-25s %-12s %s\n",
+				job.Stage,
+				truncateTitle(job.Name, 25),
+				status,
+				output.FormatDuration(job.Duration),
+			)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(jobSummary(jobs))
+	return nil
+}
+
+// jobSummary counts jobs by outcome, e.g. "2 passed, 1 failed, 1 running"
+func jobSummary(jobs []api.Job) string {
+	var passed, failed, running, other int
+	for _, job := range jobs {
+		switch job.NormalizedStatus() {
+		case "success", "passed":
+			passed++
+		case "failed", "canceled":
+			failed++
+		case "running", "pending", "created":
+			running++
+		default:
+			other++
+		}
+	}
+	parts := []string{fmt.Sprintf("%d passed", passed)}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	if running > 0 {
+		parts = append(parts, fmt.Sprintf("%d running", running))
+	}
+	if other > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", other))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// hasFailedJob reports whether any job failed
+func hasFailedJob(jobs []api.Job) bool {
+	for _, job := range jobs {
+		if job.NormalizedStatus() == "failed" {
+			return true
+		}
+	}
+	return false
+}
+
+// checksExit maps the pipeline state to the exit code of 'gf mr checks'
+func checksExit(pipeline *api.Pipeline) error {
+	switch {
+	case pipeline.Succeeded():
+		return nil
+	case pipeline.IsFinished():
+		return api.NewExitError(checksExitFailed)
+	default:
+		return api.NewExitError(checksExitPending)
+	}
+}
+
+// ciSummary returns a one-line pipeline status such as "✓ success (pipeline #45)"
+func ciSummary(p *api.Pipeline) string {
+	return fmt.Sprintf("%s%s %s%s (pipeline #%d)",
+		api.StatusColor(p.Status), api.StatusIcon(p.Status), p.NormalizedStatus(), api.ColorReset(), p.LocalID)
+}
+
+// ciIcon returns the colored status icon of p, or "-" without a pipeline
+func ciIcon(p *api.Pipeline) string {
+	if p == nil {
+		return "-"
+	}
+	return api.StatusColor(p.Status) + api.StatusIcon(p.Status) + api.ColorReset()
+}
@@ -0,0 +1,54 @@
+package mr
+
+import (
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestChecksExit(t *testing.T) {
+	tests := []struct {
+		status string
+		want   int // -1 = no error
+	}{
+		{"SUCCESS", -1},
+		{"FAILED", checksExitFailed},
+		{"CANCELED", checksExitFailed},
+		{"RUNNING", checksExitPending},
+		{"PENDING", checksExitPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			err := checksExit(&api.Pipeline{Status: tt.status})
+			if tt.want == -1 {
+				if err != nil {
+					t.Errorf("checksExit() = %v, want nil", err)
+				}
+				return
+			}
+			if !api.IsExitError(err) || api.GetExitCode(err) != tt.want {
+				t.Errorf("checksExit() = %v, want exit code %d", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobSummary(t *testing.T) {
+	jobs := []api.Job{
+		{Status: "SUCCESS"},
+		{Status: "SUCCESS"},
+		{Status: "FAILED"},
+		{Status: "RUNNING"},
+		{Status: "SKIPPED"},
+	}
+	if got, want := jobSummary(jobs), "2 passed, 1 failed, 1 running, 1 skipped"; got != want {
+		t.Errorf("jobSummary() = %q, want %q", got, want)
+	}
+	if got, want := jobSummary(nil), "0 passed"; got != want {
+		t.Errorf("jobSummary(nil) = %q, want %q", got, want)
+	}
+	if !hasFailedJob(jobs) || hasFailedJob(jobs[:2]) {
+		t.Error("hasFailedJob() mismatch")
+	}
+}
@@ -2,7 +2,6 @@ package mr
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -18,16 +17,16 @@ func newCloseCmd() *cobra.Command {
 	opts := &closeOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "close <id>",
+		Use:   "close [<id> | <branch> | <url>]",
 		Short: "Close a merge request",
 		Long:  `Close a merge request without merging it.`,
 		Example: `  # Close MR #42
   gf mr close 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(args[0])
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runClose(opts, id)
 		},
@@ -56,7 +55,7 @@ func runClose(opts *closeOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Close MR
 	if err := client.MergeRequests().Close(repo.Owner, repo.Name, id); err != nil {
@@ -2,16 +2,18 @@ package mr
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/diff"
 	"github.com/josinSbazin/gf/internal/git"
 	"github.com/josinSbazin/gf/internal/output"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 type commentOptions struct {
@@ -26,7 +28,7 @@ func newCommentCmd() *cobra.Command {
 	opts := &commentOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "comment <id>",
+		Use:   "comment [<id> | <branch> | <url>]",
 		Short: "Add a comment to a merge request",
 		Long: `Add a comment to a merge request.
 
@@ -42,12 +44,16 @@ Use --file and --line to add inline comments on specific lines.`,
   gf mr comment 42 --body "Why was this removed?" --file "utils.go" --old-line 15
 
   # Pipe comment from stdin
-  echo "LGTM" | gf mr comment 42 --body -`,
-		Args: cobra.ExactArgs(1),
+  echo "LGTM" | gf mr comment 42 --body -
+
+  # Fix or remove one of your comments
+  gf mr comment edit 42 abc12345 --body "Fixed typo"
+  gf mr comment delete 42 abc12345`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runComment(opts, id)
 		},
@@ -59,6 +65,9 @@ Use --file and --line to add inline comments on specific lines.`,
 	cmd.Flags().IntVarP(&opts.line, "line", "l", 0, "New-side line number for inline comment")
 	cmd.Flags().IntVar(&opts.oldLine, "old-line", 0, "Old-side line number for inline comment")
 
+	cmd.AddCommand(newCommentEditCmd())
+	cmd.AddCommand(newCommentDeleteCmd())
+
 	return cmd
 }
 
@@ -88,7 +97,7 @@ func runComment(opts *commentOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -123,32 +132,9 @@ func runComment(opts *commentOptions, id int) error {
 		return fmt.Errorf("comment body cannot be empty")
 	}
 
-	// Build discussion request
-	req := &api.CreateDiscussionRequest{
-		Message: body,
-	}
-
-	// Add inline comment fields if specified
-	// GitFlic API requires all four fields (newLine, oldLine, newPath, oldPath)
-	// and all line values must be > 0
-	if opts.file != "" {
-		req.NewPath = &opts.file
-		req.OldPath = &opts.file
-		newLine := opts.line
-		oldLine := opts.oldLine
-		// If only one side specified, mirror to the other (API requires both > 0)
-		if newLine > 0 && oldLine == 0 {
-			oldLine = newLine
-		}
-		if oldLine > 0 && newLine == 0 {
-			newLine = oldLine
-		}
-		req.NewLine = &newLine
-		req.OldLine = &oldLine
-	}
-
 	// Create discussion
-	_, err = client.MergeRequests().CreateDiscussion(repo.Owner, repo.Name, id, req)
+	_, err = client.MergeRequests().CreateDiscussion(repo.Owner, repo.Name, id,
+		inlineDiscussion(body, opts.file, opts.line, opts.oldLine))
 	if err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
@@ -167,36 +153,96 @@ func runComment(opts *commentOptions, id int) error {
 	return nil
 }
 
+// inlineDiscussion builds a discussion request, placed on a line of file
+// when file is set
+func inlineDiscussion(body, file string, line, oldLine int) *api.CreateDiscussionRequest {
+	req := &api.CreateDiscussionRequest{
+		Message: body,
+	}
+
+	// GitFlic API requires all four fields (newLine, oldLine, newPath, oldPath)
+	// and all line values must be > 0
+	if file != "" {
+		req.NewPath = &file
+		req.OldPath = &file
+		// If only one side specified, mirror to the other (API requires both > 0)
+		if line > 0 && oldLine == 0 {
+			oldLine = line
+		}
+		if oldLine > 0 && line == 0 {
+			line = oldLine
+		}
+		req.NewLine = &line
+		req.OldLine = &oldLine
+	}
+	return req
+}
+
+type commentsOptions struct {
+	repo       string
+	unresolved bool
+	author     string
+	file       string
+	context    int
+	json       bool
+}
+
 func newCommentsCmd() *cobra.Command {
-	opts := &struct {
-		repo string
-	}{}
+	opts := &commentsOptions{}
 
 	cmd := &cobra.Command{
-		Use:     "comments <id>",
+		Use:     "comments [<id> | <branch> | <url>]",
 		Aliases: []string{"discussions"},
 		Short:   "List comments on a merge request",
-		Long:    `List all comments and discussions on a merge request, grouped by file.`,
+		Long: `List all comments and discussions on a merge request, grouped by file.
+
+Inline comments are shown with the surrounding lines of the diff. Comments
+on lines that are no longer part of the diff are marked as outdated.`,
 		Example: `  # List comments
-  gf mr comments 42`,
-		Args: cobra.ExactArgs(1),
+  gf mr comments 42
+
+  # Open threads on Go files started by alice
+  gf mr comments 42 --unresolved --author alice --file '*.go'
+
+  # More diff context, or only the commented line
+  gf mr comments 42 -C 6
+  gf mr comments 42 -C 0`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
-			return runComments(opts.repo, id)
+			if opts.context < 0 {
+				return fmt.Errorf("--context must not be negative")
+			}
+			return runComments(opts, id)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.unresolved, "unresolved", false, "Only show unresolved threads")
+	cmd.Flags().StringVar(&opts.author, "author", "", "Only show threads started by this user")
+	cmd.Flags().StringVar(&opts.file, "file", "", "Only show inline threads on files matching this path or glob")
+	cmd.Flags().IntVarP(&opts.context, "context", "C", 3, "Lines of diff context around inline comments")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
 
 	return cmd
 }
 
-func runComments(repoFlag string, id int) error {
+// commentThread is a discussion thread with its location in the diff
+type commentThread struct {
+	api.DiscussionThread
+	Outdated bool     `json:"outdated"`
+	Context  []string `json:"context,omitempty"`
+
+	lines []diff.Line
+	at    int
+}
+
+func runComments(opts *commentsOptions, id int) error {
 	// Get repository
-	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	repo, err := git.ResolveRepo(opts.repo, config.DefaultHost())
 	if err != nil {
 		return fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
 	}
@@ -212,7 +258,7 @@ func runComments(repoFlag string, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -228,31 +274,63 @@ func runComments(repoFlag string, id int) error {
 	if err != nil {
 		return fmt.Errorf("failed to list comments: %w", err)
 	}
-
-	if len(threads) == 0 {
-		fmt.Printf("No comments on MR #%d: %s\n", mr.LocalID, mr.Title)
-		return nil
-	}
-
-	fmt.Printf("\nComments on MR #%d: %s\n", mr.LocalID, mr.Title)
-	fmt.Println(strings.Repeat("─", 60))
+	threads = filterThreads(threads, opts)
 
 	// Separate inline and general comments
-	var inlineThreads []api.DiscussionThread
-	var generalThreads []api.DiscussionThread
+	var inlineThreads []*commentThread
+	var generalThreads []*commentThread
 
 	for _, t := range threads {
+		ct := &commentThread{DiscussionThread: t}
 		if t.RootNote.NewPath != nil && *t.RootNote.NewPath != "" {
-			inlineThreads = append(inlineThreads, t)
+			inlineThreads = append(inlineThreads, ct)
+		} else {
+			generalThreads = append(generalThreads, ct)
+		}
+	}
+
+	// Locate inline comments in the current diff
+	if len(inlineThreads) > 0 {
+		files, err := mrDiffFiles(client, repo, mr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "! Could not load the diff, showing comments without context: %v\n", err)
 		} else {
-			generalThreads = append(generalThreads, t)
+			for _, ct := range inlineThreads {
+				lines, at, found := commentContext(files, ct.RootNote, opts.context)
+				ct.Outdated = !found
+				ct.lines, ct.at = lines, at
+				ct.Context = contextStrings(lines)
+			}
+		}
+	}
+
+	if opts.json {
+		all := append(inlineThreads, generalThreads...)
+		if all == nil {
+			all = []*commentThread{}
 		}
+		data, err := json.MarshalIndent(all, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
 	}
 
+	if len(threads) == 0 {
+		fmt.Printf("No comments on MR #%d: %s\n", mr.LocalID, mr.Title)
+		return nil
+	}
+
+	color := term.IsTerminal(int(os.Stdout.Fd())) && !api.NoColor()
+
+	fmt.Printf("\nComments on MR #%d: %s\n", mr.LocalID, mr.Title)
+	fmt.Println(strings.Repeat("─", 60))
+
 	// Print inline comments grouped by file
 	if len(inlineThreads) > 0 {
 		// Group by file
-		fileGroups := make(map[string][]api.DiscussionThread)
+		fileGroups := make(map[string][]*commentThread)
 		var fileOrder []string
 		for _, t := range inlineThreads {
 			path := *t.RootNote.NewPath
@@ -266,7 +344,7 @@ func runComments(repoFlag string, id int) error {
 		for _, path := range fileOrder {
 			fmt.Printf("\n  %s\n", path)
 			for _, t := range fileGroups[path] {
-				printThreadedDiscussion(t, "    ")
+				printThreadedDiscussion(t, "    ", color)
 			}
 		}
 	}
@@ -275,7 +353,7 @@ func runComments(repoFlag string, id int) error {
 	if len(generalThreads) > 0 {
 		fmt.Println("\n💬 General comments:")
 		for _, t := range generalThreads {
-			printThreadedDiscussion(t, "  ")
+			printThreadedDiscussion(t, "  ", color)
 		}
 	}
 
@@ -283,7 +361,35 @@ func runComments(repoFlag string, id int) error {
 	return nil
 }
 
-func printThreadedDiscussion(t api.DiscussionThread, indent string) {
+// filterThreads applies the --unresolved, --author and --file filters
+func filterThreads(threads []api.DiscussionThread, opts *commentsOptions) []api.DiscussionThread {
+	author := strings.TrimPrefix(opts.author, "@")
+	var result []api.DiscussionThread
+	for _, t := range threads {
+		root := t.RootNote
+		if opts.unresolved && root.Resolved {
+			continue
+		}
+		if author != "" && !strings.EqualFold(root.Author.Username, author) && !strings.EqualFold(root.Author.FullName, author) {
+			continue
+		}
+		if opts.file != "" {
+			matched := false
+			for _, p := range []*string{root.NewPath, root.OldPath} {
+				if p != nil && *p != "" && matchPathspec(opts.file, *p) {
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func printThreadedDiscussion(t *commentThread, indent string, color bool) {
 	root := t.RootNote
 
 	// Status badge
@@ -291,6 +397,9 @@ func printThreadedDiscussion(t api.DiscussionThread, indent string) {
 	if root.Resolved {
 		status = " ✅"
 	}
+	if t.Outdated {
+		status += " (outdated)"
+	}
 
 	// Line info
 	lineInfo := ""
@@ -300,12 +409,6 @@ func printThreadedDiscussion(t api.DiscussionThread, indent string) {
 		lineInfo = fmt.Sprintf(":%d (old)", *root.OldLine)
 	}
 
-	// UUID (short)
-	shortUUID := root.UUID
-	if len(shortUUID) > 8 {
-		shortUUID = shortUUID[:8]
-	}
-
 	authorName := root.Author.Username
 	if authorName == "" {
 		authorName = root.Author.FullName
@@ -313,7 +416,10 @@ func printThreadedDiscussion(t api.DiscussionThread, indent string) {
 
 	fmt.Printf("%s%s @%s • %s%s  [%s]\n",
 		indent, lineInfo, authorName,
-		output.FormatRelativeTime(root.CreatedAt), status, shortUUID)
+		output.FormatRelativeTime(root.CreatedAt), status, shortUUID(root.UUID))
+	if len(t.lines) > 0 {
+		writeCommentContext(os.Stdout, t.lines, t.at, indent+"  ", color)
+	}
 	fmt.Printf("%s%s\n", indent, root.Message)
 
 	// Print replies
@@ -0,0 +1,204 @@
+package mr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/diff"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+// commentDiffTimeout bounds the local git diff used for comment context
+const commentDiffTimeout = 30 * time.Second
+
+// mrDiffFiles returns the per-file diff of mr. The local clone is used
+// when its remote-tracking branches are up to date with the merge
+// request; otherwise the diff comes from the API.
+func mrDiffFiles(client *api.Client, repo *git.Repository, mr *api.MergeRequest) ([]api.CommitDiff, error) {
+	if remote := localDiffRemote(repo, mr); remote != "" {
+		if files, ok := localMRDiff(remote, mr); ok {
+			return files, nil
+		}
+	}
+	return client.MergeRequests().Diff(repo.Owner, repo.Name, mr.LocalID)
+}
+
+// localMRDiff diffs the remote-tracking branches of mr without fetching;
+// ok is false if they are missing or do not match the merge request
+func localMRDiff(remote string, mr *api.MergeRequest) ([]api.CommitDiff, bool) {
+	source := remote + "/" + mr.SourceBranch.Title
+	target := remote + "/" + mr.TargetBranch.Title
+	if validateBranchName(mr.SourceBranch.Title) != nil || validateBranchName(mr.TargetBranch.Title) != nil {
+		return nil, false
+	}
+	if !git.RefExists(target) || !git.RefExists(source) {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commentDiffTimeout)
+	defer cancel()
+
+	if mr.SourceBranch.Hash != "" {
+		out, err := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", source+"^{commit}").Output()
+		if err != nil || strings.TrimSpace(string(out)) != mr.SourceBranch.Hash {
+			return nil, false
+		}
+	}
+
+	out, err := exec.CommandContext(ctx, "git", "diff", "--no-color", "--no-ext-diff", "-M",
+		target+"..."+source, "--").Output()
+	if err != nil {
+		return nil, false
+	}
+	return parseGitDiff(string(out)), true
+}
+
+// parseGitDiff splits the output of git diff into file diffs
+func parseGitDiff(out string) []api.CommitDiff {
+	var files []api.CommitDiff
+	var cur *api.CommitDiff
+	var body []string
+	flush := func() {
+		if cur != nil {
+			cur.DiffContent = strings.Join(body, "\n")
+			files = append(files, *cur)
+		}
+	}
+
+	inHunks := false
+	for _, line := range strings.Split(strings.ReplaceAll(out, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			cur, body, inHunks = &api.CommitDiff{ChangeType: "MODIFY"}, nil, false
+			// diff --git a/<old> b/<new>; reliable for paths without " b/"
+			if a, b, ok := strings.Cut(strings.TrimPrefix(line, "diff --git "), " b/"); ok {
+				cur.OldPath = strings.TrimPrefix(a, "a/")
+				cur.FilePath = b
+			}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if !inHunks {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				inHunks = true
+			case strings.HasPrefix(line, "new file mode"):
+				cur.ChangeType = "ADD"
+			case strings.HasPrefix(line, "deleted file mode"):
+				cur.ChangeType = "DELETE"
+			case strings.HasPrefix(line, "rename from "):
+				cur.ChangeType = "RENAME"
+				cur.OldPath = strings.TrimPrefix(line, "rename from ")
+			case strings.HasPrefix(line, "rename to "):
+				cur.FilePath = strings.TrimPrefix(line, "rename to ")
+			}
+			if !inHunks {
+				continue
+			}
+		}
+		body = append(body, line)
+		switch {
+		case strings.HasPrefix(line, "+"):
+			cur.Additions++
+		case strings.HasPrefix(line, "-"):
+			cur.Deletions++
+		}
+	}
+	flush()
+
+	for i := range files {
+		if files[i].OldPath == files[i].FilePath {
+			files[i].OldPath = ""
+		}
+	}
+	return files
+}
+
+// commentContext locates a note in the diff and returns the surrounding
+// hunk lines and the index of the commented line. found is false when
+// the line is no longer part of the diff (an outdated comment).
+func commentContext(files []api.CommitDiff, note api.DiscussionNote, lines int) (ctx []diff.Line, at int, found bool) {
+	path := ""
+	if note.NewPath != nil {
+		path = *note.NewPath
+	}
+	for _, f := range files {
+		oldPath, newPath := filePaths(f)
+		if path != newPath && path != oldPath {
+			continue
+		}
+		for _, hunk := range diff.ParseHunks(f.DiffContent) {
+			i := findCommentLine(hunk, note)
+			if i < 0 {
+				continue
+			}
+			from, to := max(0, i-lines), min(len(hunk), i+lines+1)
+			return hunk[from:to], i - from, true
+		}
+	}
+	return nil, 0, false
+}
+
+// findCommentLine returns the index of the commented line in hunk, or -1.
+// The new side wins: inline comments mirror the line number to the old
+// side when only one side was given.
+func findCommentLine(hunk []diff.Line, note api.DiscussionNote) int {
+	if note.NewLine != nil && *note.NewLine > 0 {
+		for i, l := range hunk {
+			if l.New == *note.NewLine {
+				return i
+			}
+		}
+	}
+	if note.OldLine != nil && *note.OldLine > 0 {
+		for i, l := range hunk {
+			if l.Kind == '-' && l.Old == *note.OldLine {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// writeCommentContext prints hunk lines with line numbers, marking the
+// commented line
+func writeCommentContext(w io.Writer, lines []diff.Line, at int, indent string, color bool) {
+	for i, l := range lines {
+		num := l.New
+		if l.Kind == '-' {
+			num = l.Old
+		}
+		marker := " "
+		if i == at {
+			marker = ">"
+		}
+		text := fmt.Sprintf("%s%5d %c %s", marker, num, l.Kind, l.Text)
+		if color {
+			switch {
+			case i == at:
+				text = colorMeta + text + colorReset
+			case l.Kind == '+':
+				text = colorAdded + text + colorReset
+			case l.Kind == '-':
+				text = colorRemoved + text + colorReset
+			}
+		}
+		fmt.Fprintf(w, "%s%s\n", indent, text)
+	}
+}
+
+// contextStrings formats hunk lines as "+text", "-text" or " text"
+func contextStrings(lines []diff.Line) []string {
+	result := make([]string, len(lines))
+	for i, l := range lines {
+		result[i] = string(l.Kind) + l.Text
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package mr
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/diff"
+)
+
+const testGitDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -10,4 +10,5 @@ func main() {
+ 	a := 1
+-	b := 2
++	b := 3
++	c := 4
+ 	fmt.Println(a, b)
+diff --git a/old.txt b/new.txt
+similarity index 90%
+rename from old.txt
+rename to new.txt
+@@ -1 +1 @@
+-x
++y
+diff --git a/added.go b/added.go
+new file mode 100644
+--- /dev/null
++++ b/added.go
+@@ -0,0 +1 @@
++package main
+`
+
+func TestParseGitDiff(t *testing.T) {
+	files := parseGitDiff(testGitDiff)
+	if len(files) != 3 {
+		t.Fatalf("got %d files, want 3: %+v", len(files), files)
+	}
+
+	if f := files[0]; f.FilePath != "main.go" || f.OldPath != "" || f.Additions != 2 || f.Deletions != 1 || f.ChangeType != "MODIFY" {
+		t.Errorf("main.go = %+v", f)
+	}
+	if f := files[1]; f.FilePath != "new.txt" || f.OldPath != "old.txt" || f.ChangeType != "RENAME" {
+		t.Errorf("rename = %+v", f)
+	}
+	if f := files[2]; f.FilePath != "added.go" || f.ChangeType != "ADD" || f.DiffContent != "@@ -0,0 +1 @@\n+package main\n" {
+		t.Errorf("added.go = %+v", f)
+	}
+}
+
+func TestParseHunks(t *testing.T) {
+	hunks := diff.ParseHunks(parseGitDiff(testGitDiff)[0].DiffContent)
+	want := [][]diff.Line{{
+		{Kind: ' ', Old: 10, New: 10, Text: "\ta := 1"},
+		{Kind: '-', Old: 11, Text: "\tb := 2"},
+		{Kind: '+', New: 11, Text: "\tb := 3"},
+		{Kind: '+', New: 12, Text: "\tc := 4"},
+		{Kind: ' ', Old: 12, New: 13, Text: "\tfmt.Println(a, b)"},
+	}}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("diff.ParseHunks() = %+v, want %+v", hunks, want)
+	}
+}
+
+func TestCommentContext(t *testing.T) {
+	files := parseGitDiff(testGitDiff)
+	path := "main.go"
+	line := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		note     api.DiscussionNote
+		wantText string
+		wantLen  int
+		found    bool
+	}{
+		{"added line", api.DiscussionNote{NewPath: &path, NewLine: line(12), OldLine: line(12)}, "\tc := 4", 3, true},
+		{"removed line", api.DiscussionNote{NewPath: &path, OldLine: line(11)}, "\tb := 2", 3, true},
+		{"outdated line", api.DiscussionNote{NewPath: &path, NewLine: line(40)}, "", 0, false},
+		{"file not in diff", api.DiscussionNote{NewPath: strPtr("gone.go"), NewLine: line(1)}, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, at, found := commentContext(files, tt.note, 1)
+			if found != tt.found {
+				t.Fatalf("found = %v, want %v", found, tt.found)
+			}
+			if !found {
+				return
+			}
+			if lines[at].Text != tt.wantText || len(lines) != tt.wantLen {
+				t.Errorf("context = %+v (at %d), want %q with %d lines", lines, at, tt.wantText, tt.wantLen)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestWriteCommentContext(t *testing.T) {
+	lines := []diff.Line{
+		{Kind: ' ', Old: 10, New: 10, Text: "a"},
+		{Kind: '-', Old: 11, Text: "b"},
+		{Kind: '+', New: 11, Text: "c"},
+	}
+	var buf bytes.Buffer
+	writeCommentContext(&buf, lines, 2, "  ", false)
+	want := "      10   a\n      11 - b\n  >   11 + c\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFilterThreads(t *testing.T) {
+	path := "cmd/main.go"
+	threads := []api.DiscussionThread{
+		{RootNote: api.DiscussionNote{UUID: "1", Author: api.User{Username: "alice"}, NewPath: &path}},
+		{RootNote: api.DiscussionNote{UUID: "2", Author: api.User{Username: "alice"}, Resolved: true, NewPath: &path}},
+		{RootNote: api.DiscussionNote{UUID: "3", Author: api.User{Username: "bob"}}},
+	}
+
+	ids := func(ts []api.DiscussionThread) []string {
+		var result []string
+		for _, t := range ts {
+			result = append(result, t.RootNote.UUID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		opts commentsOptions
+		want []string
+	}{
+		{commentsOptions{}, []string{"1", "2", "3"}},
+		{commentsOptions{unresolved: true}, []string{"1", "3"}},
+		{commentsOptions{author: "@Alice"}, []string{"1", "2"}},
+		{commentsOptions{file: "*.go"}, []string{"1", "2"}},
+		{commentsOptions{file: "docs/"}, nil},
+	}
+	for _, tt := range tests {
+		if got := ids(filterThreads(threads, &tt.opts)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("filterThreads(%+v) = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+}
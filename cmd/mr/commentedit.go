@@ -0,0 +1,195 @@
+package mr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+type noteOptions struct {
+	repo string
+	body string
+	yes  bool
+}
+
+func newCommentEditCmd() *cobra.Command {
+	opts := &noteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit [<mr-id>] <comment-id>",
+		Short: "Edit one of your comments",
+		Long: `Edit a comment or reply you posted on a merge request.
+
+The comment ID is the UUID shown in 'gf mr comments' output; a unique
+prefix is enough. Without a merge request ID, the merge request of the
+current branch is used. Without --body, the comment opens in your editor.`,
+		Example: `  gf mr comment edit 42 abc12345 --body "Fixed typo"
+
+  # Edit in your editor, MR of the current branch
+  gf mr comment edit abc12345`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentEdit(opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "New comment body (use - to read from stdin)")
+
+	return cmd
+}
+
+func runCommentEdit(opts *noteOptions, args []string) error {
+	client, target, err := findOwnNote(opts.repo, args, "edit")
+	if err != nil {
+		return err
+	}
+
+	current := target.note.RawMessage
+	if current == "" {
+		current = target.note.Message
+	}
+	body, err := commentBody(opts.body, current)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("comment body cannot be empty")
+	}
+	if body == current {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	repo := target.repo
+	if _, err := client.MergeRequests().EditNote(repo.Owner, repo.Name, target.mr.LocalID, target.note.UUID, body); err != nil {
+		return fmt.Errorf("failed to edit comment: %w", err)
+	}
+	fmt.Printf("✓ Edited comment %s on MR #%d\n", shortUUID(target.note.UUID), target.mr.LocalID)
+	return nil
+}
+
+func newCommentDeleteCmd() *cobra.Command {
+	opts := &noteOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "delete [<mr-id>] <comment-id>",
+		Aliases: []string{"rm"},
+		Short:   "Delete one of your comments",
+		Long: `Delete a comment or reply you posted on a merge request.
+
+Deleting the first comment of a thread removes the whole thread.`,
+		Example: `  gf mr comment delete 42 abc12345
+
+  # Without confirmation
+  gf mr comment delete 42 abc12345 --yes`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentDelete(opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation")
+
+	return cmd
+}
+
+func runCommentDelete(opts *noteOptions, args []string) error {
+	client, target, err := findOwnNote(opts.repo, args, "delete")
+	if err != nil {
+		return err
+	}
+
+	if !opts.yes {
+		fmt.Printf("%s\n\n", target.note.Message)
+		if target.note == &target.thread.RootNote && len(target.thread.Replies) > 0 {
+			fmt.Printf("! This removes the whole thread with %d reply(ies)\n", len(target.thread.Replies))
+		}
+		fmt.Printf("Delete comment %s on MR #%d? [y/N] ", shortUUID(target.note.UUID), target.mr.LocalID)
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	repo := target.repo
+	if err := client.MergeRequests().DeleteNote(repo.Owner, repo.Name, target.mr.LocalID, target.note.UUID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	fmt.Printf("✓ Deleted comment %s on MR #%d\n", shortUUID(target.note.UUID), target.mr.LocalID)
+	return nil
+}
+
+// noteTarget is a discussion note addressed on the command line
+type noteTarget struct {
+	repo   *git.Repository
+	mr     *api.MergeRequest
+	thread *api.DiscussionThread
+	note   *api.DiscussionNote
+}
+
+// findOwnNote resolves [<mr-id>] <comment-id> arguments to a note and
+// checks that the authenticated user wrote it
+func findOwnNote(repoFlag string, args []string, action string) (*api.Client, *noteTarget, error) {
+	id, err := mrIDArg(args[:len(args)-1], &repoFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, client, err := repoClient(repoFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uuid := args[len(args)-1]
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return nil, nil, fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	threads, err := client.MergeRequests().ListDiscussionThreads(repo.Owner, repo.Name, mr.LocalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	note, thread, err := api.FindNote(threads, uuid)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w on MR #%d", err, mr.LocalID)
+	}
+
+	me, err := client.Users().Me()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if !isAuthor(me, note.Author) {
+		return nil, nil, fmt.Errorf("cannot %s comment %s: it was written by @%s", action, shortUUID(note.UUID), note.Author.Username)
+	}
+
+	return client, &noteTarget{repo: repo, mr: mr, thread: thread, note: note}, nil
+}
+
+// isAuthor reports whether user me wrote a note by author
+func isAuthor(me *api.User, author api.User) bool {
+	if me.ID != "" && author.ID != "" {
+		return me.ID == author.ID
+	}
+	return me.Username != "" && strings.EqualFold(me.Username, author.Username)
+}
+
+// shortUUID returns the 8-character prefix shown in 'gf mr comments'
+func shortUUID(uuid string) string {
+	if len(uuid) > 8 {
+		return uuid[:8]
+	}
+	return uuid
+}
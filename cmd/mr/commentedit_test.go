@@ -0,0 +1,40 @@
+package mr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestIsAuthor(t *testing.T) {
+	tests := []struct {
+		name   string
+		me     api.User
+		author api.User
+		want   bool
+	}{
+		{"same ID", api.User{ID: "1", Username: "alice"}, api.User{ID: "1", Username: "renamed"}, true},
+		{"different ID", api.User{ID: "1", Username: "alice"}, api.User{ID: "2", Username: "alice"}, false},
+		{"username fallback", api.User{Username: "Alice"}, api.User{Username: "alice"}, true},
+		{"no username", api.User{}, api.User{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAuthor(&tt.me, tt.author); got != tt.want {
+				t.Errorf("isAuthor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentEditCmd_Args(t *testing.T) {
+	cmd := newCommentEditCmd()
+	cmd.SetArgs([]string{"x", "abc", "extra"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "accepts between 1 and 2 arg(s)") {
+		t.Errorf("err = %v, want argument count error", err)
+	}
+}
@@ -0,0 +1,84 @@
+package mr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type changesOptions struct {
+	repo string
+	json bool
+}
+
+func newCommitsCmd() *cobra.Command {
+	opts := &changesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "commits [<id> | <branch> | <url>]",
+		Short: "List the commits of a merge request",
+		Long:  `List the commits of a merge request, oldest first, without a local clone.`,
+		Example: `  gf mr commits 42
+  gf mr commits 42 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			return runCommits(opts, id)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runCommits(opts *changesOptions, id int) error {
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	commits, err := client.MergeRequests().Commits(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	if opts.json {
+		data, err := json.MarshalIndent(commits, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(commits) == 0 {
+		fmt.Printf("No commits in MR #%d\n", id)
+		return nil
+	}
+
+	fmt.Printf("\n%-9s %-50s %-20s %s\n", "HASH", "MESSAGE", "AUTHOR", "DATE")
+	fmt.Println(strings.Repeat("-", 95))
+	for _, c := range commits {
+		author := c.AuthorName
+		if author == "" && c.Author != nil {
+			author = c.Author.Username
+		}
+		message, _, _ := strings.Cut(c.Message, "\n")
+		fmt.Printf("%-9s %-50s %-20s %s\n", shortSHA(c.Hash), truncateTitle(message, 50),
+			truncateTitle(author, 20), output.FormatRelativeTime(c.CreatedAt))
+	}
+	fmt.Printf("\n%d commit(s)\n", len(commits))
+	return nil
+}
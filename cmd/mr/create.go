@@ -3,15 +3,19 @@ package mr
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/browser"
+	"github.com/josinSbazin/gf/internal/codeowners"
 	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/editor"
 	"github.com/josinSbazin/gf/internal/git"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // validMRBranchRegex validates branch names for MR creation
@@ -44,6 +48,18 @@ type createOptions struct {
 	repo         string
 	web          bool
 	quiet        bool
+	fill         bool
+	fillFirst    bool
+	template     string
+	editor       bool
+	bodyFile     string
+	push         bool
+	noPush       bool
+	head         string
+	reviewers    []string
+	assignees    []string
+	labels       []string
+	codeowners   bool
 }
 
 func newCreateCmd() *cobra.Command {
@@ -52,7 +68,18 @@ func newCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a merge request",
-		Long:  `Create a new merge request.`,
+		Long: `Create a new merge request.
+
+When the current clone is a fork (origin) with an "upstream" remote
+pointing at the parent repository, and GitFlic confirms origin is a fork
+of it, the merge request is created in the parent from the fork's branch.
+The chosen target is printed. Use --head owner/repo:branch to pick the
+source project explicitly, or --repo to disable detection.
+
+Title and description can be filled from the commits between the target
+and source branches (--fill, --fill-first). Description templates are
+read from .gitflic/merge_request_templates/*.md; a template named
+default.md is used when no description is given.`,
 		Example: `  # Interactive create
   gf mr create
 
@@ -60,8 +87,41 @@ func newCreateCmd() *cobra.Command {
   gf mr create --title "Add new feature"
 
   # Create with all options
-  gf mr create --title "Fix bug" --body "Description" --target main`,
+  gf mr create --title "Fix bug" --body "Description" --target main
+
+  # Title and description from commits
+  gf mr create --fill
+
+  # Use a template and review everything in $EDITOR
+  gf mr create --fill --template bugfix --editor
+
+  # Description from a file (- for stdin)
+  gf mr create --title "Release 2.0" --body-file CHANGELOG.md
+
+  # Push the current branch without asking, then create
+  gf mr create --fill --push
+
+  # Request reviews, assign yourself and add labels
+  gf mr create --fill --reviewer alice,bob --assignee @me --label bug
+
+  # Add reviewers suggested by CODEOWNERS
+  gf mr create --fill --codeowners
+
+  # From a branch in a fork into the upstream repository
+  gf mr create --head alice/app:fix-typo -R team/app --fill`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.fill && opts.fillFirst {
+				return fmt.Errorf("--fill and --fill-first cannot be used together")
+			}
+			if opts.body != "" && opts.bodyFile != "" {
+				return fmt.Errorf("--body and --body-file cannot be used together")
+			}
+			if opts.push && opts.noPush {
+				return fmt.Errorf("--push and --no-push cannot be used together")
+			}
+			if opts.head != "" && opts.source != "" {
+				return fmt.Errorf("--head and --source cannot be used together")
+			}
 			return runCreate(opts)
 		},
 	}
@@ -75,6 +135,18 @@ func newCreateCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open in browser after creating")
 	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "Output only the MR number")
+	cmd.Flags().BoolVarP(&opts.fill, "fill", "f", false, "Fill title and description from commits")
+	cmd.Flags().BoolVar(&opts.fillFirst, "fill-first", false, "Fill title and description from the first commit only")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Description template from .gitflic/merge_request_templates")
+	cmd.Flags().BoolVarP(&opts.editor, "editor", "e", false, "Edit title and description in $EDITOR")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read description from file (- for stdin)")
+	cmd.Flags().BoolVar(&opts.push, "push", false, "Push the source branch without asking")
+	cmd.Flags().BoolVar(&opts.noPush, "no-push", false, "Do not check or push the source branch")
+	cmd.Flags().StringVar(&opts.head, "head", "", "Source as [owner/repo:]branch, for merge requests from a fork")
+	cmd.Flags().StringSliceVarP(&opts.reviewers, "reviewer", "r", nil, "Request review from users (username or @me, repeatable)")
+	cmd.Flags().StringSliceVarP(&opts.assignees, "assignee", "a", nil, "Assign users (username or @me, repeatable)")
+	cmd.Flags().StringSliceVarP(&opts.labels, "label", "l", nil, "Add labels by title (repeatable)")
+	cmd.Flags().BoolVar(&opts.codeowners, "codeowners", false, "Request review from code owners of the changed files")
 
 	return cmd
 }
@@ -86,6 +158,38 @@ func runCreate(opts *createOptions) error {
 		return fmt.Errorf("could not determine repository: %w", err)
 	}
 
+	// Load config and create client
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	// Source project: explicit --head, detected fork, or the target itself
+	sourceRepo := repo
+	if opts.head != "" {
+		headRepo, branch, err := parseHead(opts.head, repo)
+		if err != nil {
+			return err
+		}
+		sourceRepo, opts.source = headRepo, branch
+	} else if opts.repo == "" && os.Getenv("GF_REPO") == "" {
+		fork, parent, err := git.DetectFork()
+		if err != nil {
+			return fmt.Errorf("could not inspect git remotes: %w", err)
+		}
+		if fork != nil {
+			sourceRepo, repo = forkTarget(client, fork, parent)
+		}
+	}
+	isFork := !strings.EqualFold(sourceRepo.FullName(), repo.FullName())
+
 	// Get source branch
 	if opts.source == "" {
 		opts.source, err = git.CurrentBranch()
@@ -102,24 +206,92 @@ func runCreate(opts *createOptions) error {
 		}
 	}
 
-	// Load config and create client
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+	reader := bufio.NewReader(os.Stdin)
+	interactive := opts.title == "" && !opts.fill && !opts.fillFirst && !opts.editor
+	hasBody := opts.body != "" || opts.bodyFile != ""
+	if interactive {
+		source := opts.source
+		if isFork {
+			source = sourceRepo.FullName() + ":" + opts.source
+		}
+		fmt.Printf("Creating merge request for %s into %s in %s\n\n",
+			source, opts.target, repo.FullName())
 	}
 
-	token, err := cfg.Token()
-	if err != nil {
-		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	// Local remotes of the source and target projects, if any
+	sourceRemote, _ := git.FindRemoteFor(sourceRepo.Owner, sourceRepo.Name)
+	targetRemote, _ := git.FindRemoteFor(repo.Owner, repo.Name)
+
+	// A local source branch is pushed first, so it needs a remote; a
+	// repository detected from this clone falls back to its GitFlic remote
+	if sourceRemote == "" && !opts.noPush && git.LocalBranchExists(opts.source) {
+		if opts.repo == "" && opts.head == "" && !isFork && os.Getenv("GF_REPO") == "" {
+			sourceRemote, _ = git.FindGitflicRemote()
+		}
+		if sourceRemote == "" {
+			return fmt.Errorf("no git remote for %s; push %s manually or pass --no-push", sourceRepo.FullName(), opts.source)
+		}
 	}
 
-	// Interactive mode if title not provided
-	if opts.title == "" {
-		fmt.Printf("Creating merge request for %s into %s in %s\n\n",
-			opts.source, opts.target, repo.FullName())
+	// Description from file or stdin
+	if opts.bodyFile != "" {
+		var data []byte
+		if opts.bodyFile == "-" {
+			data, err = io.ReadAll(reader)
+		} else {
+			data, err = os.ReadFile(opts.bodyFile)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read body file: %w", err)
+		}
+		opts.body = strings.TrimSpace(string(data))
+	}
+
+	// Title and description from commits
+	if opts.fill || opts.fillFirst {
+		commits, err := commitsForMR(opts.source, opts.target, targetRemote)
+		if err != nil {
+			return err
+		}
+		title, body := fillFromCommits(commits, opts.source, opts.fillFirst)
+		if opts.title == "" {
+			opts.title = title
+		}
+		if !hasBody {
+			opts.body = body
+		}
+	}
+
+	// Description template (an explicit description skips templates)
+	var template string
+	if !hasBody || opts.template != "" {
+		template, err = chooseTemplate(opts.template, reader, interactive || opts.editor)
+		if err != nil {
+			return err
+		}
+	}
+	if template != "" {
+		if opts.body != "" {
+			// Keep filled commit summary below the template
+			opts.body = template + "\n\n" + opts.body
+		} else {
+			opts.body = template
+		}
+	}
 
-		reader := bufio.NewReader(os.Stdin)
+	if opts.editor {
+		text, err := editor.Edit("MERGE_REQUEST.md", editorText(opts.title, opts.body))
+		if err != nil {
+			return fmt.Errorf("editor failed: %w", err)
+		}
+		opts.title, opts.body = parseEditorText(text)
+		if opts.title == "" {
+			return fmt.Errorf("aborting: empty title")
+		}
+	}
 
+	// Interactive mode if title not provided
+	if opts.title == "" {
 		fmt.Print("Title: ")
 		opts.title, _ = reader.ReadString('\n')
 		opts.title = strings.TrimSpace(opts.title)
@@ -128,9 +300,22 @@ func runCreate(opts *createOptions) error {
 			return fmt.Errorf("title is required")
 		}
 
-		fmt.Print("Description (optional, press Enter to skip): ")
-		opts.body, _ = reader.ReadString('\n')
-		opts.body = strings.TrimSpace(opts.body)
+		if template != "" {
+			fmt.Print("Edit description in editor? [Y/n]: ")
+			answer, _ := reader.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer == "" || answer == "y" || answer == "yes" {
+				text, err := editor.Edit("MERGE_REQUEST.md", opts.body+"\n")
+				if err != nil {
+					return fmt.Errorf("editor failed: %w", err)
+				}
+				opts.body = strings.TrimSpace(text)
+			}
+		} else if opts.body == "" {
+			fmt.Print("Description (optional, press Enter to skip): ")
+			opts.body, _ = reader.ReadString('\n')
+			opts.body = strings.TrimSpace(opts.body)
+		}
 	}
 
 	// Validate branch names
@@ -141,24 +326,71 @@ func runCreate(opts *createOptions) error {
 		return err
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	// Make sure the source branch is on the remote with all local commits
+	if !opts.noPush && sourceRemote != "" {
+		if err := ensurePushed(sourceRemote, opts.source, opts.push, reader); err != nil {
+			return err
+		}
+	}
 
-	// Get project info to get UUID
+	// Get project info to get UUIDs
 	project, err := client.Projects().Get(repo.Owner, repo.Name)
 	if err != nil {
 		return fmt.Errorf("failed to get project info: %w", err)
 	}
+	sourceProject := project
+	if isFork {
+		sourceProject, err = client.Projects().Get(sourceRepo.Owner, sourceRepo.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get source project %s: %w", sourceRepo.FullName(), err)
+		}
+	}
+
+	// Suggest reviewers from CODEOWNERS
+	if len(opts.reviewers) == 0 && (opts.codeowners || interactive) {
+		if owners := suggestReviewers(opts.source, opts.target, targetRemote); len(owners) > 0 {
+			add := opts.codeowners
+			if !add {
+				fmt.Printf("Request review from %s (CODEOWNERS)? [Y/n]: ", "@"+strings.Join(owners, ", @"))
+				answer, _ := reader.ReadString('\n')
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				add = answer == "" || answer == "y" || answer == "yes"
+			}
+			if add {
+				opts.reviewers = owners
+			}
+		}
+	}
+
+	// Resolve reviewers, assignees and labels
+	users := newUserResolver(client)
+	reviewers, err := users.resolve(opts.reviewers)
+	if err != nil {
+		return err
+	}
+	reviewers = withoutAuthor(users, reviewers)
+	assignees, err := users.resolve(opts.assignees)
+	if err != nil {
+		return err
+	}
+	labels, err := resolveLabels(client, repo.Owner, repo.Name, opts.labels)
+	if err != nil {
+		return err
+	}
 
 	// Create merge request
 	mr, err := client.MergeRequests().Create(repo.Owner, repo.Name, &api.CreateMRRequest{
-		Title:        opts.title,
-		Description:  opts.body,
-		SourceBranch: api.BranchRef{ID: opts.source},
-		TargetBranch: api.BranchRef{ID: opts.target},
-		SourceProject: api.ProjectRef{ID: project.ID},
-		TargetProject: api.ProjectRef{ID: project.ID},
+		Title:              opts.title,
+		Description:        opts.body,
+		SourceBranch:       api.BranchRef{ID: opts.source},
+		TargetBranch:       api.BranchRef{ID: opts.target},
+		SourceProject:      api.ProjectRef{ID: sourceProject.ID},
+		TargetProject:      api.ProjectRef{ID: project.ID},
 		IsDraft:            opts.draft,
 		RemoveSourceBranch: opts.deleteBranch,
+		Reviewers:          mergeUsers(nil, reviewers, nil),
+		AssignedUsers:      mergeUsers(nil, assignees, nil),
+		Labels:             mergeLabels(nil, labels, nil),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create merge request: %w", err)
@@ -177,6 +409,16 @@ func runCreate(opts *createOptions) error {
 		fmt.Printf("\n✓ Created merge request #%d\n", mr.LocalID)
 	}
 
+	if len(reviewers) > 0 {
+		fmt.Printf("  Reviewers: %s\n", formatUsers(reviewers))
+	}
+	if len(assignees) > 0 {
+		fmt.Printf("  Assignees: %s\n", formatUsers(assignees))
+	}
+	if len(labels) > 0 {
+		fmt.Printf("  Labels:    %s\n", formatLabels(labels))
+	}
+
 	url := fmt.Sprintf("https://%s/project/%s/%s/merge-request/%d",
 		repo.Host, repo.Owner, repo.Name, mr.LocalID)
 	fmt.Println(url)
@@ -187,3 +429,170 @@ func runCreate(opts *createOptions) error {
 
 	return nil
 }
+
+// chooseTemplate returns the description template to use, or "" for none.
+// An explicit name wins; otherwise the user picks one when prompting
+// is allowed, and default.md applies when no description was given.
+func chooseTemplate(name string, reader *bufio.Reader, prompt bool) (string, error) {
+	root, err := git.TopLevel()
+	if err != nil {
+		if name != "" {
+			return "", err
+		}
+		return "", nil
+	}
+	templates, err := findTemplates(root)
+	if err != nil {
+		return "", err
+	}
+
+	var chosen *mrTemplate
+	switch {
+	case name != "":
+		chosen = lookupTemplate(templates, name)
+		if chosen == nil {
+			return "", fmt.Errorf("template %q not found in %s", name, templateDir)
+		}
+	case len(templates) == 0:
+		return "", nil
+	case prompt && len(templates) > 1:
+		chosen, err = promptTemplate(reader, templates)
+		if err != nil {
+			return "", err
+		}
+	default:
+		chosen = lookupTemplate(templates, defaultTemplate)
+		if chosen == nil && prompt {
+			chosen = &templates[0]
+		}
+	}
+
+	if chosen == nil {
+		return "", nil
+	}
+	return readTemplate(chosen)
+}
+
+// ensurePushed pushes branch to remote if it does not exist there or is
+// behind the local branch. Without push, the user is asked first;
+// non-interactive runs fail with a hint instead.
+func ensurePushed(remote, branch string, push bool, reader *bufio.Reader) error {
+	// Nothing to push for branches that exist only on the remote
+	if !git.LocalBranchExists(branch) {
+		return nil
+	}
+
+	var reason string
+	if !git.RemoteBranchExists(remote, branch) {
+		reason = fmt.Sprintf("Branch %s does not exist on %s", branch, remote)
+	} else {
+		ahead, err := git.CommitsAhead(remote+"/"+branch, branch)
+		if err != nil {
+			return fmt.Errorf("failed to compare %s with %s/%s: %w", branch, remote, branch, err)
+		}
+		if ahead == 0 {
+			return nil
+		}
+		reason = fmt.Sprintf("Branch %s has %d unpushed commit(s)", branch, ahead)
+	}
+
+	if !push {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("%s. Push it first or use --push (--no-push to skip this check)", reason)
+		}
+		fmt.Printf("%s. Push it now? [Y/n]: ", reason)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "" && answer != "y" && answer != "yes" {
+			return fmt.Errorf("aborted: push %s first or use --no-push", branch)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Pushing %s to %s...\n", branch, remote)
+	if err := git.Push(remote, branch, true); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// forkTarget chooses the project for a merge request from a clone with
+// both origin and upstream remotes: upstream when GitFlic confirms origin
+// is its fork, origin otherwise. The choice is printed to stderr.
+func forkTarget(client *api.Client, fork, parent *git.Repository) (source, target *git.Repository) {
+	isFork, err := client.Projects().IsForkOf(fork.Owner, fork.Name, parent.Owner, parent.Name)
+	switch {
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "! Could not check whether %s is a fork of %s: %v\n", fork.FullName(), parent.FullName(), err)
+	case !isFork:
+		fmt.Fprintf(os.Stderr, "! %s is not a fork of %s (remote %s)\n", fork.FullName(), parent.FullName(), git.UpstreamRemote)
+	default:
+		fmt.Fprintf(os.Stderr, "Targeting %s, the parent of fork %s\n", parent.FullName(), fork.FullName())
+		return fork, parent
+	}
+	fmt.Fprintf(os.Stderr, "Targeting %s; use --repo %s --head %s:<branch> to target upstream\n",
+		fork.FullName(), parent.FullName(), fork.FullName())
+	return fork, fork
+}
+
+// parseHead parses --head as branch, owner:branch or owner/repo:branch.
+// owner:branch refers to the fork of target owned by owner.
+func parseHead(head string, target *git.Repository) (*git.Repository, string, error) {
+	project, branch, found := strings.Cut(head, ":")
+	if !found {
+		return target, head, nil
+	}
+	if branch == "" {
+		return nil, "", fmt.Errorf("invalid --head %q: missing branch", head)
+	}
+
+	if !strings.Contains(project, "/") {
+		project += "/" + target.Name
+	}
+	repo, err := git.ParseRepoFlag(project, target.Host)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid --head %q: %w", head, err)
+	}
+	return repo, branch, nil
+}
+
+// suggestReviewers returns the CODEOWNERS owners of the files changed
+// between target and source, or nil if there is no CODEOWNERS file
+func suggestReviewers(source, target, targetRemote string) []string {
+	root, err := git.TopLevel()
+	if err != nil {
+		return nil
+	}
+	owners, err := codeowners.Find(root)
+	if err != nil || owners == nil {
+		return nil
+	}
+
+	base := target
+	if targetRemote != "" && git.RefExists(targetRemote+"/"+target) {
+		base = targetRemote + "/" + target
+	}
+	files, err := git.ChangedFiles(base, source)
+	if err != nil {
+		return nil
+	}
+	return owners.OwnersOf(files)
+}
+
+// withoutAuthor drops the authenticated user from reviewers, since
+// authors cannot review their own merge requests
+func withoutAuthor(users *userResolver, reviewers []api.User) []api.User {
+	if len(reviewers) == 0 {
+		return reviewers
+	}
+	me, err := users.resolve([]string{"@me"})
+	if err != nil || len(me) == 0 {
+		return reviewers
+	}
+	filtered := reviewers[:0:0]
+	for _, u := range reviewers {
+		if u.ID != me[0].ID {
+			filtered = append(filtered, u)
+		}
+	}
+	return filtered
+}
@@ -2,6 +2,8 @@ package mr
 
 import (
 	"testing"
+
+	"github.com/josinSbazin/gf/internal/git"
 )
 
 func TestValidateMRBranch(t *testing.T) {
@@ -117,3 +119,62 @@ func TestCreateCmd_Usage(t *testing.T) {
 		t.Error("Short description is empty")
 	}
 }
+
+func TestParseHead(t *testing.T) {
+	target := &git.Repository{Host: "gitflic.ru", Owner: "team", Name: "app"}
+
+	tests := []struct {
+		head       string
+		wantRepo   string
+		wantBranch string
+		wantErr    bool
+	}{
+		{"feature", "team/app", "feature", false},
+		{"alice:fix-typo", "alice/app", "fix-typo", false},
+		{"alice/app-fork:fix/typo", "alice/app-fork", "fix/typo", false},
+		{"alice:", "", "", true},
+		{"../etc:main", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.head, func(t *testing.T) {
+			repo, branch, err := parseHead(tt.head, target)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseHead(%q) should return error", tt.head)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.FullName() != tt.wantRepo || branch != tt.wantBranch {
+				t.Errorf("parseHead(%q) = %s:%s, want %s:%s", tt.head, repo.FullName(), branch, tt.wantRepo, tt.wantBranch)
+			}
+			if repo.Host != "gitflic.ru" {
+				t.Errorf("Host = %q, want gitflic.ru", repo.Host)
+			}
+		})
+	}
+}
+
+func TestValidCloneURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://gitflic.ru/project/alice/app.git", true},
+		{"git@gitflic.ru:alice/app.git", true},
+		{"ssh://git@gitflic.ru/alice/app.git", true},
+		{"--upload-pack=evil", false},
+		{"file:///etc", false},
+		{"https://gitflic.ru/a b", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := validCloneURL(tt.url); got != tt.want {
+			t.Errorf("validCloneURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package mr
+
+import "syscall"
+
+// detachedProcAttr returns nil on platforms without session support;
+// the process is still started without a terminal attached to stdio.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+// processAlive cannot check processes on these platforms and assumes
+// the process is still running
+func processAlive(pid int) bool {
+	return true
+}
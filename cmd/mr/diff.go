@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/josinSbazin/gf/internal/config"
 	"github.com/josinSbazin/gf/internal/git"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 const diffTimeout = 2 * time.Minute
@@ -21,6 +21,8 @@ type diffOptions struct {
 	repo     string
 	stat     bool
 	nameOnly bool
+	patch    bool
+	remote   bool
 	color    string
 }
 
@@ -28,11 +30,17 @@ func newDiffCmd() *cobra.Command {
 	opts := &diffOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "diff <id>",
+		Use:   "diff [<id> | <branch> | <url>] [-- <path>...]",
 		Short: "Show diff of a merge request",
 		Long: `Show the diff between source and target branches of a merge request.
 
-This fetches the latest changes and shows the diff locally using git.`,
+Inside a clone of the repository, gf fetches both branches and shows the
+diff with git. Outside a clone, for merge requests from forks, or with
+--remote, the diff is fetched from the GitFlic API instead.
+
+Without an ID, the merge request of the current branch is used. Paths
+after the ID (or after -- alone) limit the diff to matching files: exact paths,
+directories or glob patterns such as '*.go'.`,
 		Example: `  # Show diff for MR #42
   gf mr diff 42
 
@@ -40,26 +48,47 @@ This fetches the latest changes and shows the diff locally using git.`,
   gf mr diff 42 --stat
 
   # Show only changed file names
-  gf mr diff 42 --name-only`,
-		Args: cobra.ExactArgs(1),
+  gf mr diff 42 --name-only
+
+  # Only changes under cmd/ and Go files
+  gf mr diff 42 -- cmd/ '*.go'
+
+  # Diff of the current branch's MR, limited to docs/
+  gf mr diff -- docs/
+
+  # Apply the commits of MR #42 to the current branch
+  gf mr diff 42 --patch | git am
+
+  # Diff from the API without a local clone
+  gf mr diff 42 --remote -R owner/repo`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			// "gf mr diff -- <path>" diffs the current branch's MR
+			ref, pathspecs := args, []string(nil)
+			if cmd.ArgsLenAtDash() == 0 {
+				ref, pathspecs = nil, args
+			} else if len(args) > 1 {
+				ref, pathspecs = args[:1], args[1:]
+			}
+			id, err := mrIDArg(ref, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
-			return runDiff(opts, id)
+			return runDiff(opts, id, pathspecs)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
 	cmd.Flags().BoolVar(&opts.stat, "stat", false, "Show diffstat instead of patch")
 	cmd.Flags().BoolVar(&opts.nameOnly, "name-only", false, "Show only names of changed files")
+	cmd.Flags().BoolVar(&opts.patch, "patch", false, "Show commits as patches for git am")
+	cmd.Flags().BoolVar(&opts.remote, "remote", false, "Fetch the diff from the API instead of local git")
 	cmd.Flags().StringVar(&opts.color, "color", "auto", "Use color: always, never, auto")
+	cmd.MarkFlagsMutuallyExclusive("stat", "name-only", "patch")
 
 	return cmd
 }
 
-func runDiff(opts *diffOptions, id int) error {
+func runDiff(opts *diffOptions, id int, pathspecs []string) error {
 	// Get repository
 	repo, err := git.ResolveRepo(opts.repo, config.DefaultHost())
 	if err != nil {
@@ -77,7 +106,7 @@ func runDiff(opts *diffOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -88,6 +117,20 @@ func runDiff(opts *diffOptions, id int) error {
 		return fmt.Errorf("failed to get merge request: %w", err)
 	}
 
+	for _, spec := range pathspecs {
+		if strings.HasPrefix(spec, "-") {
+			return fmt.Errorf("invalid path: %s", spec)
+		}
+	}
+
+	remote := ""
+	if !opts.remote {
+		remote = localDiffRemote(repo, mr)
+	}
+	if remote == "" {
+		return runRemoteDiff(opts, client, repo, mr, pathspecs)
+	}
+
 	sourceBranch := mr.SourceBranch.Title
 	targetBranch := mr.TargetBranch.Title
 
@@ -104,7 +147,7 @@ func runDiff(opts *diffOptions, id int) error {
 
 	// Fetch latest changes
 	fmt.Fprintf(os.Stderr, "Fetching branches...\n")
-	fetchCmd := exec.CommandContext(ctx, "git", "fetch", "origin", sourceBranch, targetBranch)
+	fetchCmd := exec.CommandContext(ctx, "git", "fetch", remote, sourceBranch, targetBranch)
 	fetchCmd.Stderr = os.Stderr
 	if err := fetchCmd.Run(); err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
@@ -115,6 +158,13 @@ func runDiff(opts *diffOptions, id int) error {
 
 	// Build diff command
 	diffArgs := []string{"diff"}
+	// Three-dot diff: shows changes in source since it diverged from target
+	revs := fmt.Sprintf("%s/%s...%s/%s", remote, targetBranch, remote, sourceBranch)
+	if opts.patch {
+		// Patches of the commits on source that are not on target
+		diffArgs = []string{"format-patch", "--stdout"}
+		revs = fmt.Sprintf("%s/%s..%s/%s", remote, targetBranch, remote, sourceBranch)
+	}
 
 	// Color option
 	switch opts.color {
@@ -123,7 +173,9 @@ func runDiff(opts *diffOptions, id int) error {
 	case "never":
 		diffArgs = append(diffArgs, "--color=never")
 	default:
-		diffArgs = append(diffArgs, "--color=auto")
+		if !opts.patch {
+			diffArgs = append(diffArgs, "--color=auto")
+		}
 	}
 
 	// Output format options
@@ -133,10 +185,14 @@ func runDiff(opts *diffOptions, id int) error {
 		diffArgs = append(diffArgs, "--name-only")
 	}
 
-	// Three-dot diff: shows changes in source since it diverged from target
-	diffArgs = append(diffArgs, fmt.Sprintf("origin/%s...origin/%s", targetBranch, sourceBranch))
+	diffArgs = append(diffArgs, revs)
+	if len(pathspecs) > 0 {
+		diffArgs = append(append(diffArgs, "--"), pathspecs...)
+	}
 
-	fmt.Fprintf(os.Stderr, "Showing diff: %s → %s\n\n", sourceBranch, targetBranch)
+	if !opts.patch {
+		fmt.Fprintf(os.Stderr, "Showing diff: %s → %s\n\n", sourceBranch, targetBranch)
+	}
 
 	diffCmd := exec.CommandContext(ctx, "git", diffArgs...)
 	diffCmd.Stdout = os.Stdout
@@ -151,3 +207,86 @@ func runDiff(opts *diffOptions, id int) error {
 
 	return nil
 }
+
+// localDiffRemote returns the git remote to diff mr with locally, or ""
+// if the diff has to come from the API (no clone, no remote for the
+// repository, or a merge request from a fork)
+func localDiffRemote(repo *git.Repository, mr *api.MergeRequest) string {
+	if mr.IsCrossProject() {
+		return ""
+	}
+	if _, err := git.TopLevel(); err != nil {
+		return ""
+	}
+	remote, err := git.FindRemoteFor(repo.Owner, repo.Name)
+	if err != nil {
+		return ""
+	}
+	return remote
+}
+
+// runRemoteDiff renders the diff of mr from the API
+func runRemoteDiff(opts *diffOptions, client *api.Client, repo *git.Repository, mr *api.MergeRequest, pathspecs []string) error {
+	color := false
+	switch opts.color {
+	case "always":
+		color = true
+	case "auto":
+		color = term.IsTerminal(int(os.Stdout.Fd())) && !api.NoColor()
+	}
+
+	if opts.patch {
+		return runRemotePatch(client, repo, mr, pathspecs)
+	}
+
+	files, err := client.MergeRequests().Diff(repo.Owner, repo.Name, mr.LocalID)
+	if err != nil {
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+	files = filterPaths(files, pathspecs)
+
+	switch {
+	case opts.stat:
+		writeStat(os.Stdout, files, color)
+	case opts.nameOnly:
+		writeNames(os.Stdout, files)
+	default:
+		fmt.Fprintf(os.Stderr, "Showing diff: %s → %s\n\n", mr.SourceBranch.Title, mr.TargetBranch.Title)
+		writePatch(os.Stdout, files, color)
+	}
+	return nil
+}
+
+// runRemotePatch writes the commits of mr as git am compatible patches
+func runRemotePatch(client *api.Client, repo *git.Repository, mr *api.MergeRequest, pathspecs []string) error {
+	commits, err := client.MergeRequests().Commits(repo.Owner, repo.Name, mr.LocalID)
+	if err != nil {
+		return fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	// Commits of fork merge requests live in the source project
+	owner, name := repo.Owner, repo.Name
+	if mr.IsCrossProject() && mr.SourceProject.Alias != "" {
+		owner, name = mr.SourceProject.Owner.Alias, mr.SourceProject.Alias
+	}
+
+	type patch struct {
+		commit api.CommitDetail
+		files  []api.CommitDiff
+	}
+	var patches []patch
+	for _, c := range commits {
+		files, err := client.Commits().Diff(owner, name, c.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to get diff of %s: %w", shortSHA(c.Hash), err)
+		}
+		if files = filterPaths(files, pathspecs); len(files) > 0 {
+			patches = append(patches, patch{c, files})
+		}
+	}
+
+	for i, p := range patches {
+		writeMailPatch(os.Stdout, p.commit, p.files, i+1, len(patches))
+	}
+	return nil
+}
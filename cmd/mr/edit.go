@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -14,18 +13,33 @@ import (
 )
 
 type editOptions struct {
-	repo  string
-	title string
-	body  string
+	repo            string
+	title           string
+	body            string
+	target          string
+	addReviewers    []string
+	removeReviewers []string
+	addAssignees    []string
+	removeAssignees []string
+	addLabels       []string
+	removeLabels    []string
+}
+
+// hasListChanges reports whether reviewers, assignees or labels change
+func (opts *editOptions) hasListChanges() bool {
+	return len(opts.addReviewers) > 0 || len(opts.removeReviewers) > 0 ||
+		len(opts.addAssignees) > 0 || len(opts.removeAssignees) > 0 ||
+		len(opts.addLabels) > 0 || len(opts.removeLabels) > 0
 }
 
 func newEditCmd() *cobra.Command {
 	opts := &editOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "edit <id>",
+		Use:   "edit [<id> | <branch> | <url>]",
 		Short: "Edit a merge request",
-		Long:  `Edit the title or description of a merge request.`,
+		Long: `Edit the title, description, target branch, reviewers, assignees or
+labels of a merge request.`,
 		Example: `  # Edit MR interactively
   gf mr edit 42
 
@@ -33,12 +47,18 @@ func newEditCmd() *cobra.Command {
   gf mr edit 42 --title "New title"
 
   # Edit description
-  gf mr edit 42 --body "New description"`,
-		Args: cobra.ExactArgs(1),
+  gf mr edit 42 --body "New description"
+
+  # Retarget to another branch
+  gf mr edit 42 --target release/1.2
+
+  # Change reviewers and labels
+  gf mr edit 42 --add-reviewer alice --remove-reviewer bob --add-label ready`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runEdit(opts, id)
 		},
@@ -47,6 +67,13 @@ func newEditCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
 	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "New title")
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "New description")
+	cmd.Flags().StringVarP(&opts.target, "target", "T", "", "New target branch")
+	cmd.Flags().StringSliceVar(&opts.addReviewers, "add-reviewer", nil, "Add reviewers (username or @me)")
+	cmd.Flags().StringSliceVar(&opts.removeReviewers, "remove-reviewer", nil, "Remove reviewers")
+	cmd.Flags().StringSliceVar(&opts.addAssignees, "add-assignee", nil, "Add assignees (username or @me)")
+	cmd.Flags().StringSliceVar(&opts.removeAssignees, "remove-assignee", nil, "Remove assignees")
+	cmd.Flags().StringSliceVar(&opts.addLabels, "add-label", nil, "Add labels by title")
+	cmd.Flags().StringSliceVar(&opts.removeLabels, "remove-label", nil, "Remove labels by title")
 
 	return cmd
 }
@@ -69,7 +96,7 @@ func runEdit(opts *editOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get current MR info
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -81,7 +108,7 @@ func runEdit(opts *editOptions, id int) error {
 	}
 
 	// Interactive mode if no flags provided
-	if opts.title == "" && opts.body == "" {
+	if opts.title == "" && opts.body == "" && opts.target == "" && !opts.hasListChanges() {
 		reader := bufio.NewReader(os.Stdin)
 
 		fmt.Printf("Editing MR #%d: %s\n\n", mr.LocalID, mr.Title)
@@ -114,13 +141,89 @@ func runEdit(opts *editOptions, id int) error {
 	if opts.body != "" {
 		req.Description = opts.body
 	}
+	if opts.target != "" {
+		if err := validateMRBranch(opts.target, "target"); err != nil {
+			return err
+		}
+		if mr.State() != "open" {
+			return fmt.Errorf("merge request #%d is %s, only open merge requests can be retargeted", id, mr.State())
+		}
+		if opts.target == mr.SourceBranch.Title && !mr.IsCrossProject() {
+			return fmt.Errorf("target branch cannot be the source branch %s", opts.target)
+		}
+		if opts.target != mr.TargetBranch.Title {
+			req.TargetBranch = &api.BranchRef{ID: opts.target}
+		}
+	}
+
+	if err := applyListChanges(client, repo.Owner, repo.Name, mr, opts, req); err != nil {
+		return err
+	}
 
 	// Update MR
-	_, err = client.MergeRequests().Update(repo.Owner, repo.Name, id, req)
+	updated, err := client.MergeRequests().Update(repo.Owner, repo.Name, id, req)
 	if err != nil {
 		return fmt.Errorf("failed to update merge request: %w", err)
 	}
 
 	fmt.Printf("✓ Updated merge request #%d\n", mr.LocalID)
+	if req.TargetBranch != nil {
+		fmt.Printf("  Target: %s → %s\n", mr.TargetBranch.Title, opts.target)
+	}
+	if updated != nil && opts.hasListChanges() {
+		printPeople(updated)
+	}
+	return nil
+}
+
+// applyListChanges sets reviewers, assignees and labels on req from the
+// add/remove flags, starting from the merge request's current lists
+func applyListChanges(client *api.Client, owner, project string, mr *api.MergeRequest, opts *editOptions, req *api.UpdateMRRequest) error {
+	users := newUserResolver(client)
+
+	if len(opts.addReviewers) > 0 || len(opts.removeReviewers) > 0 {
+		add, err := users.resolve(opts.addReviewers)
+		if err != nil {
+			return err
+		}
+		remove, err := users.resolve(opts.removeReviewers)
+		if err != nil {
+			return err
+		}
+		refs := mergeUsers(mr.Reviewers, add, remove)
+		req.Reviewers = &refs
+	}
+
+	if len(opts.addAssignees) > 0 || len(opts.removeAssignees) > 0 {
+		add, err := users.resolve(opts.addAssignees)
+		if err != nil {
+			return err
+		}
+		remove, err := users.resolve(opts.removeAssignees)
+		if err != nil {
+			return err
+		}
+		refs := mergeUsers(mr.Assignees, add, remove)
+		req.AssignedUsers = &refs
+	}
+
+	if len(opts.addLabels) > 0 || len(opts.removeLabels) > 0 {
+		add, err := resolveLabels(client, owner, project, opts.addLabels)
+		if err != nil {
+			return err
+		}
+		// Removing only needs labels present on the merge request
+		var remove []api.Label
+		for _, title := range opts.removeLabels {
+			for _, l := range mr.Labels {
+				if strings.EqualFold(l.Title, title) {
+					remove = append(remove, l)
+				}
+			}
+		}
+		refs := mergeLabels(mr.Labels, add, remove)
+		req.Labels = &refs
+	}
+
 	return nil
 }
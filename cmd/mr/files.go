@@ -0,0 +1,135 @@
+package mr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newFilesCmd() *cobra.Command {
+	opts := &changesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "files [<id> | <branch> | <url>]",
+		Short: "List the files changed by a merge request",
+		Long: `List the files changed by a merge request with their change type
+(A added, M modified, D deleted, R renamed) and line counts, without a
+local clone.`,
+		Example: `  gf mr files 42
+  gf mr files 42 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			return runFiles(opts, id)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runFiles(opts *changesOptions, id int) error {
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	files, err := client.MergeRequests().Files(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	if opts.json {
+		data, err := json.MarshalIndent(files, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(files) == 0 {
+		fmt.Printf("No changed files in MR #%d\n", id)
+		return nil
+	}
+
+	color := term.IsTerminal(int(os.Stdout.Fd())) && !api.NoColor()
+	writeFileList(os.Stdout, files, color)
+	return nil
+}
+
+// changeLetter abbreviates a change type like git status: A, M, D or R
+func changeLetter(changeType string) string {
+	switch strings.ToUpper(changeType) {
+	case "ADD":
+		return "A"
+	case "DELETE":
+		return "D"
+	case "RENAME":
+		return "R"
+	default:
+		return "M"
+	}
+}
+
+// changeSummary formats totals like "3 files changed, +10 -2"
+func changeSummary(files []api.CommitDiff) string {
+	var additions, deletions int
+	for _, f := range files {
+		additions += f.Additions
+		deletions += f.Deletions
+	}
+	return fmt.Sprintf("%d file(s) changed, +%d -%d", len(files), additions, deletions)
+}
+
+// writeFileList prints one changed file per line with its change type
+// and line counts, followed by the totals
+func writeFileList(w io.Writer, files []api.CommitDiff, color bool) {
+	paint := func(c, s string) string {
+		if !color || s == "" {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	nameWidth := 0
+	for _, f := range files {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(fileListName(f)))
+	}
+	for _, f := range files {
+		name := fileListName(f)
+		line := fmt.Sprintf("%s  %s%s  ", changeLetter(f.ChangeType), name,
+			strings.Repeat(" ", nameWidth-utf8.RuneCountInString(name)))
+		if f.Additions > 0 {
+			line += paint(colorAdded, fmt.Sprintf("+%d", f.Additions)) + " "
+		}
+		if f.Deletions > 0 {
+			line += paint(colorRemoved, fmt.Sprintf("-%d", f.Deletions))
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(w, "\n%s\n", changeSummary(files))
+}
+
+// fileListName shows renames as "old → new"
+func fileListName(f api.CommitDiff) string {
+	if oldPath, newPath := filePaths(f); oldPath != newPath {
+		return oldPath + " → " + newPath
+	}
+	return f.FilePath
+}
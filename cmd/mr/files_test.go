@@ -0,0 +1,29 @@
+package mr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestWriteFileList(t *testing.T) {
+	files := []api.CommitDiff{
+		{FilePath: "main.go", ChangeType: "MODIFY", Additions: 10, Deletions: 2},
+		{FilePath: "docs/new.md", ChangeType: "ADD", Additions: 5},
+		{FilePath: "old.txt", ChangeType: "DELETE", Deletions: 3},
+		{FilePath: "b.go", OldPath: "a.go", ChangeType: "RENAME"},
+	}
+
+	var buf bytes.Buffer
+	writeFileList(&buf, files, false)
+
+	want := "M  main.go      +10 -2\n" +
+		"A  docs/new.md  +5\n" +
+		"D  old.txt      -3\n" +
+		"R  a.go → b.go\n" +
+		"\n4 file(s) changed, +15 -5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeFileList() =\n%s\nwant\n%s", got, want)
+	}
+}
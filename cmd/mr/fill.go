@@ -0,0 +1,155 @@
+package mr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+// templateDir holds merge request description templates, one per *.md file
+const templateDir = ".gitflic/merge_request_templates"
+
+// defaultTemplate is applied automatically when no description is given
+const defaultTemplate = "default"
+
+// mrTemplate is a merge request description template found in the repository
+type mrTemplate struct {
+	Name string // file name without .md
+	Path string
+}
+
+// findTemplates returns the templates in root/.gitflic/merge_request_templates,
+// sorted by name. A missing directory yields no templates.
+func findTemplates(root string) ([]mrTemplate, error) {
+	matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(templateDir), "*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]mrTemplate, 0, len(matches))
+	for _, path := range matches {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		templates = append(templates, mrTemplate{Name: name, Path: path})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// lookupTemplate finds a template by name (case-insensitive)
+func lookupTemplate(templates []mrTemplate, name string) *mrTemplate {
+	for i := range templates {
+		if strings.EqualFold(templates[i].Name, name) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
+
+// promptTemplate asks the user to pick one of templates; 0 means none
+func promptTemplate(reader *bufio.Reader, templates []mrTemplate) (*mrTemplate, error) {
+	fmt.Println("Description templates:")
+	fmt.Println("  0) None")
+	for i, t := range templates {
+		fmt.Printf("  %d) %s\n", i+1, t.Name)
+	}
+	fmt.Print("Choose a template [0]: ")
+
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" || input == "0" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 0 || n > len(templates) {
+		return nil, fmt.Errorf("invalid template choice: %s", input)
+	}
+	return &templates[n-1], nil
+}
+
+// readTemplate returns the template contents
+func readTemplate(t *mrTemplate) (string, error) {
+	data, err := os.ReadFile(t.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %s: %w", t.Name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// commitsForMR returns the commits on source that are not on target.
+// The target branch of targetRemote is preferred so that stale local
+// copies of the target do not add unrelated commits.
+func commitsForMR(source, target, targetRemote string) ([]git.Commit, error) {
+	base := target
+	if targetRemote != "" && git.RefExists(targetRemote+"/"+target) {
+		base = targetRemote + "/" + target
+	}
+	if !git.RefExists(base) {
+		return nil, fmt.Errorf("target branch %s not found locally; run 'git fetch' first", target)
+	}
+	if !git.RefExists(source) {
+		return nil, fmt.Errorf("source branch %s not found locally", source)
+	}
+
+	commits, err := git.CommitsBetween(base, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits: %w", err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits between %s and %s", base, source)
+	}
+	return commits, nil
+}
+
+// fillFromCommits builds a title and description from commits.
+// A single commit (or firstOnly) provides its subject and body; several
+// commits produce a title from the branch name and a list of subjects.
+func fillFromCommits(commits []git.Commit, branch string, firstOnly bool) (title, body string) {
+	if len(commits) == 0 {
+		return humanizeBranch(branch), ""
+	}
+	if firstOnly || len(commits) == 1 {
+		return commits[0].Subject, commits[0].Body
+	}
+
+	var sb strings.Builder
+	for _, c := range commits {
+		fmt.Fprintf(&sb, "- %s\n", c.Subject)
+	}
+	return humanizeBranch(branch), strings.TrimSpace(sb.String())
+}
+
+// humanizeBranch turns a branch name into a title:
+// feature/add-user_login -> Add user login
+func humanizeBranch(branch string) string {
+	name := branch
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_'
+	}), " ")
+	if name == "" {
+		return branch
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// editorText formats a title and description for editing:
+// the first line is the title, the rest is the description
+func editorText(title, body string) string {
+	return title + "\n\n" + body + "\n"
+}
+
+// parseEditorText splits edited text into title (first non-empty line)
+// and description
+func parseEditorText(text string) (title, body string) {
+	text = strings.TrimLeft(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	title, body, _ = strings.Cut(text, "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(body)
+}
@@ -0,0 +1,113 @@
+package mr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+func TestFillFromCommits(t *testing.T) {
+	commits := []git.Commit{
+		{Subject: "Add login form", Body: "Uses the new auth API"},
+		{Subject: "Validate password length"},
+	}
+
+	tests := []struct {
+		name      string
+		commits   []git.Commit
+		firstOnly bool
+		wantTitle string
+		wantBody  string
+	}{
+		{"single commit", commits[:1], false, "Add login form", "Uses the new auth API"},
+		{"first only", commits, true, "Add login form", "Uses the new auth API"},
+		{"several commits", commits, false, "Login form", "- Add login form\n- Validate password length"},
+		{"no commits", nil, false, "Login form", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := fillFromCommits(tt.commits, "feature/login-form", tt.firstOnly)
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHumanizeBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"feature/add-user_login", "Add user login"},
+		{"fix-123", "Fix 123"},
+		{"main", "Main"},
+		{"feature/---", "feature/---"},
+	}
+
+	for _, tt := range tests {
+		if got := humanizeBranch(tt.branch); got != tt.want {
+			t.Errorf("humanizeBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestParseEditorText(t *testing.T) {
+	title, body := parseEditorText("\n  Fix crash  \r\n\r\n## Summary\r\nDetails\n")
+	if title != "Fix crash" {
+		t.Errorf("title = %q, want %q", title, "Fix crash")
+	}
+	if body != "## Summary\nDetails" {
+		t.Errorf("body = %q, want %q", body, "## Summary\nDetails")
+	}
+
+	title, body = parseEditorText(editorText("Title", "Body"))
+	if title != "Title" || body != "Body" {
+		t.Errorf("round trip = %q, %q", title, body)
+	}
+}
+
+func TestFindTemplates(t *testing.T) {
+	root := t.TempDir()
+
+	templates, err := findTemplates(root)
+	if err != nil || len(templates) != 0 {
+		t.Fatalf("findTemplates(empty) = %v, %v, want none", templates, err)
+	}
+
+	dir := filepath.Join(root, filepath.FromSlash(templateDir))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"feature.md", "Bugfix.md", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("## "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err = findTemplates(root)
+	if err != nil {
+		t.Fatalf("findTemplates failed: %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "Bugfix" || templates[1].Name != "feature" {
+		t.Fatalf("templates = %+v, want Bugfix and feature", templates)
+	}
+
+	chosen := lookupTemplate(templates, "bugfix")
+	if chosen == nil {
+		t.Fatal("lookupTemplate(bugfix) = nil")
+	}
+	content, err := readTemplate(chosen)
+	if err != nil || content != "## Bugfix.md" {
+		t.Errorf("readTemplate = %q, %v", content, err)
+	}
+	if lookupTemplate(templates, "missing") != nil {
+		t.Error("lookupTemplate(missing) should be nil")
+	}
+}
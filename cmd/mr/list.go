@@ -3,6 +3,8 @@ package mr
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -17,14 +19,28 @@ import (
 const (
 	maxTitleLen  = 47 // Max characters for title column before truncation
 	maxBranchLen = 17 // Max characters for branch column before truncation
-	tableWidth   = 100
+	tableWidth   = 110
+	// ciPipelinesPageSize is how many recent pipelines are searched for
+	// the CI column
+	ciPipelinesPageSize = 100
 )
 
 type listOptions struct {
-	state  string
-	limit  int
-	repo   string
-	json   bool
+	state     string
+	limit     int
+	repo      string
+	json      bool
+	author    string
+	assignee  string
+	reviewer  string
+	labels    []string
+	source    string
+	target    string
+	draft     bool
+	noDraft   bool
+	conflicts bool
+	search    string
+	sort      string
 }
 
 func newListCmd() *cobra.Command {
@@ -33,7 +49,9 @@ func newListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List merge requests",
-		Long:  `List merge requests in the current repository.`,
+		Long: `List merge requests in the current repository.
+
+Filters are combined with AND. Use @me as a username to refer to yourself.`,
 		Example: `  # List open merge requests
   gf mr list
 
@@ -41,20 +59,128 @@ func newListCmd() *cobra.Command {
   gf mr list --state all
 
   # List merged merge requests
-  gf mr list --state merged`,
+  gf mr list --state merged
+
+  # Merge requests waiting for my review
+  gf mr list --reviewer @me --no-draft
+
+  # My merge requests into main with a label
+  gf mr list --author @me --target main --label bug
+
+  # Search titles and descriptions, most recently updated first
+  gf mr list --search "login" --sort updated`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validate(); err != nil {
+				return err
+			}
 			return runList(opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.state, "state", "s", "open", "Filter by state: open, merged, closed, all")
-	cmd.Flags().IntVarP(&opts.limit, "limit", "L", 30, "Maximum number of results")
-	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	addListFlags(cmd, opts, 30)
 	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
 
 	return cmd
 }
 
+// addListFlags adds the filters of 'gf mr list' to cmd, with limit as
+// the default of --limit
+func addListFlags(cmd *cobra.Command, opts *listOptions, limit int) {
+	cmd.Flags().StringVarP(&opts.state, "state", "s", "open", "Filter by state: open, merged, closed, all")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "L", limit, "Maximum number of results")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().StringVarP(&opts.author, "author", "A", "", "Filter by author (username or @me)")
+	cmd.Flags().StringVarP(&opts.assignee, "assignee", "a", "", "Filter by assignee (username or @me)")
+	cmd.Flags().StringVarP(&opts.reviewer, "reviewer", "r", "", "Filter by reviewer (username or @me)")
+	cmd.Flags().StringSliceVarP(&opts.labels, "label", "l", nil, "Filter by label (repeatable, all must match)")
+	cmd.Flags().StringVar(&opts.source, "source", "", "Filter by source branch")
+	cmd.Flags().StringVar(&opts.target, "target", "", "Filter by target branch")
+	cmd.Flags().BoolVar(&opts.draft, "draft", false, "Only draft merge requests")
+	cmd.Flags().BoolVar(&opts.noDraft, "no-draft", false, "Exclude draft merge requests")
+	cmd.Flags().BoolVar(&opts.conflicts, "conflicts", false, "Only merge requests with conflicts")
+	cmd.Flags().StringVarP(&opts.search, "search", "S", "", "Search in title and description")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort by: created, updated (newest first)")
+}
+
+// validate checks flag combinations
+func (opts *listOptions) validate() error {
+	if opts.draft && opts.noDraft {
+		return fmt.Errorf("--draft and --no-draft cannot be used together")
+	}
+	switch opts.sort {
+	case "", "created", "updated":
+	default:
+		return fmt.Errorf("invalid sort %q: use created or updated", opts.sort)
+	}
+	return nil
+}
+
+// hasFilters reports whether any filter besides state is set
+func (opts *listOptions) hasFilters() bool {
+	return opts.author != "" || opts.assignee != "" || opts.reviewer != "" ||
+		len(opts.labels) > 0 || opts.source != "" || opts.target != "" ||
+		opts.draft || opts.noDraft || opts.conflicts || opts.search != ""
+}
+
+// listFilter builds API list options, resolving @me to the current user.
+// Without sorting, fetching stops once --limit merge requests match.
+func (opts *listOptions) listFilter(client *api.Client) (*api.MRListOptions, error) {
+	filter := &api.MRListOptions{
+		State:        opts.state,
+		SourceBranch: opts.source,
+		TargetBranch: opts.target,
+		Labels:       opts.labels,
+		HasConflicts: opts.conflicts,
+		Search:       opts.search,
+	}
+	if opts.sort == "" {
+		filter.Limit = opts.limit
+	}
+	if opts.draft || opts.noDraft {
+		draft := opts.draft
+		filter.Draft = &draft
+	}
+
+	var me string
+	resolve := func(alias string) (string, error) {
+		alias = strings.TrimPrefix(alias, "@")
+		if alias != "me" {
+			return alias, nil
+		}
+		if me == "" {
+			user, err := client.Users().Me()
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve @me: %w", err)
+			}
+			me = user.Username
+		}
+		return me, nil
+	}
+
+	var err error
+	if filter.AuthorAlias, err = resolve(opts.author); err != nil {
+		return nil, err
+	}
+	if filter.AssigneeAlias, err = resolve(opts.assignee); err != nil {
+		return nil, err
+	}
+	if filter.ReviewerAlias, err = resolve(opts.reviewer); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// sortMergeRequests sorts by creation or update time, newest first
+func sortMergeRequests(mrs []api.MergeRequest, by string) {
+	switch by {
+	case "created":
+		sort.SliceStable(mrs, func(i, j int) bool { return mrs[i].CreatedAt.After(mrs[j].CreatedAt) })
+	case "updated":
+		sort.SliceStable(mrs, func(i, j int) bool { return mrs[i].UpdatedAt.After(mrs[j].UpdatedAt) })
+	}
+}
+
 func runList(opts *listOptions) error {
 	// Get repository
 	repo, err := git.ResolveRepo(opts.repo, config.DefaultHost())
@@ -73,24 +199,35 @@ func runList(opts *listOptions) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch merge requests
-	mrs, err := client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{
-		State: opts.state,
-	})
+	var truncated bool
+	fetch := func() (mrs []api.MergeRequest, err error) {
+		filter, err := opts.listFilter(client)
+		if err != nil {
+			return nil, err
+		}
+		mrs, truncated, err = client.MergeRequests().ListTruncated(repo.Owner, repo.Name, filter)
+		return mrs, err
+	}
+
+	mrs, err := fetch()
 	if err != nil {
 		// Try inline re-auth if token is invalid
 		if newClient, reAuthErr := auth.HandleTokenError(err, cfg.ActiveHost); reAuthErr == nil {
 			client = newClient
-			mrs, err = client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{
-				State: opts.state,
-			})
+			mrs, err = fetch()
 		}
 		if err != nil {
 			return fmt.Errorf("failed to list merge requests: %w", err)
 		}
 	}
+	if truncated {
+		fmt.Fprintln(os.Stderr, "! Stopped after the maximum number of pages; results may be incomplete. Narrow the filters to see everything")
+	}
+
+	sortMergeRequests(mrs, opts.sort)
 
 	// Apply limit
 	if opts.limit > 0 && len(mrs) > opts.limit {
@@ -102,6 +239,10 @@ func runList(opts *listOptions) error {
 			fmt.Println("[]")
 			return nil
 		}
+		if opts.hasFilters() {
+			fmt.Printf("No %s merge requests match your filters in %s\n", opts.state, repo.FullName())
+			return nil
+		}
 		fmt.Printf("No %s merge requests in %s\n", opts.state, repo.FullName())
 		return nil
 	}
@@ -116,11 +257,15 @@ func runList(opts *listOptions) error {
 		return nil
 	}
 
+	// Latest pipelines for the CI column; a failure only hides CI status
+	pipelines, _ := client.Pipelines().ListWithOptions(repo.Owner, repo.Name, &api.PipelineListOptions{Size: ciPipelinesPageSize})
+	approvals := approvalSummaries(client, repo.Owner, repo.Name, mrs)
+
 	// Print header
 	fmt.Printf("\nShowing %d merge requests in %s\n\n", len(mrs), repo.FullName())
 
 	// Print table
-	fmt.Printf("%-6s %-50s %-20s %-12s %s\n", "ID", "TITLE", "BRANCH", "AUTHOR", "UPDATED")
+	fmt.Printf("%-6s %-3s %-5s %-50s %-20s %-12s %s\n", "ID", "CI", "APPR", "TITLE", "BRANCH", "AUTHOR", "UPDATED")
 	fmt.Println(strings.Repeat("-", tableWidth))
 
 	for _, mr := range mrs {
@@ -153,9 +298,13 @@ func runList(opts *listOptions) error {
 
 		updated := output.FormatRelativeTime(mr.UpdatedAt)
 
-		fmt.Printf("%s%s%s #%-4d %-48s %-20s @%-11s %s\n",
+		ci := ciIcon(api.LatestPipeline(pipelines, mr.SourceBranch.Title, mr.SourceBranch.Hash))
+
+		fmt.Printf("%s%s%s #%-4d %s   %-5s %-48s %-20s @%-11s %s\n",
 			color, stateIcon, reset,
 			mr.LocalID,
+			ci,
+			approvalColumn(approvals[mr.LocalID]),
 			title,
 			branch,
 			mr.Author.Username,
@@ -0,0 +1,58 @@
+package mr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestListFilter(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	meCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/me" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		meCalls++
+		w.Write([]byte(`{"username": "alice"}`))
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+
+	opts := &listOptions{
+		state:    "open",
+		limit:    30,
+		author:   "bob",
+		assignee: "@me",
+		reviewer: "@me",
+		labels:   []string{"bug", "ui"},
+		noDraft:  true,
+	}
+	filter, err := opts.listFilter(client)
+	if err != nil {
+		t.Fatalf("listFilter() error: %v", err)
+	}
+	if filter.AuthorAlias != "bob" || filter.AssigneeAlias != "alice" || filter.ReviewerAlias != "alice" {
+		t.Errorf("users = %q/%q/%q, want bob/alice/alice", filter.AuthorAlias, filter.AssigneeAlias, filter.ReviewerAlias)
+	}
+	if meCalls != 1 {
+		t.Errorf("@me resolved with %d requests, want 1", meCalls)
+	}
+	if !reflect.DeepEqual(filter.Labels, []string{"bug", "ui"}) || filter.Draft == nil || *filter.Draft {
+		t.Errorf("filter = %+v, want labels bug,ui and no drafts", filter)
+	}
+	if filter.Limit != 30 {
+		t.Errorf("Limit = %d, want 30 without --sort", filter.Limit)
+	}
+	if !opts.hasFilters() {
+		t.Error("hasFilters() = false")
+	}
+
+	if err := (&listOptions{draft: true, noDraft: true}).validate(); err == nil {
+		t.Error("validate() should reject --draft with --no-draft")
+	}
+}
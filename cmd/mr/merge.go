@@ -3,31 +3,63 @@ package mr
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/editor"
 	"github.com/josinSbazin/gf/internal/git"
 	"github.com/spf13/cobra"
 )
 
+// workerFlag marks the detached process started by 'gf mr merge --auto --background'
+const workerFlag = "auto-merge-worker"
+
 type mergeOptions struct {
-	squash       bool
-	deleteBranch bool
-	yes          bool
-	repo         string
+	squash        bool
+	rebase        bool
+	merge         bool
+	deleteBranch  bool
+	yes           bool
+	repo          string
+	message       string
+	messageFile   string
+	edit          bool
+	squashMessage string
+	force         bool
+	auto          bool
+	background    bool
+	interval      int
+	timeout       time.Duration
+	worker        bool
 }
 
 func newMergeCmd() *cobra.Command {
 	opts := &mergeOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "merge <id>",
+		Use:   "merge [<id> | <branch> | <url>]",
 		Short: "Merge a merge request",
-		Long:  `Merge a merge request.`,
-		Example: `  # Select MR to merge interactively
+		Long: `Merge a merge request.
+
+Before merging, gf checks that the merge request is not a draft, the
+pipeline of the source branch's head commit succeeded, all discussions
+are resolved and required approvals are given. Use --admin (or --force)
+to merge anyway.
+
+The merge method is chosen with --merge, --squash or --rebase; without
+them the project default is used. --edit opens the merge commit message
+(or the squash commit message with --squash) in $EDITOR.
+
+With --auto, gf waits for the pipeline of the source branch's head commit
+and merges once it succeeds and GitFlic allows the merge (required
+approvals are given). Auto-merge is aborted if the pipeline fails, new
+commits are pushed or the merge request is closed. With --background it
+runs in a detached process; its progress is shown by 'gf mr view'.`,
+		Example: `  # Merge the current branch's MR, or select one interactively
   gf mr merge
 
   # Merge specific MR
@@ -37,25 +69,65 @@ func newMergeCmd() *cobra.Command {
   gf mr merge 12 --squash
 
   # Merge without confirmation
-  gf mr merge 12 --yes`,
+  gf mr merge 12 --yes
+
+  # Rebase onto the target branch instead of a merge commit
+  gf mr merge 12 --rebase
+
+  # Set the merge commit message
+  gf mr merge 12 --message "Release 1.2"
+  gf mr merge 12 --edit
+
+  # Merge despite a failed pipeline or unresolved discussions
+  gf mr merge 12 --admin
+
+  # Merge when the pipeline succeeds
+  gf mr merge 12 --auto
+
+  # Same, without keeping the terminal busy
+  gf mr merge 12 --auto --background`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var id int
-			if len(args) > 0 {
-				var err error
-				id, err = strconv.Atoi(strings.TrimPrefix(args[0], "#"))
-				if err != nil {
-					return fmt.Errorf("invalid merge request ID: %s", args[0])
-				}
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			if opts.background && !opts.auto {
+				return fmt.Errorf("--background requires --auto")
+			}
+			if opts.squashMessage != "" && !opts.squash {
+				return fmt.Errorf("--squash-message requires --squash")
+			}
+			if opts.rebase && (opts.message != "" || opts.messageFile != "" || opts.edit) {
+				return fmt.Errorf("--rebase does not create a merge commit; --message, --message-file and --edit cannot be used")
+			}
+			if opts.interval < 1 {
+				return fmt.Errorf("--interval must be at least 1 second")
 			}
 			return runMerge(opts, id)
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.merge, "merge", false, "Merge with a merge commit")
 	cmd.Flags().BoolVar(&opts.squash, "squash", false, "Squash commits when merging")
+	cmd.Flags().BoolVar(&opts.rebase, "rebase", false, "Rebase commits onto the target branch")
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Merge commit message")
+	cmd.Flags().StringVar(&opts.messageFile, "message-file", "", "Read merge commit message from file (- for stdin)")
+	cmd.Flags().BoolVarP(&opts.edit, "edit", "e", false, "Edit the commit message in $EDITOR")
+	cmd.Flags().StringVar(&opts.squashMessage, "squash-message", "", "Squash commit message")
+	cmd.Flags().BoolVar(&opts.force, "admin", false, "Merge even if pre-merge checks fail")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Alias for --admin")
 	cmd.Flags().BoolVarP(&opts.deleteBranch, "delete-branch", "d", false, "Delete source branch after merge")
 	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.auto, "auto", false, "Merge when the pipeline succeeds")
+	cmd.Flags().BoolVar(&opts.background, "background", false, "Run auto-merge in a detached process")
+	cmd.Flags().IntVarP(&opts.interval, "interval", "i", 10, "Auto-merge polling interval in seconds")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 2*time.Hour, "Give up auto-merge after this long (0 to wait forever)")
+	cmd.Flags().BoolVar(&opts.worker, workerFlag, false, "")
+	cmd.Flags().MarkHidden(workerFlag)
+	cmd.MarkFlagsMutuallyExclusive("merge", "squash", "rebase")
+	cmd.MarkFlagsMutuallyExclusive("message", "message-file")
 
 	return cmd
 }
@@ -78,47 +150,7 @@ func runMerge(opts *mergeOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
-
-	// Interactive mode: select from open MRs if no ID provided
-	if id == 0 {
-		mrs, err := client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{
-			State: "open",
-		})
-		if err != nil {
-			return fmt.Errorf("failed to list merge requests: %w", err)
-		}
-
-		if len(mrs) == 0 {
-			return fmt.Errorf("no open merge requests in %s", repo.FullName())
-		}
-
-		fmt.Println("Open merge requests:")
-		for i, mr := range mrs {
-			if i >= 10 {
-				fmt.Printf("  ... and %d more\n", len(mrs)-10)
-				break
-			}
-			fmt.Printf("  #%-4d %s [%s → %s]\n",
-				mr.LocalID, truncateTitle(mr.Title, 40),
-				truncateTitle(mr.SourceBranch.Title, 15),
-				truncateTitle(mr.TargetBranch.Title, 15))
-		}
-
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("\nEnter MR number to merge: ")
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-
-		if input == "" {
-			return fmt.Errorf("no MR selected")
-		}
-
-		id, err = strconv.Atoi(strings.TrimPrefix(input, "#"))
-		if err != nil {
-			return fmt.Errorf("invalid merge request ID: %s", input)
-		}
-	}
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get merge request first to show info
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -137,13 +169,37 @@ func runMerge(opts *mergeOptions, id int) error {
 		return fmt.Errorf("merge request #%d has conflicts, resolve them first", id)
 	}
 
+	// Pre-merge checks; auto-merge waits for a running pipeline and approvals
+	blockers, err := preMergeChecks(client, repo, mr)
+	if err != nil {
+		return err
+	}
+	if blocking := blockingFor(blockers, opts.auto); len(blocking) > 0 {
+		if !opts.force {
+			return fmt.Errorf("merge request #%d is not ready to be merged:\n%s\nUse --admin to merge anyway",
+				id, formatBlockers(blocking))
+		}
+		if !opts.worker {
+			fmt.Printf("! Merging despite failed checks:\n%s\n", formatBlockers(blocking))
+		}
+	}
+
+	req, err := mergeRequestFor(opts, repo, mr)
+	if err != nil {
+		return err
+	}
+
 	// Confirm if not --yes
 	if !opts.yes {
 		fmt.Printf("Merge request #%d: %s\n", mr.LocalID, mr.Title)
-		fmt.Printf("  %s → %s\n\n", mr.SourceBranch.Title, mr.TargetBranch.Title)
+		fmt.Printf("  %s → %s (%s)\n\n", mr.SourceBranch.Title, mr.TargetBranch.Title, mergeMethodName(req))
 
 		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Merge this merge request? [y/N] ")
+		if opts.auto {
+			fmt.Print("Merge this merge request when the pipeline succeeds? [y/N] ")
+		} else {
+			fmt.Print("Merge this merge request? [y/N] ")
+		}
 		answer, _ := reader.ReadString('\n')
 		answer = strings.TrimSpace(strings.ToLower(answer))
 
@@ -153,11 +209,15 @@ func runMerge(opts *mergeOptions, id int) error {
 		}
 	}
 
+	if opts.auto {
+		if opts.background {
+			return startBackgroundAutoMerge(repo, mr, opts, req)
+		}
+		return runAutoMerge(client, repo, mr, opts, req)
+	}
+
 	// Merge
-	err = client.MergeRequests().Merge(repo.Owner, repo.Name, id, &api.MergeMRRequest{
-		SquashCommit:       opts.squash,
-		RemoveSourceBranch: opts.deleteBranch,
-	})
+	err = client.MergeRequests().Merge(repo.Owner, repo.Name, id, req)
 	if err != nil {
 		return fmt.Errorf("failed to merge: %w", err)
 	}
@@ -171,6 +231,78 @@ func runMerge(opts *mergeOptions, id int) error {
 	return nil
 }
 
+// mergeRequestFor builds the merge parameters from flags, reading the
+// commit message from a file or the editor when requested
+func mergeRequestFor(opts *mergeOptions, repo *git.Repository, mr *api.MergeRequest) (*api.MergeMRRequest, error) {
+	req := &api.MergeMRRequest{
+		RemoveSourceBranch:  opts.deleteBranch,
+		MergeCommitMessage:  opts.message,
+		SquashCommitMessage: opts.squashMessage,
+	}
+	switch {
+	case opts.squash:
+		req.SquashCommit = true
+		req.MergeMethod = api.MergeMethodSquash
+	case opts.rebase:
+		req.MergeMethod = api.MergeMethodRebase
+	case opts.merge:
+		req.MergeMethod = api.MergeMethodMerge
+	}
+
+	if opts.messageFile != "" {
+		var data []byte
+		var err error
+		if opts.messageFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(opts.messageFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message file: %w", err)
+		}
+		req.MergeCommitMessage = strings.TrimSpace(string(data))
+	}
+
+	if opts.edit {
+		// With --squash the squash commit is the only commit created
+		message := &req.MergeCommitMessage
+		initial := defaultMergeMessage(repo, mr)
+		if opts.squash {
+			message = &req.SquashCommitMessage
+			initial = mr.Title + "\n"
+		}
+		if *message != "" {
+			initial = *message + "\n"
+		}
+		initial += "\n# Enter the commit message. Lines starting with '#' are ignored;\n# an empty message aborts the merge.\n"
+
+		text, err := editor.Edit("MERGE_MSG", initial)
+		if err != nil {
+			return nil, err
+		}
+		*message = parseCommitMessage(text)
+		if *message == "" {
+			return nil, fmt.Errorf("aborting merge due to empty commit message")
+		}
+	}
+
+	return req, nil
+}
+
+// mergeMethodName describes the merge method of req for the confirmation prompt
+func mergeMethodName(req *api.MergeMRRequest) string {
+	switch req.MergeMethod {
+	case api.MergeMethodSquash:
+		return "squash"
+	case api.MergeMethodRebase:
+		return "rebase"
+	case api.MergeMethodMerge:
+		return "merge commit"
+	default:
+		return "project default"
+	}
+}
+
 func truncateTitle(s string, maxLen int) string {
 	if len(s) > maxLen {
 		return s[:maxLen-3] + "..."
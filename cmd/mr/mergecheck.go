@@ -0,0 +1,141 @@
+package mr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+// mergeBlocker is a pre-merge check that did not pass
+type mergeBlocker struct {
+	message string
+	// pending blockers may clear by themselves (a running pipeline,
+	// missing approvals); auto-merge waits for them instead of failing
+	pending bool
+}
+
+// preMergeChecks returns the reasons why mr should not be merged yet:
+// draft status, the pipeline of the head commit, unresolved discussions
+// and required approvals.
+func preMergeChecks(client *api.Client, repo *git.Repository, mr *api.MergeRequest) ([]mergeBlocker, error) {
+	var blockers []mergeBlocker
+
+	if mr.IsDraft {
+		blockers = append(blockers, mergeBlocker{
+			message: fmt.Sprintf("merge request is a draft (run 'gf mr ready %d')", mr.LocalID),
+		})
+	}
+
+	if mr.SourceBranch.Hash != "" {
+		p, err := client.Pipelines().LatestForCommit(context.Background(), repo.Owner, repo.Name, mr.SourceBranch.Title, mr.SourceBranch.Hash)
+		switch {
+		case err == nil:
+			if b := pipelineBlocker(p); b != nil {
+				blockers = append(blockers, *b)
+			}
+		case !api.IsNotFound(err):
+			// No pipeline (or no CI) for the head commit is not a blocker
+			return nil, fmt.Errorf("failed to check pipeline: %w", err)
+		}
+	}
+
+	threads, err := client.MergeRequests().ListDiscussionThreads(repo.Owner, repo.Name, mr.LocalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check discussions: %w", err)
+	}
+	if n := countUnresolved(threads); n > 0 {
+		blockers = append(blockers, mergeBlocker{
+			message: fmt.Sprintf("%d unresolved discussion(s) (see 'gf mr comments %d')", n, mr.LocalID),
+		})
+	}
+
+	b, err := approvalBlocker(client, repo, mr)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		blockers = append(blockers, *b)
+	}
+
+	return blockers, nil
+}
+
+// approvalBlocker returns a blocker while mr lacks required approvals
+func approvalBlocker(client *api.Client, repo *git.Repository, mr *api.MergeRequest) (*mergeBlocker, error) {
+	missing, err := client.MergeRequests().MissingApprovals(repo.Owner, repo.Name, mr)
+	if err != nil || missing == "" {
+		return nil, err
+	}
+	return &mergeBlocker{message: missing, pending: true}, nil
+}
+
+// pipelineBlocker returns a blocker unless p succeeded
+func pipelineBlocker(p *api.Pipeline) *mergeBlocker {
+	switch {
+	case p.Succeeded():
+		return nil
+	case p.IsFinished():
+		return &mergeBlocker{message: fmt.Sprintf("pipeline #%d %s", p.LocalID, p.NormalizedStatus())}
+	default:
+		return &mergeBlocker{
+			message: fmt.Sprintf("pipeline #%d is %s", p.LocalID, p.NormalizedStatus()),
+			pending: true,
+		}
+	}
+}
+
+// countUnresolved returns the number of unresolved resolvable threads;
+// general comments are not counted
+func countUnresolved(threads []api.DiscussionThread) int {
+	n := 0
+	for _, t := range threads {
+		if t.Resolvable() && !t.RootNote.Resolved {
+			n++
+		}
+	}
+	return n
+}
+
+// blockingFor filters blockers that prevent merging now (auto=false) or
+// enabling auto-merge (auto=true)
+func blockingFor(blockers []mergeBlocker, auto bool) []mergeBlocker {
+	var result []mergeBlocker
+	for _, b := range blockers {
+		if auto && b.pending {
+			continue
+		}
+		result = append(result, b)
+	}
+	return result
+}
+
+// formatBlockers formats blockers as an indented list
+func formatBlockers(blockers []mergeBlocker) string {
+	lines := make([]string, len(blockers))
+	for i, b := range blockers {
+		lines[i] = "  • " + b.message
+	}
+	return strings.Join(lines, "\n")
+}
+
+// defaultMergeMessage returns the merge commit message proposed in the editor
+func defaultMergeMessage(repo *git.Repository, mr *api.MergeRequest) string {
+	return fmt.Sprintf("Merge branch '%s' into '%s'\n\n%s\n\nSee merge request %s!%d\n",
+		mr.SourceBranch.Title, mr.TargetBranch.Title, mr.Title, repo.FullName(), mr.LocalID)
+}
+
+// parseCommitMessage strips '#' comment lines and surrounding blank lines
+// from a commit message edited in the editor
+func parseCommitMessage(text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
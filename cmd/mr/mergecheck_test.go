@@ -0,0 +1,217 @@
+package mr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+func TestPreMergeChecks(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/project/owner/repo/cicd/pipeline":
+			w.Write([]byte(`{"_embedded": {"restPipelineModelList": [
+				{"localId": 4, "status": "FAILED", "ref": "feature", "commitId": "aaa111"}
+			]}}`))
+		case "/project/owner/repo/merge-request/7/discussions":
+			w.Write([]byte(`{"_embedded": {"restDiscussionModelList": [
+				{"rootNote": {"uuid": "1", "resolved": true, "newPath": "main.go", "newLine": 3}},
+				{"rootNote": {"uuid": "2", "resolved": false, "newPath": "main.go", "newLine": 5}},
+				{"rootNote": {"uuid": "3", "resolved": false, "message": "general comments do not block"}}
+			]}}`))
+		case "/project/owner/repo/merge-request/7/approvals":
+			w.Write([]byte(`{"approvalsRequired": 2, "approvals": [{"user": {"username": "bob"}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	repo := &git.Repository{Host: "example.com", Owner: "owner", Name: "repo"}
+	mr := &api.MergeRequest{
+		LocalID:      7,
+		IsDraft:      true,
+		SourceBranch: api.Branch{Title: "feature", Hash: "aaa111"},
+	}
+
+	blockers, err := preMergeChecks(client, repo, mr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, b := range blockers {
+		got = append(got, b.message)
+	}
+	want := []string{
+		"merge request is a draft (run 'gf mr ready 7')",
+		"pipeline #4 failed",
+		"1 unresolved discussion(s) (see 'gf mr comments 7')",
+		"1 more approval(s) required (1/2 approved)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("blockers = %q, want %q", got, want)
+	}
+
+	// Auto-merge waits for approvals but not for the rest
+	if n := len(blockingFor(blockers, true)); n != 3 {
+		t.Errorf("blockingFor(auto) = %d blockers, want 3", n)
+	}
+}
+
+func TestPipelineBlocker(t *testing.T) {
+	tests := []struct {
+		status      string
+		wantBlocked bool
+		wantPending bool
+	}{
+		{"SUCCESS", false, false},
+		{"FAILED", true, false},
+		{"CANCELED", true, false},
+		{"RUNNING", true, true},
+		{"PENDING", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			b := pipelineBlocker(&api.Pipeline{LocalID: 1, Status: tt.status})
+			if (b != nil) != tt.wantBlocked {
+				t.Fatalf("pipelineBlocker() = %v, want blocked %v", b, tt.wantBlocked)
+			}
+			if b != nil && b.pending != tt.wantPending {
+				t.Errorf("pending = %v, want %v", b.pending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestParseCommitMessage(t *testing.T) {
+	text := "Merge branch 'a' into 'b'  \r\n\r\nBody line\n# comment\n\n# another\n"
+	want := "Merge branch 'a' into 'b'\n\nBody line"
+	if got := parseCommitMessage(text); got != want {
+		t.Errorf("parseCommitMessage() = %q, want %q", got, want)
+	}
+	if got := parseCommitMessage("# only comments\n\n"); got != "" {
+		t.Errorf("parseCommitMessage() = %q, want empty", got)
+	}
+}
+
+func TestMergeRequestFor(t *testing.T) {
+	repo := &git.Repository{Owner: "owner", Name: "repo"}
+	mr := &api.MergeRequest{LocalID: 7, Title: "Add feature"}
+
+	req, err := mergeRequestFor(&mergeOptions{squash: true, squashMessage: "Feature", deleteBranch: true}, repo, mr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &api.MergeMRRequest{
+		SquashCommit:        true,
+		RemoveSourceBranch:  true,
+		SquashCommitMessage: "Feature",
+		MergeMethod:         api.MergeMethodSquash,
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("mergeRequestFor() = %+v, want %+v", req, want)
+	}
+
+	// The background worker receives the same parameters as flags
+	args := mergeArgs(req, true)
+	wantArgs := []string{"--squash", "--delete-branch", "--squash-message=Feature", "--admin"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("mergeArgs() = %q, want %q", args, wantArgs)
+	}
+
+	req, err = mergeRequestFor(&mergeOptions{rebase: true}, repo, mr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.MergeMethod != api.MergeMethodRebase || req.SquashCommit {
+		t.Errorf("mergeRequestFor(rebase) = %+v", req)
+	}
+}
+
+func TestDefaultMergeMessage(t *testing.T) {
+	repo := &git.Repository{Owner: "owner", Name: "repo"}
+	mr := &api.MergeRequest{
+		LocalID:      7,
+		Title:        "Add feature",
+		SourceBranch: api.Branch{Title: "feature"},
+		TargetBranch: api.Branch{Title: "main"},
+	}
+	got := defaultMergeMessage(repo, mr)
+	if !strings.HasPrefix(got, "Merge branch 'feature' into 'main'\n\nAdd feature\n") ||
+		!strings.Contains(got, "owner/repo!7") {
+		t.Errorf("defaultMergeMessage() = %q", got)
+	}
+}
+
+func TestMergeCmd_FlagValidation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"7", "--squash", "--rebase"}, "none of the others can be"},
+		{[]string{"7", "--squash-message", "x"}, "--squash-message requires --squash"},
+		{[]string{"7", "--rebase", "--message", "x"}, "--rebase does not create a merge commit"},
+		{[]string{"7", "--message", "x", "--message-file", "f"}, "none of the others can be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cmd := newMergeCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApprovalBlocker_Fallback(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	// Servers without the approvals API answer 404
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	repo := &git.Repository{Host: "example.com", Owner: "owner", Name: "repo"}
+
+	b, err := approvalBlocker(client, repo, &api.MergeRequest{LocalID: 7, CanMerge: false})
+	if err != nil || b == nil || b.message != "GitFlic reports the MR cannot be merged yet" || !b.pending {
+		t.Errorf("approvalBlocker(canMerge=false) = %+v, %v", b, err)
+	}
+	if b, err := approvalBlocker(client, repo, &api.MergeRequest{LocalID: 7, CanMerge: true}); err != nil || b != nil {
+		t.Errorf("approvalBlocker(canMerge=true) = %+v, %v", b, err)
+	}
+}
+
+func TestApprovalColumn(t *testing.T) {
+	one := []api.Approval{{User: api.User{Username: "alice"}}}
+	tests := []struct {
+		approvals *api.MRApprovals
+		want      string
+	}{
+		{nil, "-"},
+		{&api.MRApprovals{}, "-"},
+		{&api.MRApprovals{Required: 2, Approvals: one}, "1/2"},
+		{&api.MRApprovals{Required: 1, Approvals: one}, "✓1/1"},
+		{&api.MRApprovals{Approvals: one}, "✓1"},
+	}
+	for _, tt := range tests {
+		if got := approvalColumn(tt.approvals); got != tt.want {
+			t.Errorf("approvalColumn(%+v) = %q, want %q", tt.approvals, got, tt.want)
+		}
+	}
+}
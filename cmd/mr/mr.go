@@ -10,25 +10,37 @@ func NewCmdMR() *cobra.Command {
 		Use:     "mr",
 		Aliases: []string{"merge-request"},
 		Short:   "Work with merge requests",
-		Long:    `Create, view, and manage merge requests.`,
+		Long: `Create, view, and manage merge requests.
+
+Commands that act on one merge request take its ID (12, #12 or !12), the
+name of its source branch or its URL. Without one, they use the open merge
+request of the current branch, or let you pick one in a terminal if it has
+none.`,
 	}
 
 	cmd.AddCommand(newListCmd())
 	cmd.AddCommand(newViewCmd())
 	cmd.AddCommand(newCreateCmd())
 	cmd.AddCommand(newMergeCmd())
+	cmd.AddCommand(newChecksCmd())
 	cmd.AddCommand(newCloseCmd())
 	cmd.AddCommand(newCheckoutCmd())
 	cmd.AddCommand(newApproveCmd())
+	cmd.AddCommand(newApprovalsCmd())
 	cmd.AddCommand(newDiffCmd())
+	cmd.AddCommand(newCommitsCmd())
+	cmd.AddCommand(newFilesCmd())
 	cmd.AddCommand(newEditCmd())
+	cmd.AddCommand(newBulkCmd())
 	cmd.AddCommand(newReopenCmd())
+	cmd.AddCommand(newRevertCmd())
 	cmd.AddCommand(newReadyCmd())
 	cmd.AddCommand(newCommentCmd())
 	cmd.AddCommand(newCommentsCmd())
 	cmd.AddCommand(newReplyCmd())
 	cmd.AddCommand(newResolveCmd())
 	cmd.AddCommand(newReviewCmd())
+	cmd.AddCommand(newApplySuggestionsCmd())
 
 	return cmd
 }
@@ -0,0 +1,221 @@
+package mr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
+)
+
+// mrRef is a merge request given on the command line. The zero value
+// means the merge request of the current branch.
+type mrRef struct {
+	ID     int
+	Branch string
+	// Repo is host/owner/name, set when the reference is a URL
+	Repo string
+}
+
+// parseMRRef parses "12", "#12", "!12", a merge request URL such as
+// https://gitflic.ru/project/owner/name/merge-request/12 or a branch name
+func parseMRRef(arg string) (mrRef, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return mrRef{}, nil
+	}
+
+	// All-digit arguments are IDs, even if a branch has that name
+	num, prefixed := arg, arg[0] == '#' || arg[0] == '!'
+	if prefixed {
+		num = arg[1:]
+	}
+	if prefixed || strings.Trim(num, "0123456789") == "" {
+		n, err := strconv.Atoi(num)
+		if err != nil || n <= 0 {
+			return mrRef{}, fmt.Errorf("invalid merge request ID: %s", arg)
+		}
+		return mrRef{ID: n}, nil
+	}
+
+	if strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://") {
+		return parseMRURL(arg)
+	}
+
+	if err := validateBranchName(arg); err != nil {
+		return mrRef{}, fmt.Errorf("invalid merge request ID or branch: %s", arg)
+	}
+	return mrRef{Branch: arg}, nil
+}
+
+// parseMRURL parses the web URL of a merge request
+func parseMRURL(raw string) (mrRef, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return mrRef{}, fmt.Errorf("invalid merge request URL: %s", raw)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 5 || parts[0] != "project" || parts[3] != "merge-request" {
+		return mrRef{}, fmt.Errorf("not a merge request URL: %s\nExpected https://<host>/project/<owner>/<repo>/merge-request/<id>", raw)
+	}
+	id, err := strconv.Atoi(parts[4])
+	if err != nil || id <= 0 {
+		return mrRef{}, fmt.Errorf("invalid merge request ID in URL: %s", raw)
+	}
+	repo, err := git.ParseRepoFlag(u.Hostname()+"/"+parts[1]+"/"+parts[2], "")
+	if err != nil {
+		return mrRef{}, fmt.Errorf("invalid merge request URL: %w", err)
+	}
+	return mrRef{ID: id, Repo: repo.Host + "/" + repo.Owner + "/" + repo.Name}, nil
+}
+
+// mrIDArg resolves the optional merge request argument of a command to an
+// ID. Branch names, and the current branch when args is empty, are looked
+// up among open merge requests. A URL selects its repository by setting
+// *repoFlag.
+func mrIDArg(args []string, repoFlag *string) (int, error) {
+	var arg string
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	ref, err := parseMRRef(arg)
+	if err != nil {
+		return 0, err
+	}
+	if ref.Repo != "" {
+		if *repoFlag != "" && !sameRepo(*repoFlag, ref.Repo) {
+			return 0, fmt.Errorf("merge request URL is in %s, but --repo is %s", ref.Repo, *repoFlag)
+		}
+		*repoFlag = ref.Repo
+	}
+	if ref.ID > 0 {
+		return ref.ID, nil
+	}
+
+	repo, client, err := repoClient(*repoFlag)
+	if err != nil {
+		return 0, err
+	}
+	mr, err := mrForBranch(client, repo, ref.Branch)
+	var noMR *noBranchMRError
+	if errors.As(err, &noMR) && noMR.current && prompt.Enabled() {
+		mr, err = selectMR(client, repo)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return mr.LocalID, nil
+}
+
+// selectMR lets the user pick one of the open merge requests
+func selectMR(client *api.Client, repo *git.Repository) (*api.MergeRequest, error) {
+	mrs, err := client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{State: "open"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+	if len(mrs) == 0 {
+		return nil, fmt.Errorf("no open merge requests in %s", repo.FullName())
+	}
+
+	items := make([]prompt.Item, len(mrs))
+	for i := range mrs {
+		mr := &mrs[i]
+		items[i].Label = fmt.Sprintf("#%d %s [%s → %s]",
+			mr.LocalID, mr.Title, mr.SourceBranch.Title, mr.TargetBranch.Title)
+
+		var preview strings.Builder
+		fmt.Fprintf(&preview, "@%s · updated %s", mr.Author.Username, output.FormatRelativeTime(mr.UpdatedAt))
+		if mr.IsDraft {
+			preview.WriteString(" · draft")
+		}
+		if mr.HasConflicts {
+			preview.WriteString(" · has conflicts")
+		}
+		if desc := strings.TrimSpace(mr.Description); desc != "" {
+			preview.WriteString("\n\n" + desc)
+		}
+		items[i].Preview = preview.String()
+	}
+
+	i, err := prompt.Select("Select a merge request", items)
+	if err != nil {
+		return nil, err
+	}
+	return &mrs[i], nil
+}
+
+// sameRepo reports whether --repo flag value a names the repository b
+// (host/owner/name); a flag without host matches any host
+func sameRepo(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	return a == b || strings.HasSuffix(b, "/"+a) && strings.Count(a, "/") == 1
+}
+
+// noBranchMRError means no open merge request has the branch as source
+type noBranchMRError struct {
+	branch  string
+	repo    string
+	current bool
+}
+
+func (e *noBranchMRError) Error() string {
+	if e.current {
+		return fmt.Sprintf("no open merge request for the current branch %s\nSpecify a merge request ID, or create one with 'gf mr create'", e.branch)
+	}
+	return fmt.Sprintf("no open merge request for branch %s in %s", e.branch, e.repo)
+}
+
+// mrForCurrentBranch returns the open merge request of the current branch
+func mrForCurrentBranch(client *api.Client, repo *git.Repository) (*api.MergeRequest, error) {
+	return mrForBranch(client, repo, "")
+}
+
+// mrForBranch returns the open merge request whose source branch is
+// branch, or the current branch if branch is empty. It fails unless
+// exactly one open merge request matches.
+func mrForBranch(client *api.Client, repo *git.Repository, branch string) (*api.MergeRequest, error) {
+	current := branch == ""
+	if current {
+		var err error
+		branch, err = git.CurrentBranch()
+		if err != nil {
+			return nil, fmt.Errorf("could not determine current branch: %w\nSpecify a merge request ID", err)
+		}
+	}
+
+	// Two matches are enough to know the branch is ambiguous
+	mrs, err := client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{
+		State:        "open",
+		SourceBranch: branch,
+		Limit:        2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+
+	var matches []*api.MergeRequest
+	for i := range mrs {
+		if mrs[i].SourceBranch.Title == branch {
+			matches = append(matches, &mrs[i])
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return nil, &noBranchMRError{branch: branch, repo: repo.FullName(), current: current}
+	default:
+		ids := make([]string, len(matches))
+		for i, mr := range matches {
+			ids[i] = "#" + strconv.Itoa(mr.LocalID)
+		}
+		return nil, fmt.Errorf("branch %s has %d open merge requests (%s)\nSpecify one by ID",
+			branch, len(matches), strings.Join(ids, ", "))
+	}
+}
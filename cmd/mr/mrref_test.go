@@ -0,0 +1,104 @@
+package mr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+func TestParseMRRef(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    mrRef
+		wantErr bool
+	}{
+		{"", mrRef{}, false},
+		{"12", mrRef{ID: 12}, false},
+		{"#12", mrRef{ID: 12}, false},
+		{"!12", mrRef{ID: 12}, false},
+		{"feature/login", mrRef{Branch: "feature/login"}, false},
+		{"fix-12", mrRef{Branch: "fix-12"}, false},
+		{"https://gitflic.ru/project/owner/repo/merge-request/7", mrRef{ID: 7, Repo: "gitflic.ru/owner/repo"}, false},
+		{"https://git.example.com/project/owner/repo/merge-request/7/diff?x=1", mrRef{ID: 7, Repo: "git.example.com/owner/repo"}, false},
+		{"0", mrRef{}, true},
+		{"#abc", mrRef{}, true},
+		{"--upload-pack=x", mrRef{}, true},
+		{"a..b", mrRef{}, true},
+		{"https://gitflic.ru/project/owner/repo", mrRef{}, true},
+		{"https://gitflic.ru/project/owner/repo/issue/7", mrRef{}, true},
+		{"https://gitflic.ru/project/owner/repo/merge-request/x", mrRef{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := parseMRRef(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMRRef(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseMRRef(%q) = %+v, want %+v", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMRIDArg_URLRepo(t *testing.T) {
+	repoFlag := ""
+	id, err := mrIDArg([]string{"https://gitflic.ru/project/owner/repo/merge-request/7"}, &repoFlag)
+	if err != nil || id != 7 || repoFlag != "gitflic.ru/owner/repo" {
+		t.Errorf("mrIDArg(url) = %d, %v; repo %q", id, err, repoFlag)
+	}
+
+	repoFlag = "owner/repo"
+	if _, err := mrIDArg([]string{"https://gitflic.ru/project/owner/repo/merge-request/7"}, &repoFlag); err != nil {
+		t.Errorf("mrIDArg(url) with matching --repo: %v", err)
+	}
+
+	repoFlag = "other/repo"
+	if _, err := mrIDArg([]string{"https://gitflic.ru/project/owner/repo/merge-request/7"}, &repoFlag); err == nil {
+		t.Error("mrIDArg(url) with different --repo: want error")
+	}
+}
+
+func TestMRForBranch(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/project/owner/repo/merge-request/list" {
+			http.NotFound(w, r)
+			return
+		}
+		// The filter is not trusted: other branches are returned as well
+		w.Write([]byte(`{"_embedded": {"mergeRequestModelList": [
+			{"localId": 1, "sourceBranch": {"title": "feature"}},
+			{"localId": 2, "sourceBranch": {"title": "twice"}},
+			{"localId": 3, "sourceBranch": {"title": "twice"}},
+			{"localId": 4, "sourceBranch": {"title": "feature-2"}}
+		]}, "page": {"size": 50, "totalElements": 4, "totalPages": 1, "number": 0}}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	repo := &git.Repository{Host: "example.com", Owner: "owner", Name: "repo"}
+
+	mr, err := mrForBranch(client, repo, "feature")
+	if err != nil || mr.LocalID != 1 {
+		t.Errorf("mrForBranch(feature) = %+v, %v; want #1", mr, err)
+	}
+
+	_, err = mrForBranch(client, repo, "twice")
+	if err == nil || !strings.Contains(err.Error(), "#2, #3") {
+		t.Errorf("mrForBranch(twice) error = %v, want ambiguity listing #2, #3", err)
+	}
+
+	_, err = mrForBranch(client, repo, "missing")
+	var noMR *noBranchMRError
+	if !errors.As(err, &noMR) || noMR.branch != "missing" {
+		t.Errorf("mrForBranch(missing) error = %v, want noBranchMRError", err)
+	}
+}
@@ -0,0 +1,246 @@
+package mr
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+// Diff colors (git defaults)
+const (
+	colorMeta    = "\033[1m"
+	colorHunk    = "\033[36m"
+	colorAdded   = "\033[32m"
+	colorRemoved = "\033[31m"
+	colorReset   = "\033[0m"
+)
+
+// mboxFromLine matches body lines that would be read as the start of a
+// new message in an mbox, including already escaped ones (mboxrd)
+var mboxFromLine = regexp.MustCompile(`^>*From `)
+
+// statBarWidth is the maximum width of the +/- bar in --stat output
+const statBarWidth = 50
+
+// filePaths returns the old and new path of a file diff
+func filePaths(d api.CommitDiff) (oldPath, newPath string) {
+	oldPath, newPath = d.FilePath, d.FilePath
+	if d.OldPath != "" {
+		oldPath = d.OldPath
+	}
+	return oldPath, newPath
+}
+
+// writePatch writes file diffs in unified git diff format
+func writePatch(w io.Writer, files []api.CommitDiff, color bool) {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	for _, d := range files {
+		content := strings.TrimRight(strings.ReplaceAll(d.DiffContent, "\r\n", "\n"), "\n")
+
+		// Some servers return complete git diffs per file
+		if !strings.HasPrefix(content, "diff --git ") {
+			oldPath, newPath := filePaths(d)
+			from, to := "a/"+oldPath, "b/"+newPath
+			fmt.Fprintln(w, paint(colorMeta, fmt.Sprintf("diff --git a/%s b/%s", oldPath, newPath)))
+			switch strings.ToUpper(d.ChangeType) {
+			case "ADD":
+				fmt.Fprintln(w, paint(colorMeta, "new file mode 100644"))
+				from = "/dev/null"
+			case "DELETE":
+				fmt.Fprintln(w, paint(colorMeta, "deleted file mode 100644"))
+				to = "/dev/null"
+			case "RENAME":
+				fmt.Fprintln(w, paint(colorMeta, "rename from "+oldPath))
+				fmt.Fprintln(w, paint(colorMeta, "rename to "+newPath))
+			}
+			if content == "" {
+				continue
+			}
+			if strings.HasPrefix(content, "@@") {
+				fmt.Fprintln(w, paint(colorMeta, "--- "+from))
+				fmt.Fprintln(w, paint(colorMeta, "+++ "+to))
+			}
+		}
+
+		// Lines before the first hunk are file headers
+		header := true
+		for _, line := range strings.Split(content, "\n") {
+			switch {
+			case strings.HasPrefix(line, "diff --git "):
+				header = true
+				fmt.Fprintln(w, paint(colorMeta, line))
+			case strings.HasPrefix(line, "@@"):
+				header = false
+				fmt.Fprintln(w, paint(colorHunk, line))
+			case header:
+				fmt.Fprintln(w, paint(colorMeta, line))
+			case strings.HasPrefix(line, "+"):
+				fmt.Fprintln(w, paint(colorAdded, line))
+			case strings.HasPrefix(line, "-"):
+				fmt.Fprintln(w, paint(colorRemoved, line))
+			default:
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+}
+
+// writeStat writes a git-style diffstat
+func writeStat(w io.Writer, files []api.CommitDiff, color bool) {
+	nameWidth, maxChanges := 0, 0
+	var additions, deletions int
+	for _, d := range files {
+		nameWidth = max(nameWidth, len(statName(d)))
+		maxChanges = max(maxChanges, d.Additions+d.Deletions)
+		additions += d.Additions
+		deletions += d.Deletions
+	}
+	countWidth := len(fmt.Sprint(maxChanges))
+
+	for _, d := range files {
+		plus, minus := d.Additions, d.Deletions
+		// Scale the bar down for large changes
+		if maxChanges > statBarWidth {
+			plus = (plus*statBarWidth + maxChanges - 1) / maxChanges
+			minus = (minus*statBarWidth + maxChanges - 1) / maxChanges
+		}
+		bar := strings.Repeat("+", plus)
+		bars := strings.Repeat("-", minus)
+		if color && bar != "" {
+			bar = colorAdded + bar + colorReset
+		}
+		if color && bars != "" {
+			bars = colorRemoved + bars + colorReset
+		}
+		line := fmt.Sprintf(" %-*s | %*d %s%s", nameWidth, statName(d), countWidth, d.Additions+d.Deletions, bar, bars)
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+
+	summary := fmt.Sprintf(" %d file%s changed", len(files), plural(len(files)))
+	if additions > 0 || deletions == 0 {
+		summary += fmt.Sprintf(", %d insertion%s(+)", additions, plural(additions))
+	}
+	if deletions > 0 {
+		summary += fmt.Sprintf(", %d deletion%s(-)", deletions, plural(deletions))
+	}
+	fmt.Fprintln(w, summary)
+}
+
+// statName returns the file name shown in --stat output
+func statName(d api.CommitDiff) string {
+	oldPath, newPath := filePaths(d)
+	if oldPath != newPath {
+		return oldPath + " => " + newPath
+	}
+	return newPath
+}
+
+// writeNames writes the paths of changed files, one per line
+func writeNames(w io.Writer, files []api.CommitDiff) {
+	for _, d := range files {
+		fmt.Fprintln(w, d.FilePath)
+	}
+}
+
+// writeMailPatch writes a commit in the mbox format of git format-patch,
+// so the output can be applied with git am. Non-ASCII headers are RFC 2047
+// encoded and body lines starting with "From " are escaped as in mboxrd.
+func writeMailPatch(w io.Writer, c api.CommitDetail, files []api.CommitDiff, n, total int) {
+	subject, body, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	prefix := "[PATCH]"
+	if total > 1 {
+		prefix = fmt.Sprintf("[PATCH %d/%d]", n, total)
+	}
+
+	fmt.Fprintf(w, "From %s Mon Sep 17 00:00:00 2001\n", c.Hash)
+	fmt.Fprintf(w, "From: %s <%s>\n", mime.QEncoding.Encode("UTF-8", c.AuthorName), c.AuthorEmail)
+	fmt.Fprintf(w, "Date: %s\n", c.CreatedAt.Format("Mon, 2 Jan 2006 15:04:05 -0700"))
+	fmt.Fprintf(w, "Subject: %s\n", mime.QEncoding.Encode("UTF-8", prefix+" "+strings.TrimSpace(subject)))
+	fmt.Fprintln(w, "MIME-Version: 1.0")
+	fmt.Fprintln(w, "Content-Type: text/plain; charset=UTF-8")
+	fmt.Fprintln(w, "Content-Transfer-Encoding: 8bit")
+	fmt.Fprintln(w)
+	if body = strings.TrimSpace(body); body != "" {
+		for _, line := range strings.Split(body, "\n") {
+			if mboxFromLine.MatchString(line) {
+				line = ">" + line
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintln(w, "---")
+	writeStat(w, files, false)
+	fmt.Fprintln(w)
+	writePatch(w, files, false)
+	fmt.Fprintln(w, "-- ")
+	fmt.Fprintln(w, "gf")
+	fmt.Fprintln(w)
+}
+
+// filterPaths keeps file diffs whose old or new path matches a pathspec
+func filterPaths(files []api.CommitDiff, pathspecs []string) []api.CommitDiff {
+	if len(pathspecs) == 0 {
+		return files
+	}
+	var result []api.CommitDiff
+	for _, d := range files {
+		oldPath, newPath := filePaths(d)
+		for _, spec := range pathspecs {
+			if matchPathspec(spec, newPath) || matchPathspec(spec, oldPath) {
+				result = append(result, d)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// matchPathspec reports whether file matches spec: an exact path, a
+// directory prefix or a glob pattern
+func matchPathspec(spec, file string) bool {
+	spec = strings.TrimSuffix(strings.TrimPrefix(spec, "./"), "/")
+	if spec == "" || spec == "." {
+		return true
+	}
+	if file == spec || strings.HasPrefix(file, spec+"/") {
+		return true
+	}
+	if !strings.ContainsAny(spec, "*?[") {
+		return false
+	}
+	if ok, _ := path.Match(spec, file); ok {
+		return true
+	}
+	// A pattern without a slash matches the file name in any directory
+	if !strings.Contains(spec, "/") {
+		ok, _ := path.Match(spec, path.Base(file))
+		return ok
+	}
+	// A pattern matching a parent directory matches everything below it
+	for i := range file {
+		if file[i] == '/' {
+			if ok, _ := path.Match(spec, file[:i]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,152 @@
+package mr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+var testDiffs = []api.CommitDiff{
+	{FilePath: "cmd/mr/diff.go", ChangeType: "MODIFY", Additions: 2, Deletions: 1,
+		DiffContent: "@@ -1,2 +1,3 @@\n package mr\n-old\n+new\n+more\n"},
+	{FilePath: "README.md", ChangeType: "ADD", Additions: 1,
+		DiffContent: "@@ -0,0 +1 @@\n+# Title\n"},
+	{FilePath: "docs/new.md", OldPath: "docs/old.md", ChangeType: "RENAME"},
+}
+
+func TestWritePatch(t *testing.T) {
+	var buf bytes.Buffer
+	writePatch(&buf, testDiffs, false)
+
+	want := `diff --git a/cmd/mr/diff.go b/cmd/mr/diff.go
+--- a/cmd/mr/diff.go
++++ b/cmd/mr/diff.go
+@@ -1,2 +1,3 @@
+ package mr
+-old
++new
++more
+diff --git a/README.md b/README.md
+new file mode 100644
+--- /dev/null
++++ b/README.md
+@@ -0,0 +1 @@
++# Title
+diff --git a/docs/old.md b/docs/new.md
+rename from docs/old.md
+rename to docs/new.md
+`
+	if buf.String() != want {
+		t.Errorf("writePatch() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWritePatch_Color(t *testing.T) {
+	var buf bytes.Buffer
+	writePatch(&buf, testDiffs[:1], true)
+	out := buf.String()
+	if !strings.Contains(out, colorAdded+"+new"+colorReset) || !strings.Contains(out, colorRemoved+"-old"+colorReset) {
+		t.Errorf("writePatch() did not color lines:\n%q", out)
+	}
+	// File headers are not colored as removed lines
+	if strings.Contains(out, colorRemoved+"--- a/") {
+		t.Errorf("writePatch() colored header as removal:\n%q", out)
+	}
+}
+
+func TestWriteStat(t *testing.T) {
+	var buf bytes.Buffer
+	writeStat(&buf, testDiffs, false)
+
+	want := ` cmd/mr/diff.go             | 3 ++-
+ README.md                  | 1 +
+ docs/old.md => docs/new.md | 0
+ 3 files changed, 3 insertions(+), 1 deletion(-)
+`
+	if buf.String() != want {
+		t.Errorf("writeStat() =\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestMatchPathspec(t *testing.T) {
+	tests := []struct {
+		spec, file string
+		want       bool
+	}{
+		{"cmd/mr/diff.go", "cmd/mr/diff.go", true},
+		{"cmd", "cmd/mr/diff.go", true},
+		{"cmd/", "cmd/mr/diff.go", true},
+		{"./cmd/mr", "cmd/mr/diff.go", true},
+		{"cm", "cmd/mr/diff.go", false},
+		{"*.go", "cmd/mr/diff.go", true},
+		{"*.md", "cmd/mr/diff.go", false},
+		{"cmd/*", "cmd/mr/diff.go", true},
+		{"cmd/*/diff.go", "cmd/mr/diff.go", true},
+		{"internal/*", "cmd/mr/diff.go", false},
+		{".", "README.md", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchPathspec(tt.spec, tt.file); got != tt.want {
+			t.Errorf("matchPathspec(%q, %q) = %v, want %v", tt.spec, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestFilterPaths(t *testing.T) {
+	got := filterPaths(testDiffs, []string{"*.md"})
+	if len(got) != 2 || got[0].FilePath != "README.md" || got[1].FilePath != "docs/new.md" {
+		t.Errorf("filterPaths(*.md) = %+v", got)
+	}
+	// Renames match by their old path too
+	if got := filterPaths(testDiffs, []string{"docs/old.md"}); len(got) != 1 {
+		t.Errorf("filterPaths(old path) = %+v", got)
+	}
+	if got := filterPaths(testDiffs, nil); len(got) != len(testDiffs) {
+		t.Errorf("filterPaths(nil) = %d files, want %d", len(got), len(testDiffs))
+	}
+}
+
+func TestWriteMailPatch(t *testing.T) {
+	var buf bytes.Buffer
+	c := api.CommitDetail{
+		Hash:        "abc123",
+		Message:     "Add title\n\nLonger description.\nFrom the start\n",
+		AuthorName:  "Jane Doe",
+		AuthorEmail: "jane@example.com",
+		CreatedAt:   time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+	writeMailPatch(&buf, c, testDiffs[1:2], 1, 2)
+	out := buf.String()
+
+	for _, want := range []string{
+		"From abc123 Mon Sep 17 00:00:00 2001\n",
+		"From: Jane Doe <jane@example.com>\n",
+		"Date: Mon, 15 Jan 2024 10:00:00 +0000\n",
+		"Subject: [PATCH 1/2] Add title\n",
+		"Content-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit\n\nLonger description.\n>From the start\n---\n",
+		" 1 file changed, 1 insertion(+)\n",
+		"+++ b/README.md\n",
+		"\n-- \ngf\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("writeMailPatch() missing %q in:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	c.AuthorName, c.Message = "Иван", "Исправить вход"
+	writeMailPatch(&buf, c, testDiffs[1:2], 1, 1)
+	out = buf.String()
+	for _, want := range []string{
+		"From: =?UTF-8?q?=D0=98=D0=B2=D0=B0=D0=BD?= <jane@example.com>\n",
+		"Subject: =?UTF-8?q?[PATCH]_",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("writeMailPatch() missing %q in:\n%s", want, out)
+		}
+	}
+}
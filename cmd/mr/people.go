@@ -0,0 +1,138 @@
+package mr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+// userResolver looks up users by alias, resolving @me to the
+// authenticated user. Lookups are cached per command run.
+type userResolver struct {
+	client *api.Client
+	cache  map[string]*api.User
+}
+
+func newUserResolver(client *api.Client) *userResolver {
+	return &userResolver{client: client, cache: make(map[string]*api.User)}
+}
+
+// resolve returns the users for aliases (with or without a leading @)
+func (r *userResolver) resolve(aliases []string) ([]api.User, error) {
+	users := make([]api.User, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimPrefix(strings.TrimSpace(alias), "@")
+		if alias == "" {
+			continue
+		}
+		key := strings.ToLower(alias)
+
+		user, ok := r.cache[key]
+		if !ok {
+			var err error
+			if key == "me" {
+				user, err = r.client.Users().Me()
+			} else {
+				user, err = r.client.Users().Get(alias)
+			}
+			if err != nil {
+				if api.IsNotFound(err) {
+					return nil, fmt.Errorf("user @%s not found", alias)
+				}
+				return nil, fmt.Errorf("failed to look up @%s: %w", alias, err)
+			}
+			r.cache[key] = user
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+// resolveLabels maps label titles to the project's labels (case-insensitive)
+func resolveLabels(client *api.Client, owner, project string, titles []string) ([]api.Label, error) {
+	if len(titles) == 0 {
+		return nil, nil
+	}
+
+	available, err := client.Projects().Labels(owner, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	labels := make([]api.Label, 0, len(titles))
+	for _, title := range titles {
+		var found *api.Label
+		for i := range available {
+			if strings.EqualFold(available[i].Title, strings.TrimSpace(title)) {
+				found = &available[i]
+				break
+			}
+		}
+		if found == nil {
+			names := make([]string, len(available))
+			for i, l := range available {
+				names[i] = l.Title
+			}
+			return nil, fmt.Errorf("label %q not found in %s/%s (available: %s)", title, owner, project, strings.Join(names, ", "))
+		}
+		labels = append(labels, *found)
+	}
+	return labels, nil
+}
+
+// mergeUsers returns current plus add minus remove, without duplicates
+func mergeUsers(current, add, remove []api.User) []api.UserRef {
+	removed := make(map[string]bool, len(remove))
+	for _, u := range remove {
+		removed[u.ID] = true
+	}
+
+	seen := make(map[string]bool)
+	refs := make([]api.UserRef, 0, len(current)+len(add))
+	for _, u := range append(append([]api.User{}, current...), add...) {
+		if u.ID == "" || removed[u.ID] || seen[u.ID] {
+			continue
+		}
+		seen[u.ID] = true
+		refs = append(refs, api.UserRef{ID: u.ID})
+	}
+	return refs
+}
+
+// mergeLabels returns current plus add minus remove, without duplicates
+func mergeLabels(current, add, remove []api.Label) []api.LabelRef {
+	removed := make(map[string]bool, len(remove))
+	for _, l := range remove {
+		removed[l.ID] = true
+	}
+
+	seen := make(map[string]bool)
+	refs := make([]api.LabelRef, 0, len(current)+len(add))
+	for _, l := range append(append([]api.Label{}, current...), add...) {
+		if l.ID == "" || removed[l.ID] || seen[l.ID] {
+			continue
+		}
+		seen[l.ID] = true
+		refs = append(refs, api.LabelRef{ID: l.ID})
+	}
+	return refs
+}
+
+// formatUsers formats users as "@alice, @bob"
+func formatUsers(users []api.User) string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = "@" + u.Username
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatLabels formats labels as "bug, ui"
+func formatLabels(labels []api.Label) string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Title
+	}
+	return strings.Join(names, ", ")
+}
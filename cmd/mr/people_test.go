@@ -0,0 +1,62 @@
+package mr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestMergeUsers(t *testing.T) {
+	alice := api.User{ID: "1", Username: "alice"}
+	bob := api.User{ID: "2", Username: "bob"}
+	carol := api.User{ID: "3", Username: "carol"}
+
+	tests := []struct {
+		name    string
+		current []api.User
+		add     []api.User
+		remove  []api.User
+		want    []api.UserRef
+	}{
+		{"add to empty", nil, []api.User{alice}, nil, []api.UserRef{{ID: "1"}}},
+		{"add duplicate", []api.User{alice}, []api.User{alice, bob}, nil, []api.UserRef{{ID: "1"}, {ID: "2"}}},
+		{"remove", []api.User{alice, bob}, nil, []api.User{alice}, []api.UserRef{{ID: "2"}}},
+		{"add and remove", []api.User{alice}, []api.User{carol}, []api.User{alice}, []api.UserRef{{ID: "3"}}},
+		{"remove all", []api.User{alice}, nil, []api.User{alice}, []api.UserRef{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeUsers(tt.current, tt.add, tt.remove); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	bug := api.Label{ID: "10", Title: "bug"}
+	ui := api.Label{ID: "11", Title: "ui"}
+
+	got := mergeLabels([]api.Label{bug}, []api.Label{ui, bug}, nil)
+	if want := []api.LabelRef{{ID: "10"}, {ID: "11"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeLabels(add) = %v, want %v", got, want)
+	}
+
+	got = mergeLabels([]api.Label{bug, ui}, nil, []api.Label{bug})
+	if want := []api.LabelRef{{ID: "11"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeLabels(remove) = %v, want %v", got, want)
+	}
+}
+
+func TestFormatUsersAndLabels(t *testing.T) {
+	users := []api.User{{Username: "alice"}, {Username: "bob"}}
+	if got := formatUsers(users); got != "@alice, @bob" {
+		t.Errorf("formatUsers() = %q", got)
+	}
+	labels := []api.Label{{Title: "bug"}, {Title: "ui"}}
+	if got := formatLabels(labels); got != "bug, ui" {
+		t.Errorf("formatLabels() = %q", got)
+	}
+}
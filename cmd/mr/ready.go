@@ -2,8 +2,6 @@ package mr
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -19,16 +17,16 @@ func newReadyCmd() *cobra.Command {
 	opts := &readyOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "ready <id>",
+		Use:   "ready [<id> | <branch> | <url>]",
 		Short: "Mark a draft merge request as ready for review",
 		Long:  `Remove the draft status from a merge request, marking it as ready for review.`,
 		Example: `  # Mark MR #42 as ready
   gf mr ready 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runReady(opts, id)
 		},
@@ -57,7 +55,7 @@ func runReady(opts *readyOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -2,8 +2,6 @@ package mr
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -19,16 +17,16 @@ func newReopenCmd() *cobra.Command {
 	opts := &reopenOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "reopen <id>",
+		Use:   "reopen [<id> | <branch> | <url>]",
 		Short: "Reopen a closed merge request",
 		Long:  `Reopen a merge request that was closed without merging.`,
 		Example: `  # Reopen MR #42
   gf mr reopen 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runReopen(opts, id)
 		},
@@ -57,7 +55,7 @@ func runReopen(opts *reopenOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -23,7 +22,7 @@ func newReplyCmd() *cobra.Command {
 	opts := &replyOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "reply <mr-id>",
+		Use:   "reply [<id> | <branch> | <url>]",
 		Short: "Reply to a discussion on a merge request",
 		Long: `Reply to an existing discussion thread on a merge request.
 
@@ -33,11 +32,11 @@ Use --discussion to specify the discussion UUID (shown in 'gf mr comments' outpu
 
   # Pipe reply from stdin
   echo "Done" | gf mr reply 42 --discussion abc12345 --body -`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runReply(opts, id)
 		},
@@ -69,7 +68,7 @@ func runReply(opts *replyOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
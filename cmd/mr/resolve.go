@@ -2,8 +2,6 @@ package mr
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -14,28 +12,33 @@ import (
 type resolveOptions struct {
 	repo       string
 	discussion string
+	undo       bool
 }
 
 func newResolveCmd() *cobra.Command {
 	opts := &resolveOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "resolve <mr-id>",
+		Use:   "resolve [<id> | <branch> | <url>]",
 		Short: "Resolve a discussion on a merge request",
 		Long: `Mark a discussion thread as resolved.
 
 Use --discussion to specify the discussion UUID (shown in 'gf mr comments' output).
-If a non-root discussion UUID is passed, the root discussion is automatically resolved.`,
+If a non-root discussion UUID is passed, the root discussion is automatically resolved.
+Use --undo to reopen a resolved discussion.`,
 		Example: `  # Resolve a discussion
   gf mr resolve 42 --discussion abc12345
 
   # Short flag
-  gf mr resolve 42 -d abc12345`,
-		Args: cobra.ExactArgs(1),
+  gf mr resolve 42 -d abc12345
+
+  # Reopen a resolved discussion
+  gf mr resolve 42 -d abc12345 --undo`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runResolve(opts, id)
 		},
@@ -43,6 +46,7 @@ If a non-root discussion UUID is passed, the root discussion is automatically re
 
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
 	cmd.Flags().StringVarP(&opts.discussion, "discussion", "d", "", "Discussion UUID to resolve")
+	cmd.Flags().BoolVar(&opts.undo, "undo", false, "Mark the discussion as unresolved")
 	_ = cmd.MarkFlagRequired("discussion")
 
 	return cmd
@@ -66,7 +70,7 @@ func runResolve(opts *resolveOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -77,6 +81,16 @@ func runResolve(opts *resolveOptions, id int) error {
 		return fmt.Errorf("failed to get merge request: %w", err)
 	}
 
+	if opts.undo {
+		_, err = client.MergeRequests().UnresolveDiscussion(repo.Owner, repo.Name, id, opts.discussion)
+		if err != nil {
+			return fmt.Errorf("failed to unresolve discussion: %w", err)
+		}
+
+		fmt.Printf("✓ Unresolved discussion on MR #%d\n", mr.LocalID)
+		return nil
+	}
+
 	// Resolve discussion
 	_, err = client.MergeRequests().ResolveDiscussion(repo.Owner, repo.Name, id, opts.discussion)
 	if err != nil {
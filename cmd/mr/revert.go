@@ -0,0 +1,232 @@
+package mr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+type revertOptions struct {
+	repo   string
+	branch string
+	commit string
+	draft  bool
+}
+
+func newRevertCmd() *cobra.Command {
+	opts := &revertOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "revert [<id> | <branch> | <url>]",
+		Short: "Revert a merged merge request",
+		Long: `Revert a merged merge request with a new merge request.
+
+gf fetches the target branch, creates a revert branch from it, reverts the
+merge commit of the merge request (or its commits after a fast-forward
+merge), pushes the branch and opens a merge request into the same target
+branch that references the original one.
+
+This needs a local clone. Squash and rebase merges rewrite the commits;
+pass the commit to revert with --commit for them.`,
+		Example: `  gf mr revert 42
+  gf mr revert 42 --branch revert-login --draft
+
+  # The merge request was squashed into abc1234
+  gf mr revert 42 --commit abc1234`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := mrIDArg(args, &opts.repo)
+			if err != nil {
+				return err
+			}
+			return runRevert(opts, id)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Name of the revert branch (default: revert-<id>)")
+	cmd.Flags().StringVar(&opts.commit, "commit", "", "Commit to revert instead of the detected merge commit")
+	cmd.Flags().BoolVarP(&opts.draft, "draft", "d", false, "Create the merge request as a draft")
+
+	return cmd
+}
+
+func runRevert(opts *revertOptions, id int) error {
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return fmt.Errorf("failed to get merge request: %w", err)
+	}
+	if mr.State() != "merged" {
+		return fmt.Errorf("merge request #%d is %s, only merged merge requests can be reverted", id, mr.State())
+	}
+
+	target := mr.TargetBranch.Title
+	if err := validateMRBranch(target, "target"); err != nil {
+		return err
+	}
+	if opts.branch == "" {
+		opts.branch = fmt.Sprintf("revert-%d", mr.LocalID)
+	}
+	if err := validateBranchName(opts.branch); err != nil {
+		return err
+	}
+	if git.LocalBranchExists(opts.branch) {
+		return fmt.Errorf("branch %s already exists; choose another name with --branch", opts.branch)
+	}
+
+	dirty, err := git.HasUncommittedChanges()
+	if err != nil {
+		return fmt.Errorf("could not check working tree: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("you have uncommitted changes; commit or stash them first")
+	}
+
+	remote, err := git.FindRemoteFor(repo.Owner, repo.Name)
+	if err != nil || remote == "" {
+		return fmt.Errorf("no git remote for %s; run this in a clone of the repository", repo.FullName())
+	}
+	if err := git.Fetch(remote); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", remote, err)
+	}
+	base := remote + "/" + target
+
+	commits, merge, err := revertCommits(client, repo, mr, base, opts.commit)
+	if err != nil {
+		return err
+	}
+
+	previous, _ := git.CurrentBranch()
+	if err := git.CreateBranch(opts.branch, base); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", opts.branch, err)
+	}
+	if err := git.Revert(commits, merge); err != nil {
+		return fmt.Errorf("reverting merge request #%d on %s has conflicts\n"+
+			"Resolve them, commit and push the branch, then run 'gf mr create --target %s'", id, opts.branch, target)
+	}
+
+	title, body := revertText(mr, commits, merge)
+	if err := git.CommitStaged(title + "\n\n" + body); err != nil {
+		msg := fmt.Sprintf("failed to commit the revert of merge request #%d on %s: %v\n"+
+			"The changes are staged: commit and push the branch, then run 'gf mr create --target %s'", id, opts.branch, err, target)
+		if previous != "" {
+			msg += fmt.Sprintf("\nTo discard them, run 'git checkout -f %s && git branch -D %s'", previous, opts.branch)
+		}
+		return errors.New(msg)
+	}
+	if previous != "" {
+		if err := git.Checkout(previous); err != nil {
+			fmt.Fprintf(os.Stderr, "! Could not switch back to %s: %v\n", previous, err)
+		}
+	}
+	fmt.Printf("✓ Created branch %s reverting %s\n", opts.branch, shortSHA(commits[0]))
+
+	fmt.Fprintf(os.Stderr, "Pushing %s to %s...\n", opts.branch, remote)
+	if err := git.Push(remote, opts.branch, true); err != nil {
+		return fmt.Errorf("failed to push %s: %w", opts.branch, err)
+	}
+
+	project, err := client.Projects().Get(repo.Owner, repo.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get project info: %w", err)
+	}
+	created, err := client.MergeRequests().Create(repo.Owner, repo.Name, &api.CreateMRRequest{
+		Title:         title,
+		Description:   body,
+		SourceBranch:  api.BranchRef{ID: opts.branch},
+		TargetBranch:  api.BranchRef{ID: target},
+		SourceProject: api.ProjectRef{ID: project.ID},
+		TargetProject: api.ProjectRef{ID: project.ID},
+		IsDraft:       opts.draft,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create merge request: %w", err)
+	}
+
+	fmt.Printf("\n✓ Created merge request #%d reverting #%d\n", created.LocalID, mr.LocalID)
+	fmt.Printf("https://%s/project/%s/%s/merge-request/%d\n", repo.Host, repo.Owner, repo.Name, created.LocalID)
+	return nil
+}
+
+// revertCommits returns the commits to revert for mr on base, newest
+// first, and whether they are a merge commit. commit overrides detection.
+func revertCommits(client *api.Client, repo *git.Repository, mr *api.MergeRequest, base, commit string) ([]string, bool, error) {
+	if commit != "" {
+		sha, err := git.RefSHA(commit)
+		if err != nil {
+			return nil, false, fmt.Errorf("unknown commit %s", commit)
+		}
+		parents, err := git.CommitParents(sha)
+		if err != nil {
+			return nil, false, err
+		}
+		return []string{sha}, len(parents) > 1, nil
+	}
+
+	head := mr.SourceBranch.Hash
+	if head == "" {
+		return nil, false, fmt.Errorf("could not determine the head commit of merge request #%d; pass --commit", mr.LocalID)
+	}
+	sha, merge, err := git.FindMergeCommit(base, head)
+	if errors.Is(err, git.ErrMergeNotFound) {
+		return nil, false, fmt.Errorf("could not find merge request #%d on %s (squash or rebase merge?)\n"+
+			"Pass the commit to revert with --commit", mr.LocalID, base)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to search %s: %w", base, err)
+	}
+	if merge {
+		return []string{sha}, true, nil
+	}
+
+	// Fast-forward: revert the commits of the merge request
+	mrCommits, err := client.MergeRequests().Commits(repo.Owner, repo.Name, mr.LocalID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get commits: %w", err)
+	}
+	var commits []string
+	for _, c := range mrCommits {
+		commits = append(commits, c.Hash)
+	}
+	if len(commits) == 0 {
+		commits = []string{sha}
+	}
+	slices.Reverse(commits)
+	return commits, false, nil
+}
+
+// revertText returns the title and description of the revert commit and
+// merge request
+func revertText(mr *api.MergeRequest, commits []string, merge bool) (string, string) {
+	title := fmt.Sprintf("Revert %q", mr.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Revert !%d\n\n", mr.LocalID)
+	switch {
+	case merge:
+		fmt.Fprintf(&b, "This reverts merge commit %s of !%d (%s → %s).",
+			commits[0], mr.LocalID, mr.SourceBranch.Title, mr.TargetBranch.Title)
+	case len(commits) == 1:
+		fmt.Fprintf(&b, "This reverts commit %s of !%d.", commits[0], mr.LocalID)
+	default:
+		fmt.Fprintf(&b, "This reverts the %d commits of !%d:\n", len(commits), mr.LocalID)
+		for _, c := range commits {
+			fmt.Fprintf(&b, "\n- %s", c)
+		}
+	}
+	return title, b.String()
+}
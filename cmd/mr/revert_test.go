@@ -0,0 +1,39 @@
+package mr
+
+import (
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+func TestRevertText(t *testing.T) {
+	mr := &api.MergeRequest{
+		LocalID:      12,
+		Title:        "Add login",
+		SourceBranch: api.Branch{Title: "feature/login"},
+		TargetBranch: api.Branch{Title: "main"},
+	}
+
+	tests := []struct {
+		name    string
+		commits []string
+		merge   bool
+		body    string
+	}{
+		{"merge commit", []string{"abc123"}, true,
+			"Revert !12\n\nThis reverts merge commit abc123 of !12 (feature/login → main)."},
+		{"one commit", []string{"abc123"}, false,
+			"Revert !12\n\nThis reverts commit abc123 of !12."},
+		{"fast-forward", []string{"def456", "abc123"}, false,
+			"Revert !12\n\nThis reverts the 2 commits of !12:\n\n- def456\n- abc123"},
+	}
+	for _, tt := range tests {
+		title, body := revertText(mr, tt.commits, tt.merge)
+		if title != `Revert "Add login"` {
+			t.Errorf("%s: title = %q", tt.name, title)
+		}
+		if body != tt.body {
+			t.Errorf("%s: body =\n%s\nwant\n%s", tt.name, body, tt.body)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -23,12 +22,16 @@ func newReviewCmd() *cobra.Command {
 	opts := &reviewOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "review <id>",
+		Use:   "review [<id> | <branch> | <url>]",
 		Short: "Review a merge request (approve + comment)",
 		Long: `Submit a review on a merge request.
 
 Combines approval and commenting into a single command.
-Use --approve to approve the MR along with the comment.`,
+Use --approve to approve the MR along with the comment.
+
+For a batched review, start a local draft with 'gf mr review start',
+collect inline comments with 'gf mr review comment' and post them all
+at once with 'gf mr review submit'.`,
 		Example: `  # Approve with comment
   gf mr review 42 --approve --body "LGTM!"
 
@@ -39,12 +42,18 @@ Use --approve to approve the MR along with the comment.`,
   gf mr review 42 --approve
 
   # Pipe review from stdin
-  echo "Ship it" | gf mr review 42 --approve --body -`,
-		Args: cobra.ExactArgs(1),
+  echo "Ship it" | gf mr review 42 --approve --body -
+
+  # Batched review
+  gf mr review start 42
+  gf mr review comment -f main.go -l 10 -b "Handle the error"
+  gf mr review comment -f main.go -l 25 -b "Typo"
+  gf mr review submit --approve`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runReview(opts, id)
 		},
@@ -54,6 +63,13 @@ Use --approve to approve the MR along with the comment.`,
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Review comment body (use - to read from stdin)")
 	cmd.Flags().BoolVarP(&opts.approve, "approve", "a", false, "Approve the merge request")
 
+	cmd.AddCommand(newReviewStartCmd())
+	cmd.AddCommand(newReviewCommentCmd())
+	cmd.AddCommand(newReviewListCmd())
+	cmd.AddCommand(newReviewEditCmd())
+	cmd.AddCommand(newReviewDiscardCmd())
+	cmd.AddCommand(newReviewSubmitCmd())
+
 	return cmd
 }
 
@@ -79,7 +95,7 @@ func runReview(opts *reviewOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get MR info first
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -0,0 +1,486 @@
+package mr
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/editor"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+func newReviewStartCmd() *cobra.Command {
+	var repoFlag string
+
+	cmd := &cobra.Command{
+		Use:   "start [<id> | <branch> | <url>]",
+		Short: "Start a batched review of a merge request",
+		Long: `Start a review of a merge request. Inline comments added with
+'gf mr review comment' are kept in a local draft (under .git/gf) until
+'gf mr review submit' posts them all at once.
+
+Starting a review of a merge request that already has a draft resumes it.`,
+		Example: `  gf mr review start 12`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := mrIDArg(args, &repoFlag)
+			if err != nil {
+				return err
+			}
+			return runReviewStart(repoFlag, id)
+		},
+	}
+
+	cmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository (owner/name)")
+
+	return cmd
+}
+
+func runReviewStart(repoFlag string, id int) error {
+	repo, client, err := repoClient(repoFlag)
+	if err != nil {
+		return err
+	}
+
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", id, repo.FullName())
+		}
+		return fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	d, err := loadReviewDraft(repo, id)
+	if err != nil {
+		return err
+	}
+	if d != nil {
+		if err := saveReviewDraft(d); err != nil {
+			return fmt.Errorf("failed to save review draft: %w", err)
+		}
+		fmt.Printf("✓ Resumed review of MR #%d: %s (%d draft comment(s))\n", mr.LocalID, mr.Title, len(d.Comments))
+		return nil
+	}
+
+	d = &reviewDraft{
+		Host:      repo.Host,
+		Owner:     repo.Owner,
+		Name:      repo.Name,
+		MR:        mr.LocalID,
+		Title:     mr.Title,
+		HeadSHA:   mr.SourceBranch.Hash,
+		StartedAt: time.Now(),
+	}
+	if err := saveReviewDraft(d); err != nil {
+		return fmt.Errorf("failed to save review draft: %w", err)
+	}
+	fmt.Printf("✓ Started review of MR #%d: %s\n", mr.LocalID, mr.Title)
+	fmt.Println("Add comments with 'gf mr review comment -f <path> -l <line> -b <text>'")
+	return nil
+}
+
+func newReviewCommentCmd() *cobra.Command {
+	opts := &commentOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Add an inline comment to the review draft",
+		Long: `Add an inline comment to the review started with 'gf mr review start'.
+The comment is stored locally and posted by 'gf mr review submit'.`,
+		Example: `  gf mr review comment -f main.go -l 42 -b "This needs error handling"
+
+  # Comment on a removed line
+  gf mr review comment -f utils.go --old-line 15 -b "Why was this removed?"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewComment(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "File path")
+	cmd.Flags().IntVarP(&opts.line, "line", "l", 0, "New-side line number")
+	cmd.Flags().IntVar(&opts.oldLine, "old-line", 0, "Old-side line number")
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Comment body (use - to read from stdin)")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.MarkFlagsMutuallyExclusive("line", "old-line")
+
+	return cmd
+}
+
+func runReviewComment(opts *commentOptions) error {
+	if opts.line <= 0 && opts.oldLine <= 0 {
+		return fmt.Errorf("--file requires --line or --old-line")
+	}
+	if strings.HasPrefix(opts.file, "-") {
+		return fmt.Errorf("invalid file path: %s", opts.file)
+	}
+
+	d, err := currentReviewDraft()
+	if err != nil {
+		return err
+	}
+
+	body, err := commentBody(opts.body, "")
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("comment body cannot be empty")
+	}
+
+	c := d.add(draftComment{
+		Path:    strings.TrimPrefix(opts.file, "./"),
+		Line:    opts.line,
+		OldLine: opts.oldLine,
+		Body:    body,
+	})
+	if err := saveReviewDraft(d); err != nil {
+		return fmt.Errorf("failed to save review draft: %w", err)
+	}
+	fmt.Printf("✓ Added draft comment #%d on %s to review of MR #%d\n", c.ID, c.location(), d.MR)
+	return nil
+}
+
+func newReviewListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List comments of the review draft",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := currentReviewDraft()
+			if err != nil {
+				return err
+			}
+			printReviewDraft(os.Stdout, d)
+			return nil
+		},
+	}
+}
+
+// printReviewDraft prints the comments of a review draft
+func printReviewDraft(w io.Writer, d *reviewDraft) {
+	fmt.Fprintf(w, "Review of MR #%d: %s (%s/%s)\n", d.MR, d.Title, d.Owner, d.Name)
+	if len(d.Comments) == 0 {
+		fmt.Fprintln(w, "\nNo draft comments.")
+		return
+	}
+	for _, c := range d.Comments {
+		status := ""
+		if c.Posted != "" {
+			status = " (submitted)"
+		}
+		fmt.Fprintf(w, "\n#%d %s%s\n", c.ID, c.location(), status)
+		for _, line := range strings.Split(c.Body, "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+}
+
+func newReviewEditCmd() *cobra.Command {
+	var body string
+
+	cmd := &cobra.Command{
+		Use:   "edit <n>",
+		Short: "Edit a comment of the review draft",
+		Long: `Edit a draft comment by its number (see 'gf mr review list').
+Without --body, the comment is opened in your editor.`,
+		Example: `  gf mr review edit 2 -b "Consider a table-driven test here"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			if err != nil {
+				return fmt.Errorf("invalid draft comment number: %s", args[0])
+			}
+			return runReviewEdit(n, body)
+		},
+	}
+
+	cmd.Flags().StringVarP(&body, "body", "b", "", "New comment body (use - to read from stdin)")
+
+	return cmd
+}
+
+func runReviewEdit(n int, bodyFlag string) error {
+	d, err := currentReviewDraft()
+	if err != nil {
+		return err
+	}
+	c, err := d.comment(n)
+	if err != nil {
+		return err
+	}
+	if c.Posted != "" {
+		return fmt.Errorf("draft comment #%d was already submitted", n)
+	}
+
+	body, err := commentBody(bodyFlag, c.Body)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("comment body cannot be empty")
+	}
+
+	c.Body = body
+	if err := saveReviewDraft(d); err != nil {
+		return fmt.Errorf("failed to save review draft: %w", err)
+	}
+	fmt.Printf("✓ Updated draft comment #%d on %s\n", c.ID, c.location())
+	return nil
+}
+
+func newReviewDiscardCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "discard [<n>]",
+		Short: "Discard the review draft or one of its comments",
+		Example: `  # Drop draft comment #2
+  gf mr review discard 2
+
+  # Throw away the whole review
+  gf mr review discard --yes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 0
+			if len(args) > 0 {
+				var err error
+				n, err = strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+				if err != nil {
+					return fmt.Errorf("invalid draft comment number: %s", args[0])
+				}
+			}
+			return runReviewDiscard(n, yes)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation")
+
+	return cmd
+}
+
+func runReviewDiscard(n int, yes bool) error {
+	d, err := currentReviewDraft()
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		if err := d.remove(n); err != nil {
+			return err
+		}
+		if err := saveReviewDraft(d); err != nil {
+			return fmt.Errorf("failed to save review draft: %w", err)
+		}
+		fmt.Printf("✓ Discarded draft comment #%d\n", n)
+		return nil
+	}
+
+	if !yes {
+		fmt.Printf("Discard the review of MR #%d with %d draft comment(s)? [y/N] ", d.MR, len(d.pending()))
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if err := deleteReviewDraft(d); err != nil {
+		return fmt.Errorf("failed to discard review draft: %w", err)
+	}
+	fmt.Printf("✓ Discarded review of MR #%d\n", d.MR)
+	return nil
+}
+
+type reviewSubmitOptions struct {
+	body    string
+	approve bool
+	comment bool
+}
+
+func newReviewSubmitCmd() *cobra.Command {
+	opts := &reviewSubmitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Post all comments of the review draft",
+		Long: `Post the comments of the current review draft, then optionally approve
+the merge request.
+
+All file and line positions are checked against the merge request diff
+before anything is posted. If some comments fail to post, the draft keeps
+track of what was submitted; run 'gf mr review submit' again to retry.`,
+		Example: `  # Post comments and approve
+  gf mr review submit --approve -b "LGTM after these nits"
+
+  # Post comments only
+  gf mr review submit --comment`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReviewSubmit(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.approve, "approve", "a", false, "Approve the merge request after posting")
+	cmd.Flags().BoolVarP(&opts.comment, "comment", "c", false, "Only post comments (default)")
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Review summary (use - to read from stdin)")
+	cmd.MarkFlagsMutuallyExclusive("approve", "comment")
+
+	return cmd
+}
+
+func runReviewSubmit(opts *reviewSubmitOptions) error {
+	d, err := currentReviewDraft()
+	if err != nil {
+		return err
+	}
+
+	if opts.body != "" {
+		if d.SummaryPosted {
+			return fmt.Errorf("the review summary was already posted")
+		}
+		if d.Summary, err = commentBody(opts.body, ""); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+	repo := d.repo()
+
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, d.MR)
+	if err != nil {
+		if api.IsNotFound(err) {
+			return fmt.Errorf("merge request #%d not found in %s", d.MR, repo.FullName())
+		}
+		return fmt.Errorf("failed to get merge request: %w", err)
+	}
+	if d.HeadSHA != "" && mr.SourceBranch.Hash != "" && mr.SourceBranch.Hash != d.HeadSHA {
+		fmt.Printf("! New commits were pushed since the review started (%s → %s)\n",
+			shortSHA(d.HeadSHA), shortSHA(mr.SourceBranch.Hash))
+	}
+
+	pending := d.pending()
+	if len(pending) > 0 {
+		files, err := client.MergeRequests().Diff(repo.Owner, repo.Name, d.MR)
+		if err != nil {
+			return fmt.Errorf("failed to get merge request diff: %w", err)
+		}
+		if problems := validateComments(pending, files); len(problems) > 0 {
+			return fmt.Errorf("review comments do not match the diff of MR #%d:\n  %s\nFix them with 'gf mr review edit' or 'gf mr review discard'",
+				d.MR, strings.Join(problems, "\n  "))
+		}
+	}
+
+	failed := submitReview(client, d, pending)
+	if err := saveReviewDraft(d); err != nil {
+		return fmt.Errorf("failed to save review draft: %w", err)
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d of %d comment(s) were not posted; run 'gf mr review submit' again to retry\n", failed, len(pending))
+		return api.NewExitError(1)
+	}
+
+	if opts.approve {
+		if err := client.MergeRequests().Approve(repo.Owner, repo.Name, d.MR); err != nil {
+			return fmt.Errorf("failed to approve merge request: %w", err)
+		}
+		fmt.Printf("✓ Approved MR #%d: %s\n", mr.LocalID, mr.Title)
+	}
+
+	if err := deleteReviewDraft(d); err != nil {
+		return fmt.Errorf("failed to remove review draft: %w", err)
+	}
+	fmt.Printf("✓ Submitted review of MR #%d (%d comment(s))\n", d.MR, len(d.Comments))
+	return nil
+}
+
+// submitReview posts pending comments and the summary, recording each
+// success in d. It returns the number of posts that failed.
+func submitReview(client *api.Client, d *reviewDraft, pending []*draftComment) int {
+	failed := 0
+	for _, c := range pending {
+		note, err := client.MergeRequests().CreateDiscussion(d.Owner, d.Name, d.MR,
+			inlineDiscussion(c.Body, c.Path, c.Line, c.OldLine))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ #%d %s: %v\n", c.ID, c.location(), err)
+			failed++
+			continue
+		}
+		c.Posted = note.UUID
+		if c.Posted == "" {
+			c.Posted = "posted"
+		}
+		fmt.Printf("✓ Posted #%d on %s\n", c.ID, c.location())
+	}
+
+	// The summary goes last so it is not posted for a failed review
+	if failed == 0 && d.Summary != "" && !d.SummaryPosted {
+		if _, err := client.MergeRequests().CreateDiscussion(d.Owner, d.Name, d.MR, inlineDiscussion(d.Summary, "", 0, 0)); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ review summary: %v\n", err)
+			return 1
+		}
+		d.SummaryPosted = true
+	}
+	return failed
+}
+
+// commentBody resolves a --body value: "-" reads stdin, an empty value
+// opens the editor with initial
+func commentBody(body, initial string) (string, error) {
+	switch body {
+	case "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case "":
+		text, err := editor.Edit("COMMENT.md", initial)
+		if err != nil {
+			return "", fmt.Errorf("editor failed: %w", err)
+		}
+		return strings.TrimSpace(text), nil
+	default:
+		return body, nil
+	}
+}
+
+// repoClient resolves the repository and creates an API client
+func repoClient(repoFlag string) (*git.Repository, *api.Client, error) {
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	return repo, api.NewClientForHost(cfg, cfg.ActiveHost, token), nil
+}
@@ -0,0 +1,258 @@
+package mr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/diff"
+	"github.com/josinSbazin/gf/internal/fileutil"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+// Review drafts live in <git dir>/gf/reviews, one file per merge request,
+// plus a pointer to the review being worked on
+const (
+	reviewDraftDir    = "gf/reviews"
+	currentReviewFile = "current"
+)
+
+// reviewDraft is a pending review of a merge request
+type reviewDraft struct {
+	Host      string         `json:"host"`
+	Owner     string         `json:"owner"`
+	Name      string         `json:"name"`
+	MR        int            `json:"mr"`
+	Title     string         `json:"title"`
+	HeadSHA   string         `json:"head_sha"`
+	Comments  []draftComment `json:"comments"`
+	StartedAt time.Time      `json:"started_at"`
+	// Summary is the review body posted on submit; SummaryPosted is set
+	// once it was posted so a resumed submit does not repeat it
+	Summary       string `json:"summary,omitempty"`
+	SummaryPosted bool   `json:"summary_posted,omitempty"`
+}
+
+// draftComment is an inline comment waiting to be submitted
+type draftComment struct {
+	ID      int    `json:"id"`
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	OldLine int    `json:"old_line,omitempty"`
+	Body    string `json:"body"`
+	// Posted is the discussion UUID once the comment was submitted
+	Posted string `json:"posted,omitempty"`
+}
+
+// location formats the file position of a comment, e.g. main.go:42
+func (c draftComment) location() string {
+	if c.Line > 0 {
+		return fmt.Sprintf("%s:%d", c.Path, c.Line)
+	}
+	return fmt.Sprintf("%s:%d (old)", c.Path, c.OldLine)
+}
+
+// repo returns the repository the draft belongs to
+func (d *reviewDraft) repo() *git.Repository {
+	return &git.Repository{Host: d.Host, Owner: d.Owner, Name: d.Name}
+}
+
+// pending returns the comments that were not submitted yet
+func (d *reviewDraft) pending() []*draftComment {
+	var result []*draftComment
+	for i := range d.Comments {
+		if d.Comments[i].Posted == "" {
+			result = append(result, &d.Comments[i])
+		}
+	}
+	return result
+}
+
+// comment returns the draft comment with the given number
+func (d *reviewDraft) comment(id int) (*draftComment, error) {
+	for i := range d.Comments {
+		if d.Comments[i].ID == id {
+			return &d.Comments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no draft comment #%d in review of !%d", id, d.MR)
+}
+
+// add appends a comment with the next free number
+func (d *reviewDraft) add(c draftComment) *draftComment {
+	next := 1
+	for _, existing := range d.Comments {
+		next = max(next, existing.ID+1)
+	}
+	c.ID = next
+	d.Comments = append(d.Comments, c)
+	return &d.Comments[len(d.Comments)-1]
+}
+
+// remove deletes the comment with the given number
+func (d *reviewDraft) remove(id int) error {
+	for i := range d.Comments {
+		if d.Comments[i].ID == id {
+			d.Comments = append(d.Comments[:i], d.Comments[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no draft comment #%d in review of !%d", id, d.MR)
+}
+
+// reviewDir returns the directory holding review drafts
+func reviewDir() (string, error) {
+	gitDir, err := git.GitDir()
+	if err != nil {
+		return "", fmt.Errorf("review drafts are stored in the git directory: %w", err)
+	}
+	return filepath.Join(gitDir, reviewDraftDir), nil
+}
+
+// draftFileName returns the file name of the draft for a merge request
+func draftFileName(repo *git.Repository, id int) string {
+	name := fmt.Sprintf("%s_%s_%s_%d.json", repo.Host, repo.Owner, repo.Name, id)
+	return strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(name)
+}
+
+// loadReviewDraft reads the draft for a merge request; nil if none exists
+func loadReviewDraft(repo *git.Repository, id int) (*reviewDraft, error) {
+	dir, err := reviewDir()
+	if err != nil {
+		return nil, err
+	}
+	return readReviewDraft(filepath.Join(dir, draftFileName(repo, id)))
+}
+
+func readReviewDraft(path string) (*reviewDraft, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var d reviewDraft
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("corrupt review draft %s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// saveReviewDraft writes the draft and makes it the current review
+func saveReviewDraft(d *reviewDraft) error {
+	dir, err := reviewDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := draftFileName(d.repo(), d.MR)
+	if err := fileutil.WriteAtomic(filepath.Join(dir, name), data, 0600); err != nil {
+		return err
+	}
+	return fileutil.WriteAtomic(filepath.Join(dir, currentReviewFile), []byte(name+"\n"), 0600)
+}
+
+// deleteReviewDraft removes the draft and clears the current review if
+// it pointed to it
+func deleteReviewDraft(d *reviewDraft) error {
+	dir, err := reviewDir()
+	if err != nil {
+		return err
+	}
+	name := draftFileName(d.repo(), d.MR)
+	if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	current, _ := os.ReadFile(filepath.Join(dir, currentReviewFile))
+	if strings.TrimSpace(string(current)) == name {
+		os.Remove(filepath.Join(dir, currentReviewFile))
+	}
+	return nil
+}
+
+// currentReviewDraft returns the review started last with 'gf mr review start'
+func currentReviewDraft() (*reviewDraft, error) {
+	dir, err := reviewDir()
+	if err != nil {
+		return nil, err
+	}
+	current, err := os.ReadFile(filepath.Join(dir, currentReviewFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no review in progress. Run 'gf mr review start <id>' first")
+		}
+		return nil, err
+	}
+	name := filepath.Base(strings.TrimSpace(string(current)))
+	d, err := readReviewDraft(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, fmt.Errorf("no review in progress. Run 'gf mr review start <id>' first")
+	}
+	return d, nil
+}
+
+// diffLines returns the new-side and old-side line numbers that appear in
+// a unified diff (added/context and removed/context lines)
+func diffLines(content string) (newLines, oldLines map[int]bool) {
+	newLines, oldLines = make(map[int]bool), make(map[int]bool)
+	for _, hunk := range diff.ParseHunks(content) {
+		for _, l := range hunk {
+			if l.New > 0 {
+				newLines[l.New] = true
+			}
+			if l.Old > 0 {
+				oldLines[l.Old] = true
+			}
+		}
+	}
+	return newLines, oldLines
+}
+
+// validateComments checks that every pending comment points at a file
+// and line that is part of the merge request diff
+func validateComments(comments []*draftComment, files []api.CommitDiff) []string {
+	type fileLines struct {
+		newLines, oldLines map[int]bool
+		hasContent         bool
+	}
+	byPath := make(map[string]*fileLines)
+	for _, f := range files {
+		fl := &fileLines{hasContent: f.DiffContent != ""}
+		fl.newLines, fl.oldLines = diffLines(f.DiffContent)
+		oldPath, newPath := filePaths(f)
+		byPath[newPath] = fl
+		if _, ok := byPath[oldPath]; !ok {
+			byPath[oldPath] = fl
+		}
+	}
+
+	var problems []string
+	for _, c := range comments {
+		fl, ok := byPath[c.Path]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("#%d %s: file is not changed in this merge request", c.ID, c.location()))
+		case !fl.hasContent:
+			// No hunks to check against (binary or very large file)
+		case c.Line > 0 && !fl.newLines[c.Line]:
+			problems = append(problems, fmt.Sprintf("#%d %s: line is not part of the diff", c.ID, c.location()))
+		case c.Line == 0 && !fl.oldLines[c.OldLine]:
+			problems = append(problems, fmt.Sprintf("#%d %s: line is not part of the diff", c.ID, c.location()))
+		}
+	}
+	return problems
+}
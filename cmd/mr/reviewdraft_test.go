@@ -0,0 +1,111 @@
+package mr
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+func TestReviewDraftStorage(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	if out, err := exec.Command("git", "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+
+	if _, err := currentReviewDraft(); err == nil || !strings.Contains(err.Error(), "no review in progress") {
+		t.Fatalf("currentReviewDraft() err = %v, want no review in progress", err)
+	}
+
+	d := &reviewDraft{Host: "gitflic.ru", Owner: "owner", Name: "repo", MR: 12}
+	d.add(draftComment{Path: "main.go", Line: 10, Body: "first"})
+	d.add(draftComment{Path: "main.go", OldLine: 3, Body: "second"})
+	if err := saveReviewDraft(d); err != nil {
+		t.Fatalf("saveReviewDraft failed: %v", err)
+	}
+
+	got, err := currentReviewDraft()
+	if err != nil {
+		t.Fatalf("currentReviewDraft failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Comments, d.Comments) {
+		t.Errorf("comments = %+v, want %+v", got.Comments, d.Comments)
+	}
+
+	// Numbers are not reused after a comment is removed
+	if err := got.remove(1); err != nil {
+		t.Fatal(err)
+	}
+	if c := got.add(draftComment{Path: "a.go", Line: 1, Body: "third"}); c.ID != 3 {
+		t.Errorf("new comment ID = %d, want 3", c.ID)
+	}
+	if err := got.remove(7); err == nil {
+		t.Error("remove(7) should fail")
+	}
+
+	if err := deleteReviewDraft(got); err != nil {
+		t.Fatalf("deleteReviewDraft failed: %v", err)
+	}
+	if d, err := loadReviewDraft(&git.Repository{Host: "gitflic.ru", Owner: "owner", Name: "repo"}, 12); err != nil || d != nil {
+		t.Errorf("loadReviewDraft() = %v, %v; want nil after delete", d, err)
+	}
+	if _, err := currentReviewDraft(); err == nil {
+		t.Error("current review should be cleared after delete")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	content := "@@ -10,4 +10,5 @@ func main() {\n" +
+		" context\n" +
+		"-removed\n" +
+		"+added 1\n" +
+		"+added 2\n" +
+		" context\n" +
+		"\\ No newline at end of file\n"
+
+	newLines, oldLines := diffLines(content)
+	wantNew := map[int]bool{10: true, 11: true, 12: true, 13: true}
+	wantOld := map[int]bool{10: true, 11: true, 12: true}
+	if !reflect.DeepEqual(newLines, wantNew) {
+		t.Errorf("new lines = %v, want %v", newLines, wantNew)
+	}
+	if !reflect.DeepEqual(oldLines, wantOld) {
+		t.Errorf("old lines = %v, want %v", oldLines, wantOld)
+	}
+}
+
+func TestValidateComments(t *testing.T) {
+	files := []api.CommitDiff{
+		{FilePath: "main.go", DiffContent: "@@ -1,2 +1,3 @@\n a\n+b\n c\n"},
+		{FilePath: "new.go", OldPath: "old.go", DiffContent: "@@ -5 +5 @@\n-x\n+y\n"},
+		{FilePath: "logo.png"},
+	}
+	comments := []*draftComment{
+		{ID: 1, Path: "main.go", Line: 2},
+		{ID: 2, Path: "main.go", Line: 9},
+		{ID: 3, Path: "old.go", OldLine: 5},
+		{ID: 4, Path: "logo.png", Line: 1},
+		{ID: 5, Path: "other.go", Line: 1},
+	}
+
+	got := validateComments(comments, files)
+	want := []string{
+		"#2 main.go:9: line is not part of the diff",
+		"#5 other.go:1: file is not changed in this merge request",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateComments() = %q, want %q", got, want)
+	}
+}
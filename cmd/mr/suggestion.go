@@ -0,0 +1,197 @@
+package mr
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+)
+
+// suggestionFence matches the opening fence of a suggestion block:
+// ```suggestion or ```suggestion:-2+1 to replace 2 lines above and 1 below
+var suggestionFence = regexp.MustCompile("^\\s*(`{3,}|~{3,})suggestion(?::-(\\d+)\\+(\\d+))?\\s*$")
+
+// suggestion is a replacement for lines of a file proposed in a review
+type suggestion struct {
+	ID     int
+	Thread string
+	Author api.User
+	Path   string
+	// Start and End are the replaced lines (1-based, inclusive)
+	Start, End int
+	Lines      []string
+}
+
+// location formats the replaced lines, e.g. main.go:42 or main.go:40-43
+func (s *suggestion) location() string {
+	if s.Start == s.End {
+		return fmt.Sprintf("%s:%d", s.Path, s.Start)
+	}
+	return fmt.Sprintf("%s:%d-%d", s.Path, s.Start, s.End)
+}
+
+// suggestionBlock is a suggestion parsed from a comment body
+type suggestionBlock struct {
+	above, below int
+	lines        []string
+}
+
+// parseSuggestionBlocks extracts suggestion blocks from a comment body
+func parseSuggestionBlocks(text string) []suggestionBlock {
+	var blocks []suggestionBlock
+	var current *suggestionBlock
+	fence := ""
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if current == nil {
+			m := suggestionFence.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			current = &suggestionBlock{}
+			fence = m[1]
+			if m[2] != "" {
+				current.above, _ = strconv.Atoi(m[2])
+				current.below, _ = strconv.Atoi(m[3])
+			}
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+	// An unterminated block is not a suggestion
+	return blocks
+}
+
+// collectSuggestions returns the suggestions of inline threads, in the
+// order they were posted. Resolved threads are skipped unless
+// includeResolved is set.
+func collectSuggestions(threads []api.DiscussionThread, includeResolved bool) []*suggestion {
+	var result []*suggestion
+	for _, t := range threads {
+		root := t.RootNote
+		if root.NewPath == nil || *root.NewPath == "" || root.NewLine == nil || *root.NewLine <= 0 {
+			continue
+		}
+		if root.Resolved && !includeResolved {
+			continue
+		}
+		notes := append([]api.DiscussionNote{root}, t.Replies...)
+		for _, n := range notes {
+			text := n.RawMessage
+			if text == "" {
+				text = n.Message
+			}
+			for _, b := range parseSuggestionBlocks(text) {
+				result = append(result, &suggestion{
+					ID:     len(result) + 1,
+					Thread: root.UUID,
+					Author: n.Author,
+					Path:   *root.NewPath,
+					Start:  *root.NewLine - b.above,
+					End:    *root.NewLine + b.below,
+					Lines:  b.lines,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// applySuggestions replaces lines of content with the given suggestions
+// for one file. Suggestions overlapping an earlier one are not applied
+// and returned as skipped.
+func applySuggestions(content string, suggestions []*suggestion) (string, []*suggestion, error) {
+	trailingNewline := strings.HasSuffix(content, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	sorted := append([]*suggestion(nil), suggestions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var applied, skipped []*suggestion
+	lastEnd := 0
+	for _, s := range sorted {
+		if s.Start < 1 || s.End > len(lines) || s.Start > s.End {
+			return "", nil, fmt.Errorf("suggestion #%d: %s is outside the file (%d lines)", s.ID, s.location(), len(lines))
+		}
+		if s.Start <= lastEnd {
+			skipped = append(skipped, s)
+			continue
+		}
+		applied = append(applied, s)
+		lastEnd = s.End
+	}
+
+	// Replace from the bottom so earlier line numbers stay valid
+	for i := len(applied) - 1; i >= 0; i-- {
+		s := applied[i]
+		replaced := append(append(append([]string(nil), lines[:s.Start-1]...), s.Lines...), lines[s.End:]...)
+		lines = replaced
+	}
+
+	result := strings.Join(lines, "\n")
+	if trailingNewline && len(lines) > 0 {
+		result += "\n"
+	}
+	return result, skipped, nil
+}
+
+// writeSuggestion prints a suggestion as a hunk against the current file
+func writeSuggestion(w io.Writer, s *suggestion, current []string, color bool) {
+	paint := func(c, text string) string {
+		if !color {
+			return text
+		}
+		return c + text + colorReset
+	}
+
+	fmt.Fprintf(w, "%s by @%s\n", paint(colorMeta, fmt.Sprintf("#%d %s", s.ID, s.location())), s.Author.Username)
+	fmt.Fprintln(w, paint(colorHunk, fmt.Sprintf("@@ -%d,%d +%d,%d @@", s.Start, s.End-s.Start+1, s.Start, len(s.Lines))))
+	for _, line := range current {
+		fmt.Fprintln(w, paint(colorRemoved, "-"+line))
+	}
+	for _, line := range s.Lines {
+		fmt.Fprintln(w, paint(colorAdded, "+"+line))
+	}
+}
+
+// suggestionCommitMessage returns the commit message for applied
+// suggestions, crediting their authors
+func suggestionCommitMessage(applied []*suggestion, mrRef string) string {
+	var b strings.Builder
+	if len(applied) == 1 {
+		fmt.Fprintf(&b, "Apply suggestion to %s\n", applied[0].Path)
+	} else {
+		fmt.Fprintf(&b, "Apply %d suggestions from code review\n", len(applied))
+	}
+	fmt.Fprintf(&b, "\nSee merge request %s\n", mrRef)
+
+	seen := make(map[string]bool)
+	var trailers []string
+	for _, s := range applied {
+		name := s.Author.FullName
+		if name == "" {
+			name = s.Author.Username
+		}
+		if s.Author.Email == "" || seen[s.Author.Email] {
+			continue
+		}
+		seen[s.Author.Email] = true
+		trailers = append(trailers, fmt.Sprintf("Co-authored-by: %s <%s>", name, s.Author.Email))
+	}
+	if len(trailers) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", strings.Join(trailers, "\n"))
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package mr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+func TestParseSuggestionBlocks(t *testing.T) {
+	text := "Use a constant:\r\n" +
+		"```suggestion\r\n" +
+		"const limit = 10\r\n" +
+		"```\r\n" +
+		"And here:\n" +
+		"~~~~suggestion:-1+2\n" +
+		"a\n" +
+		"```\n" +
+		"~~~~\n" +
+		"```suggestion\n" +
+		"unterminated\n"
+
+	got := parseSuggestionBlocks(text)
+	want := []suggestionBlock{
+		{lines: []string{"const limit = 10"}},
+		{above: 1, below: 2, lines: []string{"a", "```"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSuggestionBlocks() = %+v, want %+v", got, want)
+	}
+
+	// An empty suggestion deletes the line
+	if got := parseSuggestionBlocks("```suggestion\n```"); len(got) != 1 || len(got[0].lines) != 0 {
+		t.Errorf("empty suggestion = %+v", got)
+	}
+}
+
+func TestCollectSuggestions(t *testing.T) {
+	path, line := "main.go", 5
+	threads := []api.DiscussionThread{
+		{
+			RootNote: api.DiscussionNote{UUID: "t1", NewPath: &path, NewLine: &line,
+				RawMessage: "```suggestion:-1+0\nx\n```", Author: api.User{Username: "alice"}},
+			Replies: []api.DiscussionNote{
+				{Message: "```suggestion\ny\n```", Author: api.User{Username: "bob"}},
+			},
+		},
+		{RootNote: api.DiscussionNote{UUID: "t2", NewPath: &path, NewLine: &line, Resolved: true,
+			RawMessage: "```suggestion\nz\n```"}},
+		{RootNote: api.DiscussionNote{UUID: "t3", RawMessage: "```suggestion\ngeneral\n```"}},
+	}
+
+	got := collectSuggestions(threads, false)
+	if len(got) != 2 {
+		t.Fatalf("got %d suggestions, want 2", len(got))
+	}
+	if got[0].Start != 4 || got[0].End != 5 || got[0].Author.Username != "alice" || got[0].Thread != "t1" {
+		t.Errorf("first suggestion = %+v", got[0])
+	}
+	if got[1].Start != 5 || got[1].End != 5 || got[1].ID != 2 || got[1].Author.Username != "bob" {
+		t.Errorf("reply suggestion = %+v", got[1])
+	}
+
+	if n := len(collectSuggestions(threads, true)); n != 3 {
+		t.Errorf("with resolved: %d suggestions, want 3", n)
+	}
+}
+
+func TestApplySuggestions(t *testing.T) {
+	content := "one\ntwo\nthree\nfour\nfive\n"
+	suggestions := []*suggestion{
+		{ID: 1, Path: "f", Start: 4, End: 5, Lines: []string{"FOUR-FIVE"}},
+		{ID: 2, Path: "f", Start: 1, End: 1, Lines: []string{"ONE", "ONE-B"}},
+		{ID: 3, Path: "f", Start: 5, End: 5, Lines: []string{"overlap"}},
+		{ID: 4, Path: "f", Start: 2, End: 2, Lines: nil},
+	}
+
+	got, skipped, err := applySuggestions(content, suggestions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "ONE\nONE-B\nthree\nFOUR-FIVE\n"
+	if got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+	if len(skipped) != 1 || skipped[0].ID != 3 {
+		t.Errorf("skipped = %+v, want #3", skipped)
+	}
+
+	if _, _, err := applySuggestions("a\n", []*suggestion{{ID: 1, Path: "f", Start: 2, End: 2}}); err == nil {
+		t.Error("expected error for a line outside the file")
+	}
+}
+
+func TestAnswerThreads(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	var resolved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uuid, ok := strings.CutPrefix(r.URL.Path, "/project/owner/repo/merge-request/7/discussions/resolve/")
+		if !ok || uuid == "bad" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		resolved = append(resolved, uuid)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	repo := &git.Repository{Host: "example.com", Owner: "owner", Name: "repo"}
+	applied := []*suggestion{{ID: 1, Thread: "t1"}, {ID: 2, Thread: "t1"}, {ID: 3, Thread: "bad"}, {ID: 4, Thread: "t2"}}
+
+	if failed := answerThreads(client, repo, 7, applied, "", true); failed != 1 {
+		t.Errorf("answerThreads() = %d failed, want 1", failed)
+	}
+	if want := []string{"t1", "t2"}; !reflect.DeepEqual(resolved, want) {
+		t.Errorf("resolved = %v, want %v", resolved, want)
+	}
+}
+
+func TestSuggestionCommitMessage(t *testing.T) {
+	applied := []*suggestion{
+		{Path: "a.go", Author: api.User{FullName: "Alice", Email: "alice@example.com"}},
+		{Path: "b.go", Author: api.User{Username: "bob"}},
+		{Path: "c.go", Author: api.User{FullName: "Alice", Email: "alice@example.com"}},
+	}
+	got := suggestionCommitMessage(applied, "owner/repo!7")
+	want := "Apply 3 suggestions from code review\n\n" +
+		"See merge request owner/repo!7\n\n" +
+		"Co-authored-by: Alice <alice@example.com>\n"
+	if got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+
+	if got := suggestionCommitMessage(applied[:1], "owner/repo!7"); !strings.HasPrefix(got, "Apply suggestion to a.go\n") {
+		t.Errorf("single message = %q", got)
+	}
+}
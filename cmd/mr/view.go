@@ -1,9 +1,9 @@
 package mr
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -15,31 +15,35 @@ import (
 )
 
 type viewOptions struct {
-	repo string
-	json bool
-	web  bool
+	repo  string
+	json  bool
+	web   bool
+	files bool
 }
 
 func newViewCmd() *cobra.Command {
 	opts := &viewOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "view <id>",
+		Use:   "view [<id> | <branch> | <url>]",
 		Short: "View a merge request",
 		Long:  `View details of a merge request.`,
 		Example: `  # View merge request #12
   gf mr view 12
 
+  # Include changed files and lines (downloads the diff)
+  gf mr view 12 --files
+
   # View as JSON
   gf mr view 12 --json
 
   # Open in browser
   gf mr view 12 --web`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := mrIDArg(args, &opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid merge request ID: %s", args[0])
+				return err
 			}
 			return runView(opts, id)
 		},
@@ -48,6 +52,7 @@ func newViewCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
 	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open in browser")
+	cmd.Flags().BoolVar(&opts.files, "files", false, "Count changed files and lines (downloads the diff)")
 
 	return cmd
 }
@@ -70,7 +75,7 @@ func runView(opts *viewOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch merge request
 	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, id)
@@ -122,6 +127,31 @@ func runView(opts *viewOptions, id int) error {
 		fmt.Println("⚠ This merge request has conflicts")
 	}
 
+	printPeople(mr)
+
+	if mr.SourceBranch.Title != "" {
+		p, err := client.Pipelines().LatestForCommit(context.Background(), repo.Owner, repo.Name, mr.SourceBranch.Title, mr.SourceBranch.Hash)
+		if err == nil {
+			fmt.Printf("CI:       %s\n", ciSummary(p))
+		}
+	}
+	if mr.State() == "open" {
+		if a, err := client.MergeRequests().Approvals(repo.Owner, repo.Name, mr.LocalID); err == nil {
+			fmt.Printf("Approval: %s\n", a.Summary())
+		}
+	}
+	if commits, err := client.MergeRequests().Commits(repo.Owner, repo.Name, mr.LocalID); err == nil {
+		changes := fmt.Sprintf("%d commit(s)", len(commits))
+		// File counts need the whole diff, so they are opt-in
+		if opts.files {
+			if files, err := client.MergeRequests().Files(repo.Owner, repo.Name, mr.LocalID); err == nil {
+				changes += ", " + changeSummary(files)
+			}
+		}
+		fmt.Printf("Changes:  %s\n", changes)
+	}
+	printAutoMerge(repo, mr)
+
 	fmt.Printf("Created:  %s\n", output.FormatRelativeTime(mr.CreatedAt))
 	fmt.Printf("Updated:  %s\n", output.FormatRelativeTime(mr.UpdatedAt))
 
@@ -140,3 +170,16 @@ func runView(opts *viewOptions, id int) error {
 
 	return nil
 }
+
+// printPeople prints reviewers, assignees and labels of a merge request
+func printPeople(mr *api.MergeRequest) {
+	if len(mr.Reviewers) > 0 {
+		fmt.Printf("Reviewers: %s\n", formatUsers(mr.Reviewers))
+	}
+	if len(mr.Assignees) > 0 {
+		fmt.Printf("Assignees: %s\n", formatUsers(mr.Assignees))
+	}
+	if len(mr.Labels) > 0 {
+		fmt.Printf("Labels:    %s\n", formatLabels(mr.Labels))
+	}
+}
@@ -2,8 +2,6 @@ package pipeline
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -15,16 +13,16 @@ func newCancelCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "cancel <id>",
+		Use:   "cancel [<id>]",
 		Short: "Cancel a running pipeline",
 		Long:  `Cancel a running pipeline.`,
 		Example: `  # Cancel pipeline
   gf pipeline cancel 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := pipelineIDArg(args, repo)
 			if err != nil {
-				return fmt.Errorf("invalid pipeline ID: %s", args[0])
+				return err
 			}
 			return runCancel(repo, id)
 		},
@@ -53,7 +51,7 @@ func runCancel(repoFlag string, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if pipeline exists
 	pipeline, err := client.Pipelines().Get(repo.Owner, repo.Name, id)
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -22,7 +21,7 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <id>",
+		Use:   "delete [<id>]",
 		Short: "Delete a pipeline",
 		Long: `Delete a pipeline from the repository.
 
@@ -33,11 +32,11 @@ Use --force to skip confirmation.`,
 
   # Delete pipeline without confirmation
   gf pipeline delete 42 --force`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := pipelineIDArg(args, opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid pipeline ID: %s", args[0])
+				return err
 			}
 			return runDelete(opts, id)
 		},
@@ -67,7 +66,7 @@ func runDelete(opts *deleteOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if pipeline exists
 	pipeline, err := client.Pipelines().Get(repo.Owner, repo.Name, id)
@@ -9,6 +9,7 @@ import (
 	"github.com/josinSbazin/gf/internal/config"
 	"github.com/josinSbazin/gf/internal/git"
 	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
 	"github.com/spf13/cobra"
 )
 
@@ -51,8 +52,18 @@ func parseJobArgs(args []string) (pipelineID int, jobIdent jobIdentifier, err er
 			return 0, jobIdentifier{}, fmt.Errorf("invalid pipeline ID: %s", parts[0])
 		}
 		jobArg = parts[1]
-	} else {
+	} else if !prompt.Enabled() {
 		return 0, jobIdentifier{}, fmt.Errorf("expected format: <pipeline-id> <job-id|job-name> or <pipeline-id>:<job-id|job-name>")
+	} else {
+		// Missing parts are picked interactively: a zero pipeline ID and
+		// an empty job identifier
+		if len(args) == 1 {
+			pipelineID, err = strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			if err != nil {
+				return 0, jobIdentifier{}, fmt.Errorf("invalid pipeline ID: %s", args[0])
+			}
+		}
+		return pipelineID, jobIdentifier{}, nil
 	}
 
 	// Try to parse as numeric ID first
@@ -70,6 +81,9 @@ func resolveJobID(jobs []api.Job, jobIdent jobIdentifier) (int, error) {
 	if jobIdent.isNumeric {
 		return jobIdent.id, nil
 	}
+	if jobIdent.name == "" {
+		return selectJob(jobs)
+	}
 
 	// Search by name
 	for _, job := range jobs {
@@ -85,7 +99,7 @@ func newJobViewCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "view <pipeline-id> <job-id|job-name>",
+		Use:   "view [<pipeline-id> [<job-id|job-name>]]",
 		Short: "View job details",
 		Long:  `View details of a specific job within a pipeline.`,
 		Example: `  # View job by ID
@@ -96,7 +110,7 @@ func newJobViewCmd() *cobra.Command {
 
   # Alternative format
   gf pipeline job view 42:1`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pipelineID, jobIdent, err := parseJobArgs(args)
 			if err != nil {
@@ -129,7 +143,13 @@ func runJobView(repoFlag string, pipelineID int, jobIdent jobIdentifier) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	if pipelineID == 0 {
+		if pipelineID, err = selectPipeline(client, repo); err != nil {
+			return err
+		}
+	}
 
 	// Get jobs for pipeline
 	jobs, err := client.Pipelines().Jobs(repo.Owner, repo.Name, pipelineID)
@@ -180,7 +200,7 @@ func newJobLogCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "log <pipeline-id> <job-id|job-name>",
+		Use:   "log [<pipeline-id> [<job-id|job-name>]]",
 		Short: "View job log",
 		Long:  `View the log output of a specific job.`,
 		Example: `  # View job log by ID
@@ -188,7 +208,7 @@ func newJobLogCmd() *cobra.Command {
 
   # View job log by name
   gf pipeline job log 42 deploy-dev`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pipelineID, jobIdent, err := parseJobArgs(args)
 			if err != nil {
@@ -221,7 +241,13 @@ func runJobLog(repoFlag string, pipelineID int, jobIdent jobIdentifier) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	if pipelineID == 0 {
+		if pipelineID, err = selectPipeline(client, repo); err != nil {
+			return err
+		}
+	}
 
 	// Get jobs for pipeline to resolve job name if needed
 	jobs, err := client.Pipelines().Jobs(repo.Owner, repo.Name, pipelineID)
@@ -260,7 +286,7 @@ func newJobRetryCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "retry <pipeline-id> <job-id|job-name>",
+		Use:   "retry [<pipeline-id> [<job-id|job-name>]]",
 		Short: "Retry a failed job",
 		Long:  `Retry (restart) a failed job within a pipeline.`,
 		Example: `  # Retry job by ID
@@ -268,7 +294,7 @@ func newJobRetryCmd() *cobra.Command {
 
   # Retry job by name
   gf pipeline job retry 42 deploy-dev`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pipelineID, jobIdent, err := parseJobArgs(args)
 			if err != nil {
@@ -301,7 +327,13 @@ func runJobRetry(repoFlag string, pipelineID int, jobIdent jobIdentifier) error
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	if pipelineID == 0 {
+		if pipelineID, err = selectPipeline(client, repo); err != nil {
+			return err
+		}
+	}
 
 	// Get jobs for pipeline to resolve job name if needed
 	jobs, err := client.Pipelines().Jobs(repo.Owner, repo.Name, pipelineID)
@@ -338,7 +370,7 @@ func newJobCancelCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:   "cancel <pipeline-id> <job-id|job-name>",
+		Use:   "cancel [<pipeline-id> [<job-id|job-name>]]",
 		Short: "Cancel a running job",
 		Long:  `Cancel a running job within a pipeline.`,
 		Example: `  # Cancel job by ID
@@ -346,7 +378,7 @@ func newJobCancelCmd() *cobra.Command {
 
   # Cancel job by name
   gf pipeline job cancel 42 deploy-dev`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pipelineID, jobIdent, err := parseJobArgs(args)
 			if err != nil {
@@ -379,7 +411,13 @@ func runJobCancel(repoFlag string, pipelineID int, jobIdent jobIdentifier) error
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	if pipelineID == 0 {
+		if pipelineID, err = selectPipeline(client, repo); err != nil {
+			return err
+		}
+	}
 
 	// Get jobs for pipeline to resolve job name if needed
 	jobs, err := client.Pipelines().Jobs(repo.Owner, repo.Name, pipelineID)
@@ -2,8 +2,6 @@ package pipeline
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
 	"github.com/josinSbazin/gf/internal/config"
@@ -15,17 +13,17 @@ func newRetryCmd() *cobra.Command {
 	var repo string
 
 	cmd := &cobra.Command{
-		Use:     "retry <id>",
+		Use:     "retry [<id>]",
 		Aliases: []string{"restart"},
 		Short:   "Retry a failed pipeline",
 		Long:    `Retry (restart) a failed pipeline.`,
 		Example: `  # Retry pipeline
   gf pipeline retry 42`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := pipelineIDArg(args, repo)
 			if err != nil {
-				return fmt.Errorf("invalid pipeline ID: %s", args[0])
+				return err
 			}
 			return runRetry(repo, id)
 		},
@@ -54,7 +52,7 @@ func runRetry(repoFlag string, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Retry pipeline
 	pipeline, err := client.Pipelines().Restart(repo.Owner, repo.Name, id)
@@ -0,0 +1,100 @@
+package pipeline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
+)
+
+// pipelineIDArg parses the optional pipeline ID argument of a command.
+// Without one, the user picks a recent pipeline interactively.
+func pipelineIDArg(args []string, repoFlag string) (int, error) {
+	if len(args) > 0 {
+		id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid pipeline ID: %s", args[0])
+		}
+		return id, nil
+	}
+	if !prompt.Enabled() {
+		return 0, fmt.Errorf("pipeline ID required when not running interactively")
+	}
+
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return 0, fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return 0, fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+	return selectPipeline(client, repo)
+}
+
+// selectPipeline lets the user pick one of the recent pipelines
+func selectPipeline(client *api.Client, repo *git.Repository) (int, error) {
+	pipelines, err := client.Pipelines().List(repo.Owner, repo.Name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+	if len(pipelines) == 0 {
+		return 0, fmt.Errorf("no pipelines in %s", repo.FullName())
+	}
+
+	items := make([]prompt.Item, len(pipelines))
+	for i, p := range pipelines {
+		items[i].Label = fmt.Sprintf("#%d %s %s %s %s (%s)", p.LocalID, api.StatusIcon(p.Status),
+			p.NormalizedStatus(), p.Ref, p.SHA(), output.FormatRelativeTime(p.CreatedAt.Time))
+		preview := fmt.Sprintf("Ref:      %s\nCommit:   %s\nSource:   %s", p.Ref, p.CommitID, p.Source)
+		if p.Duration > 0 {
+			preview += "\nDuration: " + output.FormatDuration(p.Duration)
+		}
+		items[i].Preview = preview
+	}
+
+	i, err := prompt.Select("Select a pipeline", items)
+	if err != nil {
+		return 0, err
+	}
+	return pipelines[i].LocalID, nil
+}
+
+// selectJob lets the user pick one of the jobs of a pipeline
+func selectJob(jobs []api.Job) (int, error) {
+	if len(jobs) == 0 {
+		return 0, fmt.Errorf("pipeline has no jobs")
+	}
+
+	items := make([]prompt.Item, len(jobs))
+	for i, j := range jobs {
+		items[i].Label = fmt.Sprintf("#%d %s %s [%s] %s", j.LocalID, api.StatusIcon(j.Status), j.Name, j.Stage, j.NormalizedStatus())
+		preview := fmt.Sprintf("Stage:    %s\nStatus:   %s", j.Stage, j.NormalizedStatus())
+		if j.Duration > 0 {
+			preview += "\nDuration: " + output.FormatDuration(j.Duration)
+		}
+		if j.Runner != "" {
+			preview += "\nRunner:   " + j.Runner
+		}
+		items[i].Preview = preview
+	}
+
+	i, err := prompt.Select("Select a job", items)
+	if err != nil {
+		return 0, err
+	}
+	return jobs[i].LocalID, nil
+}
@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineIDArg(t *testing.T) {
+	t.Setenv("GF_PROMPT_DISABLED", "1")
+
+	if id, err := pipelineIDArg([]string{"#42"}, ""); err != nil || id != 42 {
+		t.Errorf("pipelineIDArg(#42) = %d, %v", id, err)
+	}
+	if _, err := pipelineIDArg([]string{"abc"}, ""); err == nil {
+		t.Error("pipelineIDArg(abc): want error")
+	}
+	if _, err := pipelineIDArg(nil, ""); err == nil || !strings.Contains(err.Error(), "required") {
+		t.Errorf("pipelineIDArg() without prompts = %v, want required error", err)
+	}
+}
+
+func TestParseJobArgs(t *testing.T) {
+	t.Setenv("GF_PROMPT_DISABLED", "1")
+
+	tests := []struct {
+		args     []string
+		pipeline int
+		job      jobIdentifier
+		wantErr  bool
+	}{
+		{[]string{"42", "3"}, 42, jobIdentifier{isNumeric: true, id: 3}, false},
+		{[]string{"42:deploy"}, 42, jobIdentifier{name: "deploy"}, false},
+		{[]string{"42", "deploy"}, 42, jobIdentifier{name: "deploy"}, false},
+		// Omitted parts need a prompt
+		{[]string{"42"}, 0, jobIdentifier{}, true},
+		{nil, 0, jobIdentifier{}, true},
+	}
+	for _, tt := range tests {
+		pipeline, job, err := parseJobArgs(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseJobArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (pipeline != tt.pipeline || job != tt.job) {
+			t.Errorf("parseJobArgs(%v) = %d, %+v", tt.args, pipeline, job)
+		}
+	}
+}
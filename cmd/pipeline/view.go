@@ -3,7 +3,6 @@ package pipeline
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/josinSbazin/gf/internal/api"
@@ -24,7 +23,7 @@ func newViewCmd() *cobra.Command {
 	opts := &viewOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "view <id>",
+		Use:   "view [<id>]",
 		Short: "View a pipeline",
 		Long:  `View details of a pipeline and its jobs.`,
 		Example: `  # View pipeline #45
@@ -35,11 +34,11 @@ func newViewCmd() *cobra.Command {
 
   # Open in browser
   gf pipeline view 45 --web`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := pipelineIDArg(args, opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid pipeline ID: %s", args[0])
+				return err
 			}
 			return runView(opts, id)
 		},
@@ -70,7 +69,7 @@ func runView(opts *viewOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch pipeline
 	pipeline, err := client.Pipelines().Get(repo.Owner, repo.Name, id)
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"strconv"
-	"strings"
 	"syscall"
 	"time"
 
@@ -19,9 +17,9 @@ import (
 )
 
 const (
-	minInterval     = 1
-	maxInterval     = 300
-	apiCallTimeout  = 30 * time.Second
+	minInterval    = 1
+	maxInterval    = 300
+	apiCallTimeout = 30 * time.Second
 )
 
 type watchOptions struct {
@@ -34,7 +32,7 @@ func newWatchCmd() *cobra.Command {
 	opts := &watchOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "watch <id>",
+		Use:   "watch [<id>]",
 		Short: "Watch a pipeline in real-time",
 		Long:  `Watch a pipeline and its jobs update in real-time.`,
 		Example: `  # Watch pipeline #45
@@ -45,11 +43,11 @@ func newWatchCmd() *cobra.Command {
 
   # Exit with pipeline's exit status
   gf pipeline watch 45 --exit-status`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+			id, err := pipelineIDArg(args, opts.repo)
 			if err != nil {
-				return fmt.Errorf("invalid pipeline ID: %s", args[0])
+				return err
 			}
 			return runWatch(opts, id)
 		},
@@ -87,80 +85,62 @@ func runWatch(opts *watchOptions, id int) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if we're in a terminal (for ANSI escape codes)
 	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
 
-	// Setup signal handler for clean exit
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	defer signal.Stop(sigChan) // Clean up signal handler
-
-	ticker := time.NewTicker(time.Duration(opts.interval) * time.Second)
-	defer ticker.Stop()
-
-	// Initial fetch with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
-	finalStatus, err := displayPipelineWithContext(ctx, client, repo, id)
-	cancel()
-	if err != nil {
-		return err
-	}
-
-	// If already finished, exit
-	if isFinished(finalStatus) {
-		return exitWithStatus(finalStatus, opts.exitStatus)
-	}
-
-	fmt.Println("\n[Ctrl+C to stop watching]")
-
-	for {
-		select {
-		case <-sigChan:
-			fmt.Println("\nStopped watching.")
-			return nil
-		case <-ticker.C:
-			// Clear screen only if TTY (avoid garbage in redirected output)
-			if isTTY {
-				fmt.Print("\033[H\033[2J")
-			} else {
-				fmt.Println("\n---") // Separator for non-TTY
+	// Stop cleanly on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	first := true
+	pipeline, err := client.Pipelines().Watch(ctx, repo.Owner, repo.Name, id,
+		time.Duration(opts.interval)*time.Second, func(p *api.Pipeline) error {
+			if !first {
+				// Clear screen only if TTY (avoid garbage in redirected output)
+				if isTTY {
+					fmt.Print("\033[H\033[2J")
+				} else {
+					fmt.Println("\n---") // Separator for non-TTY
+				}
 			}
+			first = false
 
-			ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
-			finalStatus, err = displayPipelineWithContext(ctx, client, repo, id)
-			cancel()
-			if err != nil {
+			if err := displayPipelineWithContext(ctx, client, repo, p); err != nil {
 				return err
 			}
-
-			if isFinished(finalStatus) {
-				return exitWithStatus(finalStatus, opts.exitStatus)
+			if !p.IsFinished() {
+				fmt.Println("\n[Ctrl+C to stop watching]")
 			}
-
-			fmt.Println("\n[Ctrl+C to stop watching]")
-		}
+			return nil
+		})
+	if ctx.Err() != nil {
+		fmt.Println("\nStopped watching.")
+		return nil
 	}
-}
-
-func displayPipelineWithContext(ctx context.Context, client *api.Client, repo *git.Repository, id int) (string, error) {
-	// Fetch pipeline with context
-	pipeline, err := client.Pipelines().GetWithContext(ctx, repo.Owner, repo.Name, id)
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("API request timed out")
+		if api.IsNotFound(err) {
+			return fmt.Errorf("pipeline #%d not found", id)
 		}
-		return "", fmt.Errorf("failed to get pipeline: %w", err)
+		return fmt.Errorf("failed to get pipeline: %w", err)
 	}
 
+	return exitWithStatus(pipeline.NormalizedStatus(), opts.exitStatus)
+}
+
+// displayPipelineWithContext prints pipeline and the current state of its jobs
+func displayPipelineWithContext(ctx context.Context, client *api.Client, repo *git.Repository, pipeline *api.Pipeline) error {
+	ctx, cancel := context.WithTimeout(ctx, apiCallTimeout)
+	defer cancel()
+
 	// Fetch jobs with context
-	jobs, err := client.Pipelines().JobsWithContext(ctx, repo.Owner, repo.Name, id)
+	jobs, err := client.Pipelines().JobsWithContext(ctx, repo.Owner, repo.Name, pipeline.LocalID)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("API request timed out")
+			return fmt.Errorf("API request timed out")
 		}
-		return "", fmt.Errorf("failed to get jobs: %w", err)
+		return fmt.Errorf("failed to get jobs: %w", err)
 	}
 
 	// Print pipeline info
@@ -199,16 +179,11 @@ func displayPipelineWithContext(ctx context.Context, client *api.Client, repo *g
 		api.ColorReset(),
 	)
 
-	return pipeline.NormalizedStatus(), nil
+	return nil
 }
 
 func isFinished(status string) bool {
-	switch status {
-	case "success", "passed", "failed", "canceled":
-		return true
-	default:
-		return false
-	}
+	return api.PipelineFinished(status)
 }
 
 func exitWithStatus(status string, useExitStatus bool) error {
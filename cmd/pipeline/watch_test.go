@@ -32,9 +32,9 @@ func TestWatchCmd_Flags(t *testing.T) {
 func TestWatchCmd_Args(t *testing.T) {
 	cmd := newWatchCmd()
 
-	// Requires exactly 1 argument
-	if err := cmd.Args(cmd, []string{}); err == nil {
-		t.Error("should reject 0 args")
+	// The ID is optional: without it, a pipeline is picked interactively
+	if err := cmd.Args(cmd, []string{}); err != nil {
+		t.Errorf("should accept 0 args: %v", err)
 	}
 
 	if err := cmd.Args(cmd, []string{"42"}); err != nil {
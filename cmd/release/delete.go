@@ -21,7 +21,7 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <tag>",
+		Use:   "delete [<tag>]",
 		Short: "Delete a release",
 		Long: `Delete a release from the repository.
 
@@ -34,9 +34,13 @@ Note: This only deletes the release, not the underlying git tag.`,
 
   # Delete release without confirmation
   gf release delete v1.0.0 --force`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(opts, args[0])
+			tag, err := releaseTagArg(args, opts.repo)
+			if err != nil {
+				return err
+			}
+			return runDelete(opts, tag)
 		},
 	}
 
@@ -64,7 +68,7 @@ func runDelete(opts *deleteOptions, tagName string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if release exists
 	release, err := client.Releases().Get(repo.Owner, repo.Name, tagName)
@@ -25,7 +25,7 @@ func newDownloadCmd() *cobra.Command {
 	opts := &downloadOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "download <tag> [asset-name]",
+		Use:   "download [<tag> [asset-name]]",
 		Short: "Download release assets",
 		Long: `Download assets from a release.
 
@@ -42,13 +42,17 @@ Use --all to download all assets.`,
 
   # Download to specific path
   gf release download v1.0.0 myapp.zip --output ./downloads/`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.MaximumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			assetName := ""
 			if len(args) > 1 {
 				assetName = args[1]
 			}
-			return runDownload(opts, args[0], assetName)
+			tag, err := releaseTagArg(args, opts.repo)
+			if err != nil {
+				return err
+			}
+			return runDownload(opts, tag, assetName)
 		},
 	}
 
@@ -78,7 +82,7 @@ func runDownload(opts *downloadOptions, tagName, assetName string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if release exists
 	_, err = client.Releases().Get(repo.Owner, repo.Name, tagName)
@@ -23,7 +23,7 @@ func newEditCmd() *cobra.Command {
 	opts := &editOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "edit <tag>",
+		Use:   "edit [<tag>]",
 		Short: "Edit a release",
 		Long: `Edit an existing release.
 
@@ -36,9 +36,13 @@ You can update the title, description, draft status, and prerelease status.`,
 
   # Remove draft status
   gf release edit v1.0.0 --no-draft`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEdit(opts, args[0])
+			tag, err := releaseTagArg(args, opts.repo)
+			if err != nil {
+				return err
+			}
+			return runEdit(opts, tag)
 		},
 	}
 
@@ -81,7 +85,7 @@ func runEdit(opts *editOptions, tagName string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if release exists
 	_, err = client.Releases().Get(repo.Owner, repo.Name, tagName)
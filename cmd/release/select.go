@@ -0,0 +1,76 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
+)
+
+// releaseTagArg returns the tag argument of a command. Without one, the
+// user picks a release interactively.
+func releaseTagArg(args []string, repoFlag string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if !prompt.Enabled() {
+		return "", fmt.Errorf("release tag required when not running interactively")
+	}
+
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return "", fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return "", fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	releases, _, err := client.Releases().List(repo.Owner, repo.Name, nil)
+	if err != nil && !api.IsNotFound(err) {
+		return "", fmt.Errorf("failed to list releases: %w", err)
+	}
+	if len(releases) == 0 {
+		return "", fmt.Errorf("no releases in %s", repo.FullName())
+	}
+
+	items := make([]prompt.Item, len(releases))
+	for i, r := range releases {
+		label := r.TagName
+		if r.Title != "" && r.Title != r.TagName {
+			label += " " + r.Title
+		}
+		switch {
+		case r.IsDraft:
+			label += " (draft)"
+		case r.IsPrerelease:
+			label += " (pre-release)"
+		}
+		items[i].Label = label
+
+		preview := fmt.Sprintf("@%s · %s · %d asset(s)", r.Author.Username,
+			output.FormatRelativeTime(r.CreatedAt), len(r.AttachmentFiles))
+		if desc := strings.TrimSpace(r.Description); desc != "" {
+			preview += "\n\n" + desc
+		}
+		items[i].Preview = preview
+	}
+
+	i, err := prompt.Select("Select a release", items)
+	if err != nil {
+		return "", err
+	}
+	return releases[i].TagName, nil
+}
@@ -85,7 +85,7 @@ func runUpload(opts *uploadOptions, tagName, filePath string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if release exists
 	_, err = client.Releases().Get(repo.Owner, repo.Name, tagName)
@@ -22,7 +22,7 @@ func newViewCmd() *cobra.Command {
 	opts := &viewOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "view <tag>",
+		Use:   "view [<tag>]",
 		Short: "View a release",
 		Long:  `View details of a specific release.`,
 		Example: `  # View release v1.0.0
@@ -33,9 +33,13 @@ func newViewCmd() *cobra.Command {
 
   # Open in browser
   gf release view v1.0.0 --web`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runView(opts, args[0])
+			tag, err := releaseTagArg(args, opts.repo)
+			if err != nil {
+				return err
+			}
+			return runView(opts, tag)
 		},
 	}
 
@@ -64,7 +68,7 @@ func runView(opts *viewOptions, tagName string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch release
 	release, err := client.Releases().Get(repo.Owner, repo.Name, tagName)
@@ -59,7 +59,7 @@ func runView(opts *viewOptions) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Fetch project
 	project, err := client.Projects().Get(repo.Owner, repo.Name)
@@ -1,21 +1,30 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
+	"github.com/josinSbazin/gf/cmd/alias"
 	"github.com/josinSbazin/gf/cmd/auth"
 	"github.com/josinSbazin/gf/cmd/branch"
 	"github.com/josinSbazin/gf/cmd/commit"
+	"github.com/josinSbazin/gf/cmd/extension"
 	"github.com/josinSbazin/gf/cmd/file"
 	"github.com/josinSbazin/gf/cmd/issue"
 	"github.com/josinSbazin/gf/cmd/mr"
 	"github.com/josinSbazin/gf/cmd/pipeline"
 	"github.com/josinSbazin/gf/cmd/release"
 	"github.com/josinSbazin/gf/cmd/repo"
+	"github.com/josinSbazin/gf/cmd/stack"
 	"github.com/josinSbazin/gf/cmd/tag"
 	"github.com/josinSbazin/gf/cmd/webhook"
 	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/prompt"
 	"github.com/josinSbazin/gf/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -32,6 +41,21 @@ Get started by running:
 }
 
 func Execute() {
+	args, isShell, err := expandAlias(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if isShell {
+		os.Exit(runExternal(exec.Command(args[0], args[1:]...)))
+	}
+	if ext := findExtension(args); ext != nil {
+		cmd := exec.Command(ext.Path, args[1:]...)
+		cmd.Env = append(os.Environ(), extensionEnv()...)
+		os.Exit(runExternal(cmd))
+	}
+	rootCmd.SetArgs(args)
+
 	if err := rootCmd.Execute(); err != nil {
 		// ExitError is used when a command wants to exit with specific code
 		// (e.g., pipeline watch --exit-status). Don't print these as errors.
@@ -43,19 +67,126 @@ func Execute() {
 	}
 }
 
+// expandAlias expands a user-defined alias at the start of args.
+// Built-in commands always take precedence over aliases.
+func expandAlias(args []string) ([]string, bool, error) {
+	if len(args) == 0 {
+		return args, false, nil
+	}
+	if c, _, err := rootCmd.Find(args[:1]); err == nil && c != rootCmd {
+		return args, false, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil || len(cfg.Aliases) == 0 {
+		// Let the command itself report config errors
+		return args, false, nil
+	}
+
+	expanded, ok, err := alias.Expand(cfg.Aliases, args)
+	if err != nil || !ok {
+		return args, false, err
+	}
+	return expanded, alias.IsShell(cfg.Aliases[args[0]]), nil
+}
+
+// findExtension returns the gf-<name> extension for args[0], if any.
+// Built-in commands always take precedence over extensions.
+func findExtension(args []string) *extension.Extension {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return nil
+	}
+	if c, _, err := rootCmd.Find(args[:1]); err == nil && c != rootCmd {
+		return nil
+	}
+
+	m, err := extension.NewManager()
+	if err != nil {
+		return nil
+	}
+	ext, err := m.Find(args[0])
+	if err != nil {
+		return nil
+	}
+	return ext
+}
+
+// extensionEnv returns the environment passed to extensions: the
+// current repository and its host, or the active host when no repository
+// is detected, with the API URL and token of that host
+func extensionEnv() []string {
+	var env []string
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	host := cfg.ActiveHost
+	if repo, err := git.ResolveRepo("", config.DefaultHost()); err == nil {
+		host = repo.Host
+		env = append(env, "GF_REPO="+repo.FullName())
+	}
+	if host == "" {
+		return env
+	}
+
+	env = append(env,
+		"GF_HOST="+host,
+		"GF_API_URL="+config.BaseURL(host),
+	)
+	if token := os.Getenv("GF_TOKEN"); token != "" {
+		env = append(env, "GF_TOKEN="+token)
+	} else if h := cfg.GetHost(host); h != nil && h.Token != "" {
+		env = append(env, "GF_TOKEN="+h.Token)
+	}
+
+	return env
+}
+
+// runExternal runs a shell alias or extension with the current stdio
+// and returns its exit code
+func runExternal(cmd *exec.Cmd) int {
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "failed to run %s: %v\n", cmd.Path, err)
+		return 1
+	}
+	return 0
+}
+
 func init() {
 	rootCmd.SilenceErrors = true
+
+	var noPrompt bool
+	rootCmd.PersistentFlags().BoolVar(&noPrompt, "no-prompt", false, "Disable interactive prompts")
+	cobra.OnInitialize(func() {
+		if noPrompt {
+			prompt.Disable()
+		}
+	})
+
+	rootCmd.AddCommand(alias.NewCmdAlias())
 	rootCmd.AddCommand(newAPICmd())
 	rootCmd.AddCommand(auth.NewCmdAuth())
 	rootCmd.AddCommand(branch.NewCmdBranch())
 	rootCmd.AddCommand(newBrowseCmd())
 	rootCmd.AddCommand(commit.NewCmdCommit())
+	rootCmd.AddCommand(extension.NewCmdExtension())
 	rootCmd.AddCommand(file.NewCmdFile())
 	rootCmd.AddCommand(issue.NewCmdIssue())
 	rootCmd.AddCommand(mr.NewCmdMR())
 	rootCmd.AddCommand(pipeline.NewCmdPipeline())
 	rootCmd.AddCommand(release.NewCmdRelease())
 	rootCmd.AddCommand(repo.NewCmdRepo())
+	rootCmd.AddCommand(stack.NewCmdStack())
 	rootCmd.AddCommand(newStatusCmd())
 	rootCmd.AddCommand(tag.NewCmdTag())
 	rootCmd.AddCommand(webhook.NewCmdWebhook())
@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/josinSbazin/gf/internal/config"
 )
 
 func TestRootCmd_SubCommands(t *testing.T) {
 	// Verify root command has all expected subcommands
 	subCommands := []string{
+		"alias",
 		"api",
 		"auth",
 		"browse",
+		"extension",
 		"issue",
 		"mr",
 		"pipeline",
@@ -72,3 +77,30 @@ func TestVersionCmd(t *testing.T) {
 		t.Error("Run function is nil")
 	}
 }
+
+func TestExtensionEnv_RepoHost(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+	t.Setenv("GF_TOKEN", "")
+	t.Setenv("GF_REPO", "git.example.com/org/project")
+	err := config.Update(func(cfg *config.Config) error {
+		cfg.ActiveHost = "gitflic.ru"
+		cfg.SetHost("gitflic.ru", &config.Host{Token: "public-token"})
+		cfg.SetHost("git.example.com", &config.Host{Token: "self-hosted-token"})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("config.Update() error: %v", err)
+	}
+
+	env := extensionEnv()
+	for _, want := range []string{
+		"GF_REPO=org/project",
+		"GF_HOST=git.example.com",
+		"GF_API_URL=" + config.BaseURL("git.example.com"),
+		"GF_TOKEN=self-hosted-token",
+	} {
+		if !slices.Contains(env, want) {
+			t.Errorf("extensionEnv() = %v, missing %s", env, want)
+		}
+	}
+}
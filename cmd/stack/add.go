@@ -0,0 +1,176 @@
+package stack
+
+import (
+	"fmt"
+
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+func newAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <branch>",
+		Short: "Create a branch on top of the current branch",
+		Long: `Create a new branch at the current commit, switch to it and record the
+current branch as its parent.
+
+Run it on the default branch to start a new stack.`,
+		Example: `  git checkout main
+  gf stack add feature-api
+  # ...commit...
+  gf stack add feature-ui`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(args[0])
+		},
+	}
+}
+
+func runAdd(branch string) error {
+	current, err := git.CurrentBranch()
+	if err != nil {
+		return fmt.Errorf("could not determine current branch: %w", err)
+	}
+	if git.LocalBranchExists(branch) {
+		return fmt.Errorf("branch %s already exists\nUse 'gf stack track %s --parent %s' to add it to the stack", branch, branch, current)
+	}
+
+	if err := git.CreateBranch(branch, "HEAD"); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	if err := git.SetStackParent(branch, current); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Created branch %s on top of %s\n", branch, current)
+	return nil
+}
+
+type trackOptions struct {
+	parent string
+}
+
+func newTrackCmd() *cobra.Command {
+	opts := &trackOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "track [<branch>]",
+		Short: "Add an existing branch to a stack",
+		Long: `Record the parent of an existing branch (the current branch by default),
+adding it to the stack of the parent. Tracking a stacked branch again
+moves it onto another parent; its commits are not rebased until
+'gf stack sync'.`,
+		Example: `  # Stack the current branch on feature-api
+  gf stack track --parent feature-api
+
+  # Start a stack with an existing branch based on main
+  gf stack track feature-api --parent main`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var branch string
+			if len(args) > 0 {
+				branch = args[0]
+			}
+			return runTrack(opts, branch)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Branch to stack on (default: the default branch)")
+
+	return cmd
+}
+
+func runTrack(opts *trackOptions, branch string) error {
+	var err error
+	if branch == "" {
+		branch, err = git.CurrentBranch()
+		if err != nil {
+			return fmt.Errorf("could not determine current branch: %w", err)
+		}
+	}
+	if opts.parent == "" {
+		opts.parent, err = git.DefaultBranch()
+		if err != nil {
+			opts.parent = "main"
+		}
+	}
+
+	if !git.LocalBranchExists(branch) {
+		return fmt.Errorf("branch %s does not exist", branch)
+	}
+	if !git.LocalBranchExists(opts.parent) {
+		return fmt.Errorf("parent branch %s does not exist", opts.parent)
+	}
+
+	parents, err := git.StackParents()
+	if err != nil {
+		return err
+	}
+	// The parent must not be stacked on branch
+	for b := opts.parent; b != ""; b = parents[b] {
+		if b == branch {
+			return fmt.Errorf("cannot stack %s on %s: %s is based on %s", branch, opts.parent, opts.parent, branch)
+		}
+	}
+
+	if err := git.SetStackParent(branch, opts.parent); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Stacked %s on %s\n", branch, opts.parent)
+	return nil
+}
+
+func newUntrackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "untrack [<branch>]",
+		Short: "Remove a branch from its stack",
+		Long: `Remove a branch (the current branch by default) from its stack. Branches
+stacked on it are moved onto its parent. The branch itself is kept.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var branch string
+			if len(args) > 0 {
+				branch = args[0]
+			}
+			return runUntrack(branch)
+		},
+	}
+}
+
+func runUntrack(branch string) error {
+	var err error
+	if branch == "" {
+		branch, err = git.CurrentBranch()
+		if err != nil {
+			return fmt.Errorf("could not determine current branch: %w", err)
+		}
+	}
+
+	parents, err := git.StackParents()
+	if err != nil {
+		return err
+	}
+	parent, ok := parents[branch]
+	if !ok {
+		return fmt.Errorf("branch %s is not part of a stack", branch)
+	}
+
+	if err := unstack(parents, branch); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed %s from its stack (was on %s)\n", branch, parent)
+	return nil
+}
+
+// unstack removes branch from its stack, moving the branches stacked on
+// it onto its parent
+func unstack(parents map[string]string, branch string) error {
+	for _, child := range childrenOf(parents, branch) {
+		if err := git.SetStackParent(child, parents[branch]); err != nil {
+			return err
+		}
+		parents[child] = parents[branch]
+	}
+	delete(parents, branch)
+	return git.UnsetStackParent(branch)
+}
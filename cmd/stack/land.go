@@ -0,0 +1,264 @@
+package stack
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// pipelineStartTimeout is how long land waits for a pipeline to appear
+// for a branch it just pushed before assuming there is no CI
+const pipelineStartTimeout = 2 * time.Minute
+
+type landOptions struct {
+	repo         string
+	squash       bool
+	rebase       bool
+	deleteBranch bool
+	noWait       bool
+	interval     int
+	yes          bool
+}
+
+func newLandCmd() *cobra.Command {
+	opts := &landOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "land",
+		Aliases: []string{"merge"},
+		Short:   "Merge the stack bottom-up",
+		Long: `Merge the merge requests of the current stack one by one, starting at
+the bottom.
+
+Each merge request must be open, not a draft, free of conflicts, approved
+and have a successful pipeline. Before a merge request is merged, the
+next one is retargeted to the base branch; after the merge, the rest of
+the stack is rebased onto the updated base and force-pushed, like
+'gf stack sync'. gf then waits for the pipeline of the next merge request
+unless --no-wait is given.
+
+Landing stops at the first merge request that cannot be merged.`,
+		Example: `  gf stack land
+  gf stack land --squash --delete-branch --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.interval < 1 {
+				return fmt.Errorf("--interval must be at least 1 second")
+			}
+			return runLand(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.squash, "squash", false, "Squash commits when merging")
+	cmd.Flags().BoolVar(&opts.rebase, "rebase", false, "Rebase commits onto the base branch")
+	cmd.Flags().BoolVarP(&opts.deleteBranch, "delete-branch", "d", false, "Delete source branches after merge")
+	cmd.Flags().BoolVar(&opts.noWait, "no-wait", false, "Stop instead of waiting for running pipelines")
+	cmd.Flags().IntVarP(&opts.interval, "interval", "i", 10, "Pipeline polling interval in seconds")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation")
+	cmd.MarkFlagsMutuallyExclusive("squash", "rebase")
+
+	return cmd
+}
+
+func runLand(opts *landOptions) error {
+	if err := requireClean(); err != nil {
+		return err
+	}
+	s, current, err := currentStack()
+	if err != nil {
+		return err
+	}
+
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+	remote := stackRemote(repo)
+
+	if err := git.Fetch(remote); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", remote, err)
+	}
+	entries, err := fetchEntries(client, repo, s)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.mr == nil || (e.mr.State() != "open" && !e.merged()) {
+			return fmt.Errorf("%s has no open merge request\nRun 'gf stack submit' first", e.branch)
+		}
+	}
+
+	if !opts.yes {
+		fmt.Printf("Landing into %s:\n", s.base)
+		for _, e := range entries {
+			if !e.merged() {
+				fmt.Printf("  #%d %s (%s)\n", e.mr.LocalID, e.mr.Title, e.branch)
+			}
+		}
+		fmt.Print("\nMerge these merge requests bottom-up? [y/N] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Ctrl+C stops waiting for pipelines
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	req := &api.MergeMRRequest{RemoveSourceBranch: opts.deleteBranch}
+	switch {
+	case opts.squash:
+		req.MergeMethod = api.MergeMethodSquash
+		req.SquashCommit = true
+	case opts.rebase:
+		req.MergeMethod = api.MergeMethodRebase
+	}
+
+	// Start from a stack without merged branches
+	pushed := false
+	for _, e := range entries {
+		if e.merged() {
+			if entries, err = syncStack(client, repo, remote, s, entries, current); err != nil {
+				return err
+			}
+			if len(entries) > 0 {
+				if s, current, err = currentStack(); err != nil {
+					return err
+				}
+			}
+			pushed = true
+			break
+		}
+	}
+
+	landed := 0
+	for len(entries) > 0 {
+		e := &entries[0]
+		if err := waitReady(ctx, client, repo, e, opts, pushed); err != nil {
+			if landed > 0 {
+				fmt.Fprintf(os.Stderr, "! Landed %d merge request(s); run 'gf stack land' again to continue\n", landed)
+			}
+			return err
+		}
+
+		// Keep the next merge request open if the merged branch is deleted
+		if len(entries) > 1 {
+			next := &entries[1]
+			next.parent = s.base
+			if err := retarget(client, repo, next); err != nil {
+				return err
+			}
+		}
+
+		if err := client.MergeRequests().Merge(repo.Owner, repo.Name, e.mr.LocalID, req); err != nil {
+			if len(entries) > 1 {
+				// Point the next merge request back at the unmerged branch
+				entries[1].parent = e.branch
+				if rerr := retarget(client, repo, &entries[1]); rerr != nil {
+					fmt.Fprintf(os.Stderr, "! %v; it now targets %s\n  Run 'gf mr edit %d --target %s' to restore it\n",
+						rerr, s.base, entries[1].mr.LocalID, e.branch)
+				}
+			}
+			return fmt.Errorf("failed to merge merge request #%d: %w", e.mr.LocalID, err)
+		}
+		fmt.Printf("✓ Merged merge request #%d (%s → %s)\n", e.mr.LocalID, e.branch, s.base)
+		landed++
+		e.mr.Status.ID = "MERGED"
+
+		if err := git.Fetch(remote); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", remote, err)
+		}
+		if entries, err = syncStack(client, repo, remote, s, entries, current); err != nil {
+			return err
+		}
+		pushed = true
+		if len(entries) == 0 {
+			break
+		}
+		if s, current, err = currentStack(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("✓ Landed %d merge request(s) into %s\n", landed, s.base)
+	return nil
+}
+
+// waitReady refreshes the merge request of e and returns nil once it can
+// be merged. Running pipelines are waited for unless --no-wait is given;
+// right after a push, gf also waits for the pipeline to start.
+func waitReady(ctx context.Context, client *api.Client, repo *git.Repository, e *entry, opts *landOptions, pushed bool) error {
+	mr, err := client.MergeRequests().Get(repo.Owner, repo.Name, e.mr.LocalID)
+	if err != nil {
+		return fmt.Errorf("failed to get merge request #%d: %w", e.mr.LocalID, err)
+	}
+	e.mr = mr
+
+	switch {
+	case mr.State() != "open":
+		return fmt.Errorf("merge request #%d is %s", mr.LocalID, mr.State())
+	case mr.IsDraft:
+		return fmt.Errorf("merge request #%d is a draft (run 'gf mr ready %d')", mr.LocalID, mr.LocalID)
+	case mr.HasConflicts:
+		return fmt.Errorf("merge request #%d has conflicts, resolve them first", mr.LocalID)
+	}
+
+	interval := time.Duration(opts.interval) * time.Second
+	sha, err := git.RefSHA("refs/heads/" + e.branch)
+	if err != nil {
+		sha = mr.SourceBranch.Hash
+	}
+	p, err := latestPipeline(ctx, client, repo, e.branch, sha)
+	if err != nil {
+		return fmt.Errorf("failed to check pipeline: %w", err)
+	}
+	if p == nil && pushed && !opts.noWait {
+		fmt.Printf("Waiting for a pipeline of %s to start...\n", e.branch)
+		deadline := time.Now().Add(pipelineStartTimeout)
+		for p == nil && time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+			if p, err = latestPipeline(ctx, client, repo, e.branch, sha); err != nil {
+				return fmt.Errorf("failed to check pipeline: %w", err)
+			}
+		}
+	}
+
+	if p != nil && !p.IsFinished() {
+		if opts.noWait {
+			return fmt.Errorf("pipeline #%d of merge request #%d is %s", p.LocalID, mr.LocalID, p.NormalizedStatus())
+		}
+		fmt.Printf("Waiting for pipeline #%d of merge request #%d...\n", p.LocalID, mr.LocalID)
+		if p, err = client.Pipelines().Watch(ctx, repo.Owner, repo.Name, p.LocalID, interval, nil); err != nil {
+			return fmt.Errorf("failed to watch pipeline: %w", err)
+		}
+	}
+	if p != nil && !p.Succeeded() {
+		return fmt.Errorf("pipeline #%d of merge request #%d %s", p.LocalID, mr.LocalID, p.NormalizedStatus())
+	}
+
+	missing, err := client.MergeRequests().MissingApprovals(repo.Owner, repo.Name, mr)
+	if err != nil {
+		return err
+	}
+	if missing != "" {
+		return fmt.Errorf("merge request #%d cannot be merged: %s", mr.LocalID, missing)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdStack returns the stack command group
+func NewCmdStack() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stack",
+		Short: "Work with stacked merge requests",
+		Long: `Manage chains of dependent branches where each merge request targets
+the branch below it.
+
+The chain is recorded in git config (branch.<name>.gf-parent), so it
+survives across sessions and works with plain git commands. A stack is
+based on a branch that is not part of it, usually the default branch.
+
+  gf stack add feature-a        # branch off the current branch
+  gf stack add feature-b        # stack another branch on top
+  gf stack submit               # push and open one merge request per branch
+  gf stack view                 # show merge requests and pipelines
+  gf stack sync                 # rebase after a lower merge request merged
+  gf stack land                 # merge the stack bottom-up`,
+	}
+
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newTrackCmd())
+	cmd.AddCommand(newUntrackCmd())
+	cmd.AddCommand(newViewCmd())
+	cmd.AddCommand(newSubmitCmd())
+	cmd.AddCommand(newSyncCmd())
+	cmd.AddCommand(newLandCmd())
+
+	return cmd
+}
+
+// stack is a chain of branches, each based on the one before it
+type stack struct {
+	// base is the branch the bottom branch is based on; it is not part
+	// of the stack
+	base string
+	// branches are ordered bottom first
+	branches []string
+}
+
+// parent returns the branch that branches[i] is based on
+func (s *stack) parent(i int) string {
+	if i == 0 {
+		return s.base
+	}
+	return s.branches[i-1]
+}
+
+// index returns the position of branch in the stack, or -1
+func (s *stack) index(branch string) int {
+	for i, b := range s.branches {
+		if b == branch {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildStack returns the stack that contains branch, using the recorded
+// parent of every stacked branch. If branch is not stacked itself, it is
+// taken as the base of the stack built on it.
+func buildStack(parents map[string]string, branch string) (*stack, error) {
+	// Walk down to the base
+	chain := []string{}
+	seen := map[string]bool{}
+	bottom := branch
+	for {
+		parent, ok := parents[bottom]
+		if !ok {
+			break
+		}
+		if seen[bottom] {
+			return nil, fmt.Errorf("stack of %s has a cycle at %s\nFix it with 'gf stack track'", branch, bottom)
+		}
+		seen[bottom] = true
+		chain = append([]string{bottom}, chain...)
+		bottom = parent
+	}
+	s := &stack{base: bottom, branches: chain}
+
+	// Walk up while there is a single branch on top
+	top := branch
+	for {
+		children := childrenOf(parents, top)
+		if len(children) == 0 {
+			break
+		}
+		if len(children) > 1 {
+			return nil, fmt.Errorf("several branches are stacked on %s (%s)\nCheck out one of them to work with its stack",
+				top, strings.Join(children, ", "))
+		}
+		top = children[0]
+		if seen[top] {
+			return nil, fmt.Errorf("stack of %s has a cycle at %s\nFix it with 'gf stack track'", branch, top)
+		}
+		seen[top] = true
+		s.branches = append(s.branches, top)
+	}
+
+	if len(s.branches) == 0 {
+		return nil, fmt.Errorf("branch %s is not part of a stack\nStart one with 'gf stack add <branch>'", branch)
+	}
+	return s, nil
+}
+
+// childrenOf returns the branches stacked directly on branch, sorted
+func childrenOf(parents map[string]string, branch string) []string {
+	var children []string
+	for b, parent := range parents {
+		if parent == branch {
+			children = append(children, b)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// currentStack returns the stack of the current branch and the branch
+func currentStack() (*stack, string, error) {
+	current, err := git.CurrentBranch()
+	if err != nil {
+		return nil, "", fmt.Errorf("could not determine current branch: %w", err)
+	}
+	parents, err := git.StackParents()
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := buildStack(parents, current)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, current, nil
+}
+
+// entry is a branch of a stack with its merge request and pipeline
+type entry struct {
+	branch   string
+	parent   string
+	mr       *api.MergeRequest // nil if none was created yet
+	pipeline *api.Pipeline     // nil if the head commit has no pipeline
+}
+
+// merged reports whether the merge request of the branch was merged
+func (e *entry) merged() bool {
+	return e.mr != nil && e.mr.State() == "merged"
+}
+
+// fetchEntries returns the merge request and pipeline of every branch of
+// s, bottom first
+func fetchEntries(client *api.Client, repo *git.Repository, s *stack) ([]entry, error) {
+	pipelines, err := client.Pipelines().List(repo.Owner, repo.Name)
+	if err != nil && !api.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	entries := make([]entry, len(s.branches))
+	for i, branch := range s.branches {
+		entries[i] = entry{branch: branch, parent: s.parent(i)}
+		mrs, err := client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{SourceBranch: branch})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list merge requests of %s: %w", branch, err)
+		}
+		entries[i].mr = branchMR(mrs)
+		if mr := entries[i].mr; mr != nil && mr.SourceBranch.Hash != "" {
+			entries[i].pipeline = api.LatestPipeline(pipelines, branch, mr.SourceBranch.Hash)
+		}
+	}
+	return entries, nil
+}
+
+// branchMR picks the merge request of a branch: the open one, or else the
+// most recent one
+func branchMR(mrs []api.MergeRequest) *api.MergeRequest {
+	var best *api.MergeRequest
+	for i := range mrs {
+		mr := &mrs[i]
+		switch {
+		case best == nil:
+			best = mr
+		case (mr.State() == "open") != (best.State() == "open"):
+			if mr.State() == "open" {
+				best = mr
+			}
+		case mr.LocalID > best.LocalID:
+			best = mr
+		}
+	}
+	return best
+}
+
+// latestPipeline returns the latest pipeline of branch at sha, or nil
+func latestPipeline(ctx context.Context, client *api.Client, repo *git.Repository, branch, sha string) (*api.Pipeline, error) {
+	p, err := client.Pipelines().LatestForCommit(ctx, repo.Owner, repo.Name, branch, sha)
+	if api.IsNotFound(err) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// stackRemote returns the local remote of repo, origin by default
+func stackRemote(repo *git.Repository) string {
+	if remote, err := git.FindRemoteFor(repo.Owner, repo.Name); err == nil && remote != "" {
+		return remote
+	}
+	return "origin"
+}
+
+// needsPush reports whether the local branch differs from its copy on
+// remote (or is not on remote at all)
+func needsPush(remote, branch string) bool {
+	local, err := git.RefSHA("refs/heads/" + branch)
+	if err != nil {
+		return false
+	}
+	pushed, err := git.RefSHA("refs/remotes/" + remote + "/" + branch)
+	return err != nil || pushed != local
+}
+
+// pushBranches force-pushes the branches of s that differ from remote
+func pushBranches(remote string, branches []string) error {
+	for _, branch := range branches {
+		if !needsPush(remote, branch) {
+			continue
+		}
+		if err := git.ForcePush(remote, branch); err != nil {
+			return fmt.Errorf("failed to push %s: %w", branch, err)
+		}
+		fmt.Printf("✓ Pushed %s\n", branch)
+	}
+	return nil
+}
+
+// retarget points the merge request of e at e.parent if it targets
+// another branch
+func retarget(client *api.Client, repo *git.Repository, e *entry) error {
+	if e.mr == nil || e.mr.State() != "open" || e.mr.TargetBranch.Title == e.parent {
+		return nil
+	}
+	mr, err := client.MergeRequests().Update(repo.Owner, repo.Name, e.mr.LocalID, &api.UpdateMRRequest{
+		TargetBranch: &api.BranchRef{ID: e.parent},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retarget merge request #%d: %w", e.mr.LocalID, err)
+	}
+	fmt.Printf("✓ Retargeted merge request #%d: %s → %s\n", e.mr.LocalID, e.branch, e.parent)
+	if mr != nil && mr.LocalID != 0 {
+		e.mr = mr
+	} else {
+		e.mr.TargetBranch.Title = e.parent
+	}
+	return nil
+}
+
+// repoClient resolves the repository and creates an API client
+func repoClient(repoFlag string) (*git.Repository, *api.Client, error) {
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	return repo, api.NewClientForHost(cfg, cfg.ActiveHost, token), nil
+}
+
+// requireClean fails if the working tree has uncommitted changes, since
+// rebasing and switching branches would touch them
+func requireClean() error {
+	dirty, err := git.HasUncommittedChanges()
+	if err != nil {
+		return fmt.Errorf("could not check working tree: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("you have uncommitted changes; commit or stash them first")
+	}
+	return nil
+}
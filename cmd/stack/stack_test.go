@@ -0,0 +1,292 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+)
+
+func TestBuildStack(t *testing.T) {
+	parents := map[string]string{
+		"a":     "main",
+		"b":     "a",
+		"c":     "b",
+		"x":     "main",
+		"y1":    "x",
+		"y2":    "x",
+		"loop1": "loop2",
+		"loop2": "loop1",
+	}
+	tests := []struct {
+		branch   string
+		base     string
+		branches []string
+		wantErr  string
+	}{
+		{branch: "a", base: "main", branches: []string{"a", "b", "c"}},
+		{branch: "b", base: "main", branches: []string{"a", "b", "c"}},
+		{branch: "c", base: "main", branches: []string{"a", "b", "c"}},
+		{branch: "y1", base: "main", branches: []string{"x", "y1"}},
+		{branch: "x", wantErr: "several branches are stacked on x (y1, y2)"},
+		{branch: "main", wantErr: "several branches are stacked on main (a, x)"},
+		{branch: "other", wantErr: "not part of a stack"},
+		{branch: "loop1", wantErr: "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			s, err := buildStack(parents, tt.branch)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("buildStack(%s) error = %v, want %q", tt.branch, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildStack(%s) failed: %v", tt.branch, err)
+			}
+			if s.base != tt.base || !reflect.DeepEqual(s.branches, tt.branches) {
+				t.Errorf("buildStack(%s) = %s %v, want %s %v", tt.branch, s.base, s.branches, tt.base, tt.branches)
+			}
+		})
+	}
+
+	// The base of a single stack resolves to that stack
+	s, err := buildStack(map[string]string{"a": "main", "b": "a"}, "main")
+	if err != nil || s.base != "main" || !reflect.DeepEqual(s.branches, []string{"a", "b"}) {
+		t.Errorf("buildStack(main) = %+v, %v", s, err)
+	}
+}
+
+func TestBranchMR(t *testing.T) {
+	mr := func(id int, status string) api.MergeRequest {
+		return api.MergeRequest{LocalID: id, Status: api.Status{ID: status}}
+	}
+	tests := []struct {
+		name string
+		mrs  []api.MergeRequest
+		want int
+	}{
+		{"none", nil, 0},
+		{"open wins", []api.MergeRequest{mr(5, "MERGED"), mr(3, "OPEN"), mr(7, "CANCELED")}, 3},
+		{"latest closed", []api.MergeRequest{mr(2, "CANCELED"), mr(4, "MERGED")}, 4},
+	}
+	for _, tt := range tests {
+		got := branchMR(tt.mrs)
+		switch {
+		case tt.want == 0 && got != nil:
+			t.Errorf("%s: got #%d, want none", tt.name, got.LocalID)
+		case tt.want != 0 && (got == nil || got.LocalID != tt.want):
+			t.Errorf("%s: got %+v, want #%d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteStack(t *testing.T) {
+	s := &stack{base: "main", branches: []string{"feature-api", "ui", "docs"}}
+	entries := []entry{
+		{branch: "feature-api", parent: "main", mr: &api.MergeRequest{
+			LocalID: 12, Title: "Add API", Status: api.Status{ID: "MERGED"},
+			TargetBranch: api.Branch{Title: "main"},
+		}},
+		{branch: "ui", parent: "feature-api", mr: &api.MergeRequest{
+			LocalID: 13, Title: "Add UI", Status: api.Status{ID: "OPEN"},
+			TargetBranch: api.Branch{Title: "feature-api"},
+		}, pipeline: &api.Pipeline{LocalID: 45, Status: "success"}},
+		{branch: "docs", parent: "ui"},
+	}
+
+	var buf bytes.Buffer
+	writeStack(&buf, s, entries, "ui", map[string]bool{"docs": true}, false)
+	want := `○ docs         -    -       -          needs push · not submitted
+│
+● ui           #13  open    ✓ success  Add UI
+│
+○ feature-api  #12  merged  -          Add API
+│
+main
+`
+	if buf.String() != want {
+		t.Errorf("writeStack() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// A merge request targeting another branch than the parent
+	entries[1].parent = "main"
+	buf.Reset()
+	writeStack(&buf, &stack{base: "main", branches: []string{"ui"}}, entries[1:2], "", nil, false)
+	if !strings.Contains(buf.String(), "targets feature-api, run 'gf stack sync'") {
+		t.Errorf("writeStack() did not report the wrong target:\n%s", buf.String())
+	}
+}
+
+func TestDescribeCommits(t *testing.T) {
+	title, body := describeCommits([]git.Commit{{Subject: "Add API", Body: "Details"}})
+	if title != "Add API" || body != "Details" {
+		t.Errorf("one commit: %q, %q", title, body)
+	}
+	title, body = describeCommits([]git.Commit{{Subject: "Add API"}, {Subject: "Fix tests"}})
+	if title != "Add API" || body != "- Add API\n- Fix tests" {
+		t.Errorf("two commits: %q, %q", title, body)
+	}
+}
+
+// initTestRepo creates a git repository with a bare origin in temp dirs
+// and changes into it
+func initTestRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir, remote := t.TempDir(), t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	gitT(t, "init", "-q", "--bare", remote)
+	gitT(t, "init", "-q", "-b", "main")
+	gitT(t, "remote", "add", "origin", remote)
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Initial commit")
+	gitT(t, "push", "-q", "origin", "main")
+}
+
+func gitT(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile commits a new file on the current branch
+func commitFile(t *testing.T, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(message+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitT(t, "add", name)
+	gitT(t, "commit", "-q", "-m", message)
+}
+
+func subjects(t *testing.T, base, head string) []string {
+	t.Helper()
+	commits, err := git.CommitsBetween(base, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result []string
+	for _, c := range commits {
+		result = append(result, c.Subject)
+	}
+	return result
+}
+
+func TestSyncStack(t *testing.T) {
+	initTestRepo(t)
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	// main ← a ← b ← c
+	for _, b := range []struct{ branch, parent string }{{"a", "main"}, {"b", "a"}, {"c", "b"}} {
+		gitT(t, "checkout", "-q", "-b", b.branch)
+		commitFile(t, b.branch+".txt", "Add "+b.branch)
+		if err := git.SetStackParent(b.branch, b.parent); err != nil {
+			t.Fatal(err)
+		}
+		gitT(t, "push", "-q", "origin", b.branch)
+	}
+
+	// a is squash-merged into main on the server
+	gitT(t, "checkout", "-q", "main")
+	gitT(t, "merge", "-q", "--squash", "a")
+	gitT(t, "commit", "-q", "-m", "Add a (#1)")
+	gitT(t, "push", "-q", "origin", "main")
+	gitT(t, "reset", "-q", "--hard", "HEAD~1")
+	gitT(t, "checkout", "-q", "c")
+
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req api.UpdateMRRequest
+		if err := json.Unmarshal(body, &req); err != nil || req.TargetBranch == nil {
+			t.Errorf("bad update request %s", body)
+			return
+		}
+		updates = append(updates, r.URL.Path+" → "+req.TargetBranch.ID)
+		w.Write([]byte(`{"localId": 2, "status": {"id": "OPEN"}, "targetBranch": {"title": "` + req.TargetBranch.ID + `"}}`))
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+	repo := &git.Repository{Host: "gitflic.ru", Owner: "owner", Name: "repo"}
+
+	mr := func(id int, status, target string) *api.MergeRequest {
+		return &api.MergeRequest{LocalID: id, Status: api.Status{ID: status}, TargetBranch: api.Branch{Title: target}}
+	}
+	s := &stack{base: "main", branches: []string{"a", "b", "c"}}
+	entries := []entry{
+		{branch: "a", parent: "main", mr: mr(1, "MERGED", "main")},
+		{branch: "b", parent: "a", mr: mr(2, "OPEN", "a")},
+		{branch: "c", parent: "b", mr: mr(3, "OPEN", "b")},
+	}
+
+	if err := git.Fetch("origin"); err != nil {
+		t.Fatal(err)
+	}
+	kept, err := syncStack(client, repo, "origin", s, entries, "c")
+	if err != nil {
+		t.Fatalf("syncStack failed: %v", err)
+	}
+
+	if len(kept) != 2 || kept[0].branch != "b" || kept[0].parent != "main" || kept[1].parent != "b" {
+		t.Errorf("kept = %+v", kept)
+	}
+	if got := subjects(t, "origin/main", "b"); !reflect.DeepEqual(got, []string{"Add b"}) {
+		t.Errorf("b on origin/main = %v, want [Add b]", got)
+	}
+	if got := subjects(t, "b", "c"); !reflect.DeepEqual(got, []string{"Add c"}) {
+		t.Errorf("c on b = %v, want [Add c]", got)
+	}
+	for _, b := range []string{"b", "c"} {
+		if gitT(t, "rev-parse", b) != gitT(t, "rev-parse", "origin/"+b) {
+			t.Errorf("%s was not pushed", b)
+		}
+	}
+	if current, _ := git.CurrentBranch(); current != "c" {
+		t.Errorf("current branch = %s, want c", current)
+	}
+
+	parents, err := git.StackParents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"b": "main", "c": "b"}; !reflect.DeepEqual(parents, want) {
+		t.Errorf("parents = %v, want %v", parents, want)
+	}
+	if want := []string{"/project/owner/repo/merge-request/2 → main"}; !reflect.DeepEqual(updates, want) {
+		t.Errorf("updates = %v, want %v", updates, want)
+	}
+}
@@ -0,0 +1,141 @@
+package stack
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+type submitOptions struct {
+	repo  string
+	draft bool
+}
+
+func newSubmitCmd() *cobra.Command {
+	opts := &submitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Push the stack and create or update its merge requests",
+		Long: `Push every branch of the current stack and make sure each one has an open
+merge request targeting the branch below it.
+
+Missing merge requests are created with the title of the first commit of
+the branch and a list of its commits as description. Existing merge
+requests that target another branch are retargeted. Branches are pushed
+with --force-with-lease, since stacked branches are rebased.`,
+		Example: `  gf stack submit
+  gf stack submit --draft`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSubmit(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVarP(&opts.draft, "draft", "d", false, "Create new merge requests as drafts")
+
+	return cmd
+}
+
+func runSubmit(opts *submitOptions) error {
+	s, current, err := currentStack()
+	if err != nil {
+		return err
+	}
+
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+	remote := stackRemote(repo)
+
+	entries, err := fetchEntries(client, repo, s)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.merged() {
+			return fmt.Errorf("merge request #%d of %s was merged\nRun 'gf stack sync' first", e.mr.LocalID, e.branch)
+		}
+	}
+
+	if err := pushBranches(remote, s.branches); err != nil {
+		return err
+	}
+
+	var project *api.Project
+	for i := range entries {
+		e := &entries[i]
+		if e.mr != nil && e.mr.State() == "open" {
+			if err := retarget(client, repo, e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		base := e.parent
+		if i == 0 && git.RemoteBranchExists(remote, s.base) {
+			base = remote + "/" + s.base
+		}
+		commits, err := git.CommitsBetween(base, e.branch)
+		if err != nil {
+			return fmt.Errorf("failed to list commits of %s: %w", e.branch, err)
+		}
+		if len(commits) == 0 {
+			fmt.Fprintf(os.Stderr, "! Skipping %s: no commits on top of %s\n", e.branch, e.parent)
+			continue
+		}
+
+		if project == nil {
+			project, err = client.Projects().Get(repo.Owner, repo.Name)
+			if err != nil {
+				return fmt.Errorf("failed to get project info: %w", err)
+			}
+		}
+		title, body := describeCommits(commits)
+		mr, err := client.MergeRequests().Create(repo.Owner, repo.Name, &api.CreateMRRequest{
+			Title:         title,
+			Description:   body,
+			SourceBranch:  api.BranchRef{ID: e.branch},
+			TargetBranch:  api.BranchRef{ID: e.parent},
+			SourceProject: api.ProjectRef{ID: project.ID},
+			TargetProject: api.ProjectRef{ID: project.ID},
+			IsDraft:       opts.draft,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create merge request for %s: %w", e.branch, err)
+		}
+		e.mr = mr
+		fmt.Printf("✓ Created merge request #%d: %s → %s\n", mr.LocalID, e.branch, e.parent)
+	}
+
+	unpushed := make(map[string]bool)
+	for _, b := range s.branches {
+		unpushed[b] = needsPush(remote, b)
+	}
+	color := term.IsTerminal(int(os.Stdout.Fd())) && !api.NoColor()
+	fmt.Println()
+	writeStack(os.Stdout, s, entries, current, unpushed, color)
+	return nil
+}
+
+// describeCommits returns the title and description of a new merge
+// request: the subject of the first commit, and the list of commits if
+// there are several
+func describeCommits(commits []git.Commit) (string, string) {
+	title := commits[0].Subject
+	if len(commits) == 1 {
+		return title, commits[0].Body
+	}
+	var body strings.Builder
+	for _, c := range commits {
+		fmt.Fprintf(&body, "- %s\n", c.Subject)
+	}
+	return title, strings.TrimSuffix(body.String(), "\n")
+}
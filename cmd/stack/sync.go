@@ -0,0 +1,180 @@
+package stack
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/spf13/cobra"
+)
+
+type syncOptions struct {
+	repo string
+}
+
+func newSyncCmd() *cobra.Command {
+	opts := &syncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Rebase the stack and drop merged branches",
+		Long: `Bring the current stack up to date with its base branch.
+
+gf fetches the remote, removes branches whose merge request was merged
+from the stack, and rebases every remaining branch onto the branch below
+it (the bottom one onto the remote base branch). Rebased branches are
+force-pushed with --force-with-lease and merge requests that target a
+merged branch are retargeted.
+
+If a rebase stops because of conflicts, resolve them, run
+'git rebase --continue' and run 'gf stack sync' again.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSync(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+
+	return cmd
+}
+
+func runSync(opts *syncOptions) error {
+	if err := requireClean(); err != nil {
+		return err
+	}
+	s, current, err := currentStack()
+	if err != nil {
+		return err
+	}
+
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	remote := stackRemote(repo)
+	if err := git.Fetch(remote); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", remote, err)
+	}
+
+	entries, err := fetchEntries(client, repo, s)
+	if err != nil {
+		return err
+	}
+	if _, err := syncStack(client, repo, remote, s, entries, current); err != nil {
+		return err
+	}
+	fmt.Println("✓ Stack is up to date")
+	return nil
+}
+
+// syncStack drops merged branches from s, rebases the remaining ones onto
+// the branch below them, pushes them and retargets their merge requests.
+// It returns the remaining entries and switches back to current, or to
+// the lowest remaining branch if current was merged.
+func syncStack(client *api.Client, repo *git.Repository, remote string, s *stack, entries []entry, current string) ([]entry, error) {
+	// Skip merged branches: what was stacked on them moves down
+	var kept []entry
+	var merged []string
+	parent := s.base
+	for _, e := range entries {
+		if e.merged() {
+			merged = append(merged, e.branch)
+			continue
+		}
+		e.parent = parent
+		kept = append(kept, e)
+		parent = e.branch
+	}
+
+	baseRef := s.base
+	if git.RemoteBranchExists(remote, s.base) {
+		baseRef = remote + "/" + s.base
+	}
+
+	for i := range kept {
+		e := &kept[i]
+		onto := e.parent
+		if e.parent == s.base {
+			onto = baseRef
+		}
+		recorded := s.parent(s.index(e.branch))
+
+		if !git.IsAncestor(onto, e.branch) {
+			fmt.Printf("Rebasing %s onto %s...\n", e.branch, onto)
+			if err := git.RebaseOnto(onto, rebaseUpstream(remote, s.base, recorded, onto, e.branch), e.branch); err != nil {
+				return nil, fmt.Errorf("rebase of %s onto %s stopped\n"+
+					"Resolve the conflicts, run 'git rebase --continue' and then 'gf stack sync' again", e.branch, onto)
+			}
+		}
+		if recorded != e.parent {
+			if err := git.SetStackParent(e.branch, e.parent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Go back to where the user was
+	if s.index(current) >= 0 && !containsEntry(kept, current) {
+		current = s.base
+		if len(kept) > 0 {
+			current = kept[0].branch
+		}
+	}
+	if err := git.Checkout(current); err != nil {
+		return nil, fmt.Errorf("failed to check out %s: %w", current, err)
+	}
+
+	branches := make([]string, len(kept))
+	for i, e := range kept {
+		branches[i] = e.branch
+	}
+	if err := pushBranches(remote, branches); err != nil {
+		return nil, err
+	}
+	for i := range kept {
+		if err := retarget(client, repo, &kept[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range merged {
+		if err := git.UnsetStackParent(b); err != nil {
+			return nil, err
+		}
+		fmt.Printf("✓ Removed merged branch %s from the stack\n", b)
+		fmt.Fprintf(os.Stderr, "! Branch %s is kept locally; delete it with 'git branch -D %s'\n", b, b)
+	}
+	return kept, nil
+}
+
+// rebaseUpstream returns the commit below the own commits of branch,
+// which was stacked on recorded: where it forked from recorded, even if
+// recorded was rebased since. Branches on the base are rebased from onto.
+func rebaseUpstream(remote, base, recorded, onto, branch string) string {
+	if recorded == base {
+		return onto
+	}
+	if git.LocalBranchExists(recorded) {
+		if sha, err := git.ForkPoint(recorded, branch); err == nil {
+			return sha
+		}
+		return recorded
+	}
+	if git.RemoteBranchExists(remote, recorded) {
+		return remote + "/" + recorded
+	}
+	return onto
+}
+
+// containsEntry reports whether entries has one for branch
+func containsEntry(entries []entry, branch string) bool {
+	for _, e := range entries {
+		if e.branch == branch {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+type viewOptions struct {
+	repo string
+	json bool
+}
+
+func newViewCmd() *cobra.Command {
+	opts := &viewOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "view",
+		Aliases: []string{"ls", "status"},
+		Short:   "Show the stack of the current branch",
+		Long: `Show the branches of the current stack, top first, with the state of
+each merge request and the pipeline of its head commit.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runView(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository (owner/name)")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// stackEntryJSON is the JSON form of a stacked branch
+type stackEntryJSON struct {
+	Branch       string `json:"branch"`
+	Parent       string `json:"parent"`
+	MergeRequest int    `json:"mergeRequest,omitempty"`
+	Title        string `json:"title,omitempty"`
+	State        string `json:"state,omitempty"`
+	TargetBranch string `json:"targetBranch,omitempty"`
+	Pipeline     int    `json:"pipeline,omitempty"`
+	Status       string `json:"pipelineStatus,omitempty"`
+	NeedsPush    bool   `json:"needsPush"`
+}
+
+func runView(opts *viewOptions) error {
+	s, current, err := currentStack()
+	if err != nil {
+		return err
+	}
+
+	repo, client, err := repoClient(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	entries, err := fetchEntries(client, repo, s)
+	if err != nil {
+		return err
+	}
+	remote := stackRemote(repo)
+	unpushed := make(map[string]bool)
+	for _, b := range s.branches {
+		unpushed[b] = needsPush(remote, b)
+	}
+
+	if opts.json {
+		result := make([]stackEntryJSON, len(entries))
+		for i, e := range entries {
+			result[i] = stackEntryJSON{Branch: e.branch, Parent: e.parent, NeedsPush: unpushed[e.branch]}
+			if e.mr != nil {
+				result[i].MergeRequest = e.mr.LocalID
+				result[i].Title = e.mr.Title
+				result[i].State = e.mr.State()
+				result[i].TargetBranch = e.mr.TargetBranch.Title
+			}
+			if e.pipeline != nil {
+				result[i].Pipeline = e.pipeline.LocalID
+				result[i].Status = e.pipeline.NormalizedStatus()
+			}
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	color := term.IsTerminal(int(os.Stdout.Fd())) && !api.NoColor()
+	fmt.Printf("Stack on %s in %s\n\n", s.base, repo.FullName())
+	writeStack(os.Stdout, s, entries, current, unpushed, color)
+	return nil
+}
+
+// writeStack renders the stack top first: one line per branch with its
+// merge request, pipeline and what needs to be done, then the base
+func writeStack(w io.Writer, s *stack, entries []entry, current string, unpushed map[string]bool, color bool) {
+	colorize := func(code, text string) string {
+		if !color || code == "" {
+			return text
+		}
+		return code + text + "\033[0m"
+	}
+
+	type row struct {
+		marker, branch, mr, state, stateColor, ci, ciColor, note string
+	}
+	rows := make([]row, len(entries))
+	branchWidth, mrWidth, stateWidth, ciWidth := 0, 0, 0, 0
+	for i, e := range entries {
+		r := row{marker: "○", branch: e.branch, mr: "-", state: "-", ci: "-"}
+		if e.branch == current {
+			r.marker = "●"
+		}
+		switch {
+		case e.mr == nil:
+			r.note = "not submitted"
+		case e.merged():
+			r.note = e.mr.Title
+		case e.mr.State() == "open" && e.mr.TargetBranch.Title != e.parent:
+			r.note = fmt.Sprintf("targets %s, run 'gf stack sync'", e.mr.TargetBranch.Title)
+		default:
+			r.note = e.mr.Title
+		}
+		if e.mr != nil {
+			r.mr = fmt.Sprintf("#%d", e.mr.LocalID)
+			r.state = e.mr.State()
+			if e.mr.IsDraft && r.state == "open" {
+				r.state = "draft"
+			}
+			r.stateColor = api.MRStateColor(e.mr.State())
+		}
+		if e.pipeline != nil {
+			r.ci = fmt.Sprintf("%s %s", api.StatusIcon(e.pipeline.Status), e.pipeline.NormalizedStatus())
+			r.ciColor = api.StatusColor(e.pipeline.Status)
+		}
+		if unpushed[e.branch] && !e.merged() {
+			r.note = "needs push · " + r.note
+		}
+
+		branchWidth = max(branchWidth, len([]rune(r.branch)))
+		mrWidth = max(mrWidth, len(r.mr))
+		stateWidth = max(stateWidth, len(r.state))
+		ciWidth = max(ciWidth, len([]rune(r.ci)))
+		rows[i] = r
+	}
+
+	for i := len(rows) - 1; i >= 0; i-- {
+		r := rows[i]
+		pad := func(s string, width int) string {
+			return s + strings.Repeat(" ", width-len([]rune(s)))
+		}
+		line := fmt.Sprintf("%s %s  %s  %s  %s  %s", r.marker, pad(r.branch, branchWidth), pad(r.mr, mrWidth),
+			colorize(r.stateColor, pad(r.state, stateWidth)), colorize(r.ciColor, pad(r.ci, ciWidth)), r.note)
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+		fmt.Fprintln(w, "│")
+	}
+	fmt.Fprintln(w, s.base)
+}
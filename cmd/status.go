@@ -59,14 +59,16 @@ func runStatus(opts *statusOptions) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	fmt.Printf("\nCurrent branch: %s\n", currentBranch)
 	fmt.Println(strings.Repeat("─", 50))
 
 	// Find MR for current branch
 	mrs, err := client.MergeRequests().List(repo.Owner, repo.Name, &api.MRListOptions{
-		State: "open",
+		State:        "open",
+		SourceBranch: currentBranch,
+		Limit:        1,
 	})
 	if err != nil {
 		fmt.Printf("  Could not fetch MRs: %v\n", err)
@@ -86,6 +88,9 @@ func runStatus(opts *statusOptions) error {
 				if mr.HasConflicts {
 					fmt.Printf("    ⚠ Has conflicts\n")
 				}
+				if a, err := client.MergeRequests().Approvals(repo.Owner, repo.Name, mr.LocalID); err == nil {
+					fmt.Printf("    Approval: %s\n", a.Summary())
+				}
 				foundMR = true
 				break
 			}
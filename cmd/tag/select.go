@@ -0,0 +1,71 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/git"
+	"github.com/josinSbazin/gf/internal/output"
+	"github.com/josinSbazin/gf/internal/prompt"
+)
+
+// tagNameArg returns the tag argument of a command. Without one, the
+// user picks a tag interactively.
+func tagNameArg(args []string, repoFlag string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if !prompt.Enabled() {
+		return "", fmt.Errorf("tag name required when not running interactively")
+	}
+
+	repo, err := git.ResolveRepo(repoFlag, config.DefaultHost())
+	if err != nil {
+		return "", fmt.Errorf("could not determine repository: %w\nUse --repo owner/name to specify", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	token, err := cfg.Token()
+	if err != nil {
+		return "", fmt.Errorf("not authenticated. Run 'gf auth login' first")
+	}
+
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
+
+	tags, err := client.Tags().List(repo.Owner, repo.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return "", fmt.Errorf("no tags in %s", repo.FullName())
+	}
+
+	items := make([]prompt.Item, len(tags))
+	for i, t := range tags {
+		items[i].Label = t.Name
+		commit := t.CommitID
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		preview := "Commit: " + commit
+		if !t.CreatedAt.IsZero() {
+			preview += " · " + output.FormatRelativeTime(t.CreatedAt)
+		}
+		if message := strings.TrimSpace(t.FullMessage); message != "" {
+			preview += "\n\n" + message
+		}
+		items[i].Preview = preview
+	}
+
+	i, err := prompt.Select("Select a tag", items)
+	if err != nil {
+		return "", err
+	}
+	return tags[i].Name, nil
+}
@@ -110,7 +110,7 @@ func runCreate(opts *createOptions, webhookURL string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Create webhook
 	webhook, err := client.Webhooks().Create(repo.Owner, repo.Name, &api.CreateWebhookRequest{
@@ -62,7 +62,7 @@ func runDelete(opts *deleteOptions, webhookID string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Check if webhook exists
 	webhook, err := client.Webhooks().Get(repo.Owner, repo.Name, webhookID)
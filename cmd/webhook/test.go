@@ -47,7 +47,7 @@ func runTest(repoFlag string, webhookID string) error {
 		return fmt.Errorf("not authenticated. Run 'gf auth login' first")
 	}
 
-	client := api.NewClient(config.BaseURL(cfg.ActiveHost), token)
+	client := api.NewClientForHost(cfg, cfg.ActiveHost, token)
 
 	// Get webhook to show URL
 	webhook, err := client.Webhooks().Get(repo.Owner, repo.Name, webhookID)
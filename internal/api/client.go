@@ -18,6 +18,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/josinSbazin/gf/internal/config"
 	"github.com/josinSbazin/gf/internal/cookies"
 	"github.com/josinSbazin/gf/internal/version"
 )
@@ -31,48 +32,81 @@ const (
 type Client struct {
 	BaseURL      string
 	Token        string
+	Hostname     string // configured hostname the client talks to (gitflic.ru for api.gitflic.ru)
 	httpClient   *http.Client
 	cookieStore  *cookies.Store
 	cookiesMu    sync.Mutex
 	cookiesReady atomic.Bool
+	warmupURL    string // guarded by cookiesMu
 }
 
-// NewClient creates a new API client with persistent cookie jar for DDoS Guard support
+// NewClient creates a new API client with a persistent per-host cookie jar
+// for bot protection (DDoS-Guard) support. The warmup URL is the built-in
+// default for the host; use NewClientForHost to apply the configured one.
 func NewClient(baseURL, token string) *Client {
+	hostname := config.HostnameFromBaseURL(baseURL)
+	return newClient(baseURL, token, hostname, config.DefaultWarmupURL(hostname))
+}
+
+// NewClientForHost creates an API client for a configured host, using the
+// host's warmup_url setting from cfg
+func NewClientForHost(cfg *config.Config, hostname, token string) *Client {
+	return newClient(config.BaseURL(hostname), token, hostname, cfg.WarmupURL(hostname))
+}
+
+func newClient(baseURL, token, hostname, warmupURL string) *Client {
 	// Try to use persistent cookie store
-	store, err := cookies.NewStore()
 	var jar http.CookieJar
+	store, err := cookies.NewStore(hostname)
 	if err == nil && store != nil {
 		jar = store.Jar()
 	} else {
 		// Fallback to in-memory jar
+		store = nil
 		jar, _ = cookiejar.New(nil)
 	}
 
 	client := &Client{
 		BaseURL:     baseURL,
 		Token:       token,
+		Hostname:    hostname,
 		cookieStore: store,
+		warmupURL:   warmupURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 			Jar:     jar,
 		},
 	}
 
-	// If we loaded cookies from disk, mark as ready
-	if store != nil {
-		u, _ := url.Parse("https://gitflic.ru")
-		if len(jar.Cookies(u)) > 0 {
-			client.cookiesReady.Store(true)
-		}
+	// Nothing to warm up for unprotected hosts; otherwise reuse
+	// unexpired cookies loaded from disk
+	if warmupURL == "" || (store != nil && store.HasValid()) {
+		client.cookiesReady.Store(true)
 	}
 
 	return client
 }
 
-// warmupCookies visits the main GitFlic site to obtain DDoS Guard cookies.
-// This is required because api.gitflic.ru is protected by DDoS Guard which
-// blocks requests without valid __ddg* cookies.
+// SetWarmupURL overrides the URL visited to obtain bot-protection cookies.
+// An empty URL disables warmup until the host blocks a request.
+func (c *Client) SetWarmupURL(warmupURL string) {
+	c.cookiesMu.Lock()
+	defer c.cookiesMu.Unlock()
+	c.warmupURL = warmupURL
+	if warmupURL != "" && (c.cookieStore == nil || !c.cookieStore.HasValid()) {
+		c.cookiesReady.Store(false)
+	}
+}
+
+// CookieStore returns the persistent cookie store, or nil if cookies
+// are kept in memory only
+func (c *Client) CookieStore() *cookies.Store {
+	return c.cookieStore
+}
+
+// warmupCookies visits the host's warmup URL to obtain bot-protection cookies.
+// This is required because api.gitflic.ru (and some self-hosted instances) are
+// protected by DDoS-Guard, which blocks requests without valid __ddg* cookies.
 // Uses double-checked locking pattern for performance.
 func (c *Client) warmupCookies(ctx context.Context) error {
 	// Fast path: check without lock
@@ -89,11 +123,9 @@ func (c *Client) warmupCookies(ctx context.Context) error {
 		return nil
 	}
 
-	// Extract hostname from BaseURL to visit the main site
-	// api.gitflic.ru -> gitflic.ru
-	mainSiteURL := c.getMainSiteURL()
+	mainSiteURL := c.warmupURL
 	if mainSiteURL == "" {
-		c.cookiesReady.Store(true) // Skip for non-gitflic hosts
+		c.cookiesReady.Store(true) // Host is not protected
 		return nil
 	}
 
@@ -114,44 +146,37 @@ func (c *Client) warmupCookies(ctx context.Context) error {
 
 	// Save cookies to disk for future sessions
 	if c.cookieStore != nil {
-		c.cookieStore.MarkModified()
 		c.cookieStore.Save()
 	}
 
 	if os.Getenv("GF_DEBUG") != "" {
-		fmt.Fprintf(os.Stderr, "[DEBUG] Warmed up DDoS Guard cookies from %s\n", mainSiteURL)
+		fmt.Fprintf(os.Stderr, "[DEBUG] Warmed up protection cookies from %s\n", mainSiteURL)
 	}
 
 	return nil
 }
 
-// getMainSiteURL returns the main site URL for cookie warmup
-func (c *Client) getMainSiteURL() string {
+// siteRootURL returns the root URL of the API host (https://host/),
+// used as warmup URL for hosts that turn out to be protected
+func (c *Client) siteRootURL() string {
 	parsed, err := url.Parse(c.BaseURL)
-	if err != nil {
-		return ""
-	}
-
-	host := parsed.Host
-	// api.gitflic.ru -> gitflic.ru
-	if strings.HasPrefix(host, "api.") {
-		host = strings.TrimPrefix(host, "api.")
-	}
-
-	// Only warmup for gitflic.ru (cloud version has DDoS Guard)
-	if !strings.Contains(host, "gitflic.ru") {
+	if err != nil || parsed.Host == "" {
 		return ""
 	}
-
-	return "https://" + host + "/"
+	return parsed.Scheme + "://" + parsed.Host + "/"
 }
 
-// resetCookies clears cookie state to force re-warmup on next request
+// resetCookies clears cookie state to force re-warmup on next request.
+// Hosts without a configured warmup URL fall back to their site root.
 func (c *Client) resetCookies() {
 	c.cookiesMu.Lock()
 	defer c.cookiesMu.Unlock()
 	c.cookiesReady.Store(false)
 
+	if c.warmupURL == "" {
+		c.warmupURL = c.siteRootURL()
+	}
+
 	// Clear persistent store if available
 	if c.cookieStore != nil {
 		c.cookieStore.Clear()
@@ -182,6 +207,7 @@ func (c *Client) RESTWithContext(ctx context.Context, method, path string, body,
 
 	url := c.BaseURL + path
 	var lastErr error
+	blockRetried := false
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Wait before retry (skip on first attempt)
@@ -199,6 +225,13 @@ func (c *Client) RESTWithContext(ctx context.Context, method, path string, body,
 			return nil
 		}
 
+		// Retry once with fresh cookies after a bot-protection block
+		if errors.Is(err, ErrDDoSGuardBlock) && !blockRetried {
+			blockRetried = true
+			lastErr = err
+			continue
+		}
+
 		// Only retry on network errors, not HTTP errors
 		if !isNetworkError(err) {
 			return err
@@ -212,7 +245,7 @@ func (c *Client) RESTWithContext(ctx context.Context, method, path string, body,
 
 // doRequest performs a single HTTP request
 func (c *Client) doRequest(ctx context.Context, method, urlStr string, bodyData []byte, out any) error {
-	// Warmup cookies for DDoS Guard (only for gitflic.ru)
+	// Warmup cookies for DDoS Guard (only for protected hosts)
 	if err := c.warmupCookies(ctx); err != nil {
 		if os.Getenv("GF_DEBUG") != "" {
 			fmt.Fprintf(os.Stderr, "[DEBUG] Cookie warmup failed: %v\n", err)
@@ -296,9 +329,8 @@ func (c *Client) doRequest(ctx context.Context, method, urlStr string, bodyData
 		}
 	}
 
-	// Save cookies after successful request
+	// Save cookies after successful request (no-op if unchanged)
 	if c.cookieStore != nil {
-		c.cookieStore.MarkModified()
 		c.cookieStore.Save()
 	}
 
@@ -262,3 +262,42 @@ func TestClient_Services(t *testing.T) {
 		t.Error("Users() returned nil")
 	}
 }
+
+func TestClient_DDoSGuardBlock_WarmsUpAndRetries(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	var warmups, calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			atomic.AddInt32(&warmups, 1)
+			http.SetCookie(w, &http.Cookie{Name: "__ddg1_", Value: "ok", Path: "/", Expires: time.Now().Add(time.Hour)})
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		if _, err := r.Cookie("__ddg1_"); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<html><body>Checking your browser</body></html>"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	// Unknown host: no warmup URL until the first block
+	client := NewClient(server.URL, "test-token")
+
+	var result map[string]string
+	if err := client.Get("/test", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "ok" {
+		t.Errorf("status = %q, want ok", result["status"])
+	}
+	if warmups != 1 || calls != 2 {
+		t.Errorf("warmups = %d, calls = %d, want 1 and 2", warmups, calls)
+	}
+	if store := client.CookieStore(); store == nil || !store.HasValid() {
+		t.Error("protection cookies were not stored")
+	}
+}
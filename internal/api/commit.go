@@ -1,9 +1,12 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"time"
+
+	"github.com/josinSbazin/gf/internal/diff"
 )
 
 // CommitService handles commit API calls
@@ -107,6 +110,22 @@ type CommitDiffResponse struct {
 	Diffs []CommitDiff `json:"diffs"`
 }
 
+// UnmarshalJSON decodes the diffs and counts added and removed lines from
+// the diff content, since not every server fills in the counts
+func (r *CommitDiffResponse) UnmarshalJSON(data []byte) error {
+	type plain CommitDiffResponse
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	for i := range r.Diffs {
+		d := &r.Diffs[i]
+		if d.Additions == 0 && d.Deletions == 0 && d.DiffContent != "" {
+			d.Additions, d.Deletions = diff.CountLines(d.DiffContent)
+		}
+	}
+	return nil
+}
+
 // Diff returns the diff for a commit
 func (s *CommitService) Diff(owner, project, hash string) ([]CommitDiff, error) {
 	path := fmt.Sprintf("/project/%s/%s/commit/%s/diff",
@@ -25,6 +25,8 @@ type Issue struct {
 	Description string      `json:"description"`
 	Status      IssueStatus `json:"status"`
 	Author      User        `json:"updatedBy"` // GitFlic uses updatedBy for author in responses
+	Assignees   []User      `json:"assignedUsers"`
+	Labels      []Label     `json:"labels"`
 	CreatedAt   FlexTime    `json:"createdAt"`
 	UpdatedAt   FlexTime    `json:"updatedAt"`
 }
@@ -70,20 +72,27 @@ type CreateIssueRequest struct {
 
 // List returns issues for a project
 func (s *IssueService) List(owner, project string, opts *IssueListOptions) ([]Issue, error) {
+	issues, _, err := s.ListPage(owner, project, opts)
+	return issues, err
+}
+
+// ListPage returns one page of issues like List, and whether the server
+// has more pages. The state filter may be applied on the client and drop
+// issues from the page, so callers must page on more, not on its length.
+func (s *IssueService) ListPage(owner, project string, opts *IssueListOptions) (issues []Issue, more bool, err error) {
 	path := fmt.Sprintf("/project/%s/%s/issue", owner, project)
 
+	page, size := 0, 100
 	params := url.Values{}
-	params.Set("page", "0")
-	params.Set("size", "100")
 
 	filterState := ""
 	if opts != nil {
 		filterState = opts.State
 		if opts.Page > 0 {
-			params.Set("page", fmt.Sprintf("%d", opts.Page))
+			page = opts.Page
 		}
 		if opts.PerPage > 0 {
-			params.Set("size", fmt.Sprintf("%d", opts.PerPage))
+			size = opts.PerPage
 		}
 		// API may support status filter
 		switch opts.State {
@@ -94,14 +103,21 @@ func (s *IssueService) List(owner, project string, opts *IssueListOptions) ([]Is
 		}
 	}
 
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("size", fmt.Sprintf("%d", size))
 	path += "?" + params.Encode()
 
 	var resp IssueListResponse
 	if err := s.client.Get(path, &resp); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	issues := resp.Embedded.Issues
+	issues = resp.Embedded.Issues
+	if resp.Page.TotalPages > 0 {
+		more = page+1 < resp.Page.TotalPages
+	} else {
+		more = len(issues) == size
+	}
 
 	// Note: Server-side filtering is done via params.Set("status", ...)
 	// Client-side fallback only if API doesn't respect the filter
@@ -126,7 +142,7 @@ func (s *IssueService) List(owner, project string, opts *IssueListOptions) ([]Is
 		}
 	}
 
-	return issues, nil
+	return issues, more, nil
 }
 
 // Get returns a specific issue
@@ -178,20 +194,33 @@ func (s *IssueService) setStatus(owner, project string, localID int, statusID st
 	path := fmt.Sprintf("/project/%s/%s/issue/%d/edit", owner, project, localID)
 
 	// Build payload with existing data + new status
-	payload := map[string]interface{}{
+	payload := editPayload(issue)
+	payload["status"] = map[string]string{"id": statusID}
+
+	return s.client.Put(path, payload, nil)
+}
+
+// editPayload returns the body of an edit request that keeps the title,
+// description, status and assignees of issue
+func editPayload(issue *Issue) map[string]interface{} {
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, u := range issue.Assignees {
+		assignees = append(assignees, u.ID)
+	}
+	return map[string]interface{}{
 		"title":         issue.Title,
 		"description":   issue.Description,
-		"assignedUsers": []string{},
-		"status":        map[string]string{"id": statusID},
+		"assignedUsers": assignees,
+		"status":        map[string]string{"id": issue.Status.ID},
 	}
-
-	return s.client.Put(path, payload, nil)
 }
 
 // UpdateIssueRequest specifies parameters for updating an issue
 type UpdateIssueRequest struct {
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
+	Title         string    `json:"title,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	AssignedUsers *[]string `json:"assignedUsers,omitempty"` // user IDs; nil keeps the current assignees
+	Labels        *[]string `json:"labels,omitempty"`        // label IDs; nil keeps the current labels
 }
 
 // Update updates an issue
@@ -206,12 +235,7 @@ func (s *IssueService) Update(owner, project string, localID int, req *UpdateIss
 	path := fmt.Sprintf("/project/%s/%s/issue/%d/edit", owner, project, localID)
 
 	// Build payload with existing data, override with provided values
-	payload := map[string]interface{}{
-		"title":         existing.Title,
-		"description":   existing.Description,
-		"assignedUsers": []string{},
-		"status":        map[string]string{"id": existing.Status.ID},
-	}
+	payload := editPayload(existing)
 
 	if req.Title != "" {
 		payload["title"] = req.Title
@@ -219,6 +243,12 @@ func (s *IssueService) Update(owner, project string, localID int, req *UpdateIss
 	if req.Description != "" {
 		payload["description"] = req.Description
 	}
+	if req.AssignedUsers != nil {
+		payload["assignedUsers"] = *req.AssignedUsers
+	}
+	if req.Labels != nil {
+		payload["labels"] = *req.Labels
+	}
 
 	var issue Issue
 	if err := s.client.Put(path, payload, &issue); err != nil {
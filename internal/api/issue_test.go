@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestIssueService_EditKeepsAssignees(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"localId": 7, "title": "Bug", "description": "Details",
+				"status": {"id": "OPEN"},
+				"assignedUsers": [{"id": "u1", "username": "alice"}],
+				"labels": [{"id": "l1", "title": "bug"}]}`))
+		case http.MethodPut:
+			if r.URL.Path != "/project/owner/repo/issue/7/edit" {
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+			body, _ := io.ReadAll(r.Body)
+			var payload map[string]interface{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Errorf("bad payload %s", body)
+			}
+			payloads = append(payloads, payload)
+			w.Write([]byte(`{"localId": 7}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "test-token")
+
+	if err := client.Issues().Close("owner", "repo", 7); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	assignees := []string{"u1", "u2"}
+	labels := []string{}
+	if _, err := client.Issues().Update("owner", "repo", 7, &UpdateIssueRequest{AssignedUsers: &assignees, Labels: &labels}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := client.Issues().Update("owner", "repo", 7, &UpdateIssueRequest{Title: "New title"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if len(payloads) != 3 {
+		t.Fatalf("got %d edit requests, want 3", len(payloads))
+	}
+	tests := []struct {
+		name          string
+		payload       map[string]interface{}
+		wantStatus    string
+		wantAssignees []interface{}
+		wantLabels    interface{}
+	}{
+		{"close", payloads[0], "CLOSED", []interface{}{"u1"}, nil},
+		{"assign and clear labels", payloads[1], "OPEN", []interface{}{"u1", "u2"}, []interface{}{}},
+		{"title only", payloads[2], "OPEN", []interface{}{"u1"}, nil},
+	}
+	for _, tt := range tests {
+		status, _ := tt.payload["status"].(map[string]interface{})
+		if status["id"] != tt.wantStatus {
+			t.Errorf("%s: status = %v, want %s", tt.name, tt.payload["status"], tt.wantStatus)
+		}
+		if !reflect.DeepEqual(tt.payload["assignedUsers"], tt.wantAssignees) {
+			t.Errorf("%s: assignedUsers = %v, want %v", tt.name, tt.payload["assignedUsers"], tt.wantAssignees)
+		}
+		if !reflect.DeepEqual(tt.payload["labels"], tt.wantLabels) {
+			t.Errorf("%s: labels = %v, want %v", tt.name, tt.payload["labels"], tt.wantLabels)
+		}
+	}
+	if payloads[2]["title"] != "New title" || payloads[2]["description"] != "Details" {
+		t.Errorf("title only: payload = %v", payloads[2])
+	}
+}
+
+func TestIssueService_ListPage(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server ignores the status filter: page 0 is filtered on the
+		// client and comes back shorter than the page size
+		switch r.URL.Query().Get("page") {
+		case "0":
+			w.Write([]byte(`{"_embedded": {"issueModelList": [
+				{"localId": 1, "status": {"id": "CLOSED"}},
+				{"localId": 2, "status": {"id": "OPEN"}}
+			]}, "page": {"size": 2, "totalPages": 2, "number": 0}}`))
+		default:
+			w.Write([]byte(`{"_embedded": {"issueModelList": [
+				{"localId": 3, "status": {"id": "OPEN"}}
+			]}, "page": {"size": 2, "totalPages": 2, "number": 1}}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "test-token")
+
+	issues, more, err := client.Issues().ListPage("owner", "repo", &IssueListOptions{State: "open", PerPage: 2})
+	if err != nil || len(issues) != 1 || !more {
+		t.Errorf("ListPage(0) = %d issues, more %v, %v; want 1, true", len(issues), more, err)
+	}
+	issues, more, err = client.Issues().ListPage("owner", "repo", &IssueListOptions{State: "open", Page: 1, PerPage: 2})
+	if err != nil || len(issues) != 1 || more {
+		t.Errorf("ListPage(1) = %d issues, more %v, %v; want 1, false", len(issues), more, err)
+	}
+}
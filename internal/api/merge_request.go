@@ -3,9 +3,14 @@ package api
 import (
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
+// mrListMaxPages bounds how many pages List reads for client-side filters
+const mrListMaxPages = 50
+
 // MergeRequestService handles merge request API calls
 type MergeRequestService struct {
 	client *Client
@@ -41,6 +46,27 @@ type MergeRequest struct {
 	UpdatedAt    time.Time `json:"updatedAt"`
 	CanMerge     bool      `json:"canMerge"`
 	HasConflicts bool      `json:"hasConflicts"`
+	IsDraft      bool      `json:"workInProgress"`
+	Assignees    []User    `json:"assignedUsers"`
+	Reviewers    []User    `json:"reviewers"`
+	Labels       []Label   `json:"labels"`
+	// Source and target projects differ for merge requests from forks
+	SourceProject *Project `json:"sourceProject,omitempty"`
+	TargetProject *Project `json:"targetProject,omitempty"`
+}
+
+// IsCrossProject reports whether the source branch lives in another
+// project (a fork) than the target branch
+func (mr *MergeRequest) IsCrossProject() bool {
+	return mr.SourceProject != nil && mr.TargetProject != nil &&
+		mr.SourceProject.ID != "" && mr.SourceProject.ID != mr.TargetProject.ID
+}
+
+// Label represents a label attached to a merge request or issue
+type Label struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	HexColor string `json:"hexColor"`
 }
 
 // State returns normalized state string (open, merged, closed)
@@ -70,14 +96,92 @@ type MRListResponse struct {
 	} `json:"page"`
 }
 
-// MRListOptions specifies options for listing merge requests
+// MRListOptions specifies options for listing merge requests.
+// Filters the API does not support are applied client-side.
 type MRListOptions struct {
-	State        string // open, merged, closed, all
-	SourceBranch string
-	TargetBranch string
-	AuthorAlias  string
-	Page         int
-	PerPage      int
+	State         string // open, merged, closed, all
+	SourceBranch  string
+	TargetBranch  string
+	AuthorAlias   string
+	AssigneeAlias string
+	ReviewerAlias string
+	Labels        []string // all labels must be present
+	Draft         *bool    // nil = any
+	HasConflicts  bool     // only MRs with conflicts
+	Search        string   // case-insensitive match in title and description
+	Limit         int      // stop fetching pages once this many match (0 = no limit)
+	Page          int
+	PerPage       int
+}
+
+// Matches reports whether mr satisfies all filters in opts
+func (opts *MRListOptions) Matches(mr *MergeRequest) bool {
+	if opts == nil {
+		return true
+	}
+	switch opts.State {
+	case "open":
+		if mr.Status.ID == "MERGED" || mr.Status.ID == "CANCELED" || mr.Status.ID == "CLOSED" {
+			return false
+		}
+	case "merged", "closed":
+		if mr.State() != opts.State {
+			return false
+		}
+	}
+	if opts.SourceBranch != "" && mr.SourceBranch.Title != opts.SourceBranch {
+		return false
+	}
+	if opts.TargetBranch != "" && mr.TargetBranch.Title != opts.TargetBranch {
+		return false
+	}
+	if opts.AuthorAlias != "" && !strings.EqualFold(mr.Author.Username, opts.AuthorAlias) {
+		return false
+	}
+	if opts.AssigneeAlias != "" && !hasUser(mr.Assignees, opts.AssigneeAlias) {
+		return false
+	}
+	if opts.ReviewerAlias != "" && !hasUser(mr.Reviewers, opts.ReviewerAlias) {
+		return false
+	}
+	for _, label := range opts.Labels {
+		if !mr.HasLabel(label) {
+			return false
+		}
+	}
+	if opts.Draft != nil && mr.IsDraft != *opts.Draft {
+		return false
+	}
+	if opts.HasConflicts && !mr.HasConflicts {
+		return false
+	}
+	if opts.Search != "" {
+		query := strings.ToLower(opts.Search)
+		if !strings.Contains(strings.ToLower(mr.Title), query) &&
+			!strings.Contains(strings.ToLower(mr.Description), query) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasLabel reports whether the merge request has a label with the given title
+func (mr *MergeRequest) HasLabel(title string) bool {
+	for _, l := range mr.Labels {
+		if strings.EqualFold(l.Title, title) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUser(users []User, alias string) bool {
+	for _, u := range users {
+		if strings.EqualFold(u.Username, alias) {
+			return true
+		}
+	}
+	return false
 }
 
 // BranchRef is a reference to a branch for API requests
@@ -85,6 +189,11 @@ type BranchRef struct {
 	ID string `json:"id"`
 }
 
+// LabelRef is a reference to a label for API requests
+type LabelRef struct {
+	ID string `json:"id"`
+}
+
 // ProjectRef is a reference to a project for API requests
 type ProjectRef struct {
 	ID string `json:"id"`
@@ -101,56 +210,108 @@ type CreateMRRequest struct {
 	RemoveSourceBranch bool       `json:"removeSourceBranch,omitempty"`
 	IsDraft            bool       `json:"workInProgress,omitempty"`
 	SquashCommit       bool       `json:"squashCommit,omitempty"`
+	Reviewers          []UserRef  `json:"reviewers,omitempty"`
+	AssignedUsers      []UserRef  `json:"assignedUsers,omitempty"`
+	Labels             []LabelRef `json:"labels,omitempty"`
 }
 
+// Merge methods
+const (
+	MergeMethodMerge  = "MERGE"
+	MergeMethodSquash = "SQUASH"
+	MergeMethodRebase = "REBASE"
+)
+
 // MergeMRRequest specifies the parameters for merging a merge request
 type MergeMRRequest struct {
-	SquashCommit       bool   `json:"squashCommit,omitempty"`
-	RemoveSourceBranch bool   `json:"removeSourceBranch,omitempty"`
-	MergeCommitMessage string `json:"mergeCommitMessage,omitempty"`
+	SquashCommit        bool   `json:"squashCommit,omitempty"`
+	RemoveSourceBranch  bool   `json:"removeSourceBranch,omitempty"`
+	MergeCommitMessage  string `json:"mergeCommitMessage,omitempty"`
+	SquashCommitMessage string `json:"squashCommitMessage,omitempty"`
+	// MergeMethod is one of the MergeMethod* constants; empty uses the
+	// project default
+	MergeMethod string `json:"mergeMethod,omitempty"`
+}
+
+// List returns merge requests for a project.
+// Unless a specific page is requested, pages are fetched until opts.Limit
+// merge requests match or mrListMaxPages pages were read, so that
+// client-side filters see more than the first page.
+func (s *MergeRequestService) List(owner, project string, opts *MRListOptions) ([]MergeRequest, error) {
+	mrs, _, err := s.ListTruncated(owner, project, opts)
+	return mrs, err
 }
 
-// List returns merge requests for a project
-func (s *MergeRequestService) List(owner, project string, opts *MRListOptions) ([]MergeRequest, error) {
+// ListTruncated is List that also reports whether paging stopped at
+// mrListMaxPages while the server had more pages, so the result may miss
+// matching merge requests.
+func (s *MergeRequestService) ListTruncated(owner, project string, opts *MRListOptions) (mrs []MergeRequest, truncated bool, err error) {
 	path := fmt.Sprintf("/project/%s/%s/merge-request/list", owner, project)
 
-	filterState := ""
+	params := url.Values{}
+	allPages := true
 	if opts != nil {
-		filterState = opts.State
-
 		// API supports: MERGED, CANCELED (not OPEN)
 		// For "open" we fetch all and filter client-side
-		params := url.Values{}
 		switch opts.State {
 		case "merged":
 			params.Set("status", "MERGED")
 		case "closed":
 			params.Set("status", "CANCELED")
 		}
-		if q := params.Encode(); q != "" {
-			path += "?" + q
+		if opts.SourceBranch != "" {
+			params.Set("sourceBranch", opts.SourceBranch)
+		}
+		if opts.TargetBranch != "" {
+			params.Set("targetBranch", opts.TargetBranch)
+		}
+		if opts.Page > 0 {
+			params.Set("page", fmt.Sprintf("%d", opts.Page))
+			allPages = false
+		}
+		if opts.PerPage > 0 {
+			params.Set("size", fmt.Sprintf("%d", opts.PerPage))
+			allPages = false
 		}
 	}
 
-	var resp MRListResponse
-	if err := s.client.Get(path, &resp); err != nil {
-		return nil, err
-	}
+	for page := 0; ; page++ {
+		if page > 0 {
+			params.Set("page", fmt.Sprintf("%d", page))
+		}
+		pagePath := path
+		if q := params.Encode(); q != "" {
+			pagePath += "?" + q
+		}
 
-	mrs := resp.Embedded.MergeRequests
+		var resp MRListResponse
+		if err := s.client.Get(pagePath, &resp); err != nil {
+			return nil, false, err
+		}
 
-	// Client-side filter for "open" (API doesn't support this filter)
-	if filterState == "open" {
-		filtered := make([]MergeRequest, 0)
-		for _, mr := range mrs {
-			if mr.Status.ID != "MERGED" && mr.Status.ID != "CANCELED" && mr.Status.ID != "CLOSED" {
-				filtered = append(filtered, mr)
+		for _, mr := range resp.Embedded.MergeRequests {
+			if opts.Matches(&mr) {
+				mrs = append(mrs, mr)
 			}
 		}
-		mrs = filtered
+		if opts != nil && opts.Limit > 0 && len(mrs) >= opts.Limit {
+			mrs = mrs[:opts.Limit]
+			break
+		}
+
+		if !allPages || page+1 >= resp.Page.TotalPages || len(resp.Embedded.MergeRequests) == 0 {
+			break
+		}
+		if page+1 >= mrListMaxPages {
+			truncated = true
+			break
+		}
 	}
 
-	return mrs, nil
+	if mrs == nil {
+		mrs = []MergeRequest{}
+	}
+	return mrs, truncated, nil
 }
 
 // Get returns a specific merge request
@@ -181,23 +342,155 @@ func (s *MergeRequestService) Merge(owner, project string, localID int, req *Mer
 	return s.client.Post(path, req, nil)
 }
 
+// Diff returns the changes of a merge request, one entry per file
+func (s *MergeRequestService) Diff(owner, project string, localID int) ([]CommitDiff, error) {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/diff", owner, project, localID)
+
+	var resp CommitDiffResponse
+	if err := s.client.Get(path, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Diffs, nil
+}
+
+// Files returns the files changed by a merge request with their change
+// type and line counts, without the diff content
+func (s *MergeRequestService) Files(owner, project string, localID int) ([]CommitDiff, error) {
+	diffs, err := s.Diff(owner, project, localID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range diffs {
+		diffs[i].DiffContent = ""
+	}
+	return diffs, nil
+}
+
+// Commits returns the commits of a merge request, oldest first
+func (s *MergeRequestService) Commits(owner, project string, localID int) ([]CommitDetail, error) {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/commits", owner, project, localID)
+
+	var resp CommitListResponse
+	if err := s.client.Get(path, &resp); err != nil {
+		return nil, err
+	}
+	commits := resp.Embedded.Commits
+	// The API lists newest first like the commit log
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].CreatedAt.Before(commits[j].CreatedAt) })
+	return commits, nil
+}
+
 // Approve approves a merge request
 func (s *MergeRequestService) Approve(owner, project string, localID int) error {
 	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/approve", owner, project, localID)
 	return s.client.Post(path, nil, nil)
 }
 
+// Unapprove withdraws the approval of the authenticated user
+func (s *MergeRequestService) Unapprove(owner, project string, localID int) error {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/unapprove", owner, project, localID)
+	return s.client.Post(path, nil, nil)
+}
+
+// Approval is an approval given to a merge request
+type Approval struct {
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MRApprovals is the approval state of a merge request
+type MRApprovals struct {
+	Required  int        `json:"approvalsRequired"`
+	Approvals []Approval `json:"approvals"`
+	// Approved is set by the server once the approval rules are met
+	Approved bool `json:"approved"`
+}
+
+// Left returns how many more approvals are required
+func (a *MRApprovals) Left() int {
+	return max(0, a.Required-len(a.Approvals))
+}
+
+// Satisfied reports whether no more approvals are required
+func (a *MRApprovals) Satisfied() bool {
+	return a.Approved || a.Left() == 0
+}
+
+// ApprovedBy reports whether user has approved
+func (a *MRApprovals) ApprovedBy(user *User) bool {
+	for _, approval := range a.Approvals {
+		if (user.ID != "" && approval.User.ID == user.ID) ||
+			(user.Username != "" && strings.EqualFold(approval.User.Username, user.Username)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns a short approval status, e.g. "✓ 2/2 approved",
+// "1/2 approved" or "no approvals"
+func (a *MRApprovals) Summary() string {
+	icon := ""
+	if a.Satisfied() && (a.Required > 0 || len(a.Approvals) > 0) {
+		icon = "✓ "
+	}
+	switch {
+	case a.Required > 0:
+		return fmt.Sprintf("%s%d/%d approved", icon, len(a.Approvals), a.Required)
+	case len(a.Approvals) > 0:
+		return fmt.Sprintf("%s%d approval(s)", icon, len(a.Approvals))
+	default:
+		return "no approvals"
+	}
+}
+
+// Approvals returns who approved a merge request and how many approvals
+// are required
+func (s *MergeRequestService) Approvals(owner, project string, localID int) (*MRApprovals, error) {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/approvals", owner, project, localID)
+
+	var approvals MRApprovals
+	if err := s.client.Get(path, &approvals); err != nil {
+		return nil, err
+	}
+	return &approvals, nil
+}
+
+// MissingApprovals returns why mr cannot be merged for lack of approvals,
+// or "" once it has enough. Without the approvals API, GitFlic's canMerge
+// flag is used instead; it does not say why merging is blocked.
+func (s *MergeRequestService) MissingApprovals(owner, project string, mr *MergeRequest) (string, error) {
+	approvals, err := s.Approvals(owner, project, mr.LocalID)
+	switch {
+	case IsNotFound(err):
+		if mr.CanMerge {
+			return "", nil
+		}
+		return "GitFlic reports the MR cannot be merged yet", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to check approvals: %w", err)
+	case approvals.Satisfied():
+		return "", nil
+	}
+	return fmt.Sprintf("%d more approval(s) required (%s)", approvals.Left(), approvals.Summary()), nil
+}
+
 // Close closes a merge request without merging
 func (s *MergeRequestService) Close(owner, project string, localID int) error {
 	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/close", owner, project, localID)
 	return s.client.Post(path, nil, nil)
 }
 
-// UpdateMRRequest specifies the parameters for updating a merge request
+// UpdateMRRequest specifies the parameters for updating a merge request.
+// Nil lists are left unchanged; a non-nil empty list clears them.
 type UpdateMRRequest struct {
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	IsDraft     *bool  `json:"workInProgress,omitempty"`
+	Title         string      `json:"title,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	IsDraft       *bool       `json:"workInProgress,omitempty"`
+	TargetBranch  *BranchRef  `json:"targetBranch,omitempty"`
+	Reviewers     *[]UserRef  `json:"reviewers,omitempty"`
+	AssignedUsers *[]UserRef  `json:"assignedUsers,omitempty"`
+	Labels        *[]LabelRef `json:"labels,omitempty"`
 }
 
 // Update updates a merge request
@@ -239,6 +532,17 @@ type DiscussionThread struct {
 	Replies  []DiscussionNote `json:"replies"`
 }
 
+// Resolvable reports whether the thread can be resolved: inline comments
+// on the diff and review threads. General comments cannot be resolved and
+// do not block merging.
+func (t *DiscussionThread) Resolvable() bool {
+	root := t.RootNote
+	if (root.NewPath != nil && *root.NewPath != "") || (root.OldPath != nil && *root.OldPath != "") {
+		return true
+	}
+	return strings.Contains(strings.ToLower(root.Type), "review")
+}
+
 // MRDiscussion represents a discussion on a merge request (legacy flat model)
 type MRDiscussion struct {
 	ID        string    `json:"id"`
@@ -338,3 +642,69 @@ func (s *MergeRequestService) ResolveDiscussion(owner, project string, localID i
 	}
 	return &note, nil
 }
+
+// UnresolveDiscussion reopens a resolved discussion thread
+func (s *MergeRequestService) UnresolveDiscussion(owner, project string, localID int, uuid string) (*DiscussionNote, error) {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/discussions/unresolve/%s", owner, project, localID, uuid)
+
+	var note DiscussionNote
+	if err := s.client.Post(path, nil, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// EditNoteRequest specifies the new text of a discussion note
+type EditNoteRequest struct {
+	Message string `json:"message"`
+}
+
+// EditNote changes the text of a discussion note (root note or reply)
+func (s *MergeRequestService) EditNote(owner, project string, localID int, uuid, message string) (*DiscussionNote, error) {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/discussions/%s", owner, project, localID, uuid)
+
+	var note DiscussionNote
+	if err := s.client.Put(path, &EditNoteRequest{Message: message}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteNote deletes a discussion note; deleting a root note removes the
+// whole thread
+func (s *MergeRequestService) DeleteNote(owner, project string, localID int, uuid string) error {
+	path := fmt.Sprintf("/project/%s/%s/merge-request/%d/discussions/%s", owner, project, localID, uuid)
+	return s.client.Delete(path)
+}
+
+// FindNote returns the note whose UUID equals or starts with uuid (as
+// printed by 'gf mr comments') together with its thread
+func FindNote(threads []DiscussionThread, uuid string) (*DiscussionNote, *DiscussionThread, error) {
+	var note *DiscussionNote
+	var thread *DiscussionThread
+	matches := 0
+	for i := range threads {
+		t := &threads[i]
+		notes := []*DiscussionNote{&t.RootNote}
+		for j := range t.Replies {
+			notes = append(notes, &t.Replies[j])
+		}
+		for _, n := range notes {
+			if n.UUID == uuid {
+				return n, t, nil
+			}
+			if uuid != "" && strings.HasPrefix(n.UUID, uuid) {
+				note, thread = n, t
+				matches++
+			}
+		}
+	}
+	switch matches {
+	case 0:
+		return nil, nil, fmt.Errorf("comment %s not found", uuid)
+	case 1:
+		return note, thread, nil
+	default:
+		return nil, nil, fmt.Errorf("comment ID %s is ambiguous; use more characters", uuid)
+	}
+}
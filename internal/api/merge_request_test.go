@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -225,3 +227,350 @@ func TestMergeRequest_JSONParsing(t *testing.T) {
 		t.Errorf("State() = %q, want merged", mr.State())
 	}
 }
+
+func TestMergeRequestService_List_AllPages(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Write([]byte(`{"_embedded":{"mergeRequestModelList":[
+				{"localId":3,"title":"MR 3","status":{"id":"OPEN"}},
+				{"localId":2,"title":"MR 2","status":{"id":"MERGED"}}
+			]},"page":{"totalPages":2,"number":0}}`))
+		case "1":
+			w.Write([]byte(`{"_embedded":{"mergeRequestModelList":[
+				{"localId":1,"title":"MR 1","status":{"id":"OPEN"}}
+			]},"page":{"totalPages":2,"number":1}}`))
+		default:
+			t.Errorf("unexpected page request: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	mrs, err := client.MergeRequests().List("owner", "repo", &MRListOptions{State: "open"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Errorf("made %d requests, want 2", len(requests))
+	}
+	if len(mrs) != 2 || mrs[0].LocalID != 3 || mrs[1].LocalID != 1 {
+		t.Errorf("got %+v, want open MRs #3 and #1", mrs)
+	}
+}
+
+func TestMergeRequestService_List_Limit(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json")
+		// Every page has one open and one merged merge request
+		w.Write([]byte(`{"_embedded":{"mergeRequestModelList":[
+			{"localId":1,"status":{"id":"OPEN"}},
+			{"localId":2,"status":{"id":"MERGED"}}
+		]},"page":{"totalPages":1000}}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "test-token")
+
+	mrs, err := client.MergeRequests().List("owner", "repo", &MRListOptions{State: "open", Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mrs) != 3 || len(pages) != 3 {
+		t.Errorf("got %d MRs from %d pages, want 3 from 3", len(mrs), len(pages))
+	}
+
+	// Without a limit, paging stops at mrListMaxPages and says so
+	pages = nil
+	mrs, truncated, err := client.MergeRequests().ListTruncated("owner", "repo", &MRListOptions{State: "open"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != mrListMaxPages || len(mrs) != mrListMaxPages || !truncated {
+		t.Errorf("got %d MRs from %d pages, truncated %v; want %d from %d, truncated",
+			len(mrs), len(pages), truncated, mrListMaxPages, mrListMaxPages)
+	}
+}
+
+func TestMRListOptions_Matches(t *testing.T) {
+	mr := &MergeRequest{
+		Title:        "Fix login redirect",
+		Description:  "Users were sent to /home",
+		SourceBranch: Branch{Title: "fix/login"},
+		TargetBranch: Branch{Title: "main"},
+		Status:       Status{ID: "OPEN"},
+		Author:       User{Username: "alice"},
+		Assignees:    []User{{Username: "bob"}},
+		Reviewers:    []User{{Username: "carol"}},
+		Labels:       []Label{{Title: "bug"}, {Title: "auth"}},
+		IsDraft:      true,
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name string
+		opts *MRListOptions
+		want bool
+	}{
+		{"nil options", nil, true},
+		{"state open", &MRListOptions{State: "open"}, true},
+		{"state merged", &MRListOptions{State: "merged"}, false},
+		{"source", &MRListOptions{SourceBranch: "fix/login"}, true},
+		{"other target", &MRListOptions{TargetBranch: "develop"}, false},
+		{"author case-insensitive", &MRListOptions{AuthorAlias: "Alice"}, true},
+		{"assignee", &MRListOptions{AssigneeAlias: "bob"}, true},
+		{"reviewer mismatch", &MRListOptions{ReviewerAlias: "bob"}, false},
+		{"all labels", &MRListOptions{Labels: []string{"bug", "AUTH"}}, true},
+		{"missing label", &MRListOptions{Labels: []string{"bug", "ui"}}, false},
+		{"draft", &MRListOptions{Draft: &yes}, true},
+		{"no draft", &MRListOptions{Draft: &no}, false},
+		{"conflicts", &MRListOptions{HasConflicts: true}, false},
+		{"search description", &MRListOptions{Search: "/HOME"}, true},
+		{"search miss", &MRListOptions{Search: "logout"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Matches(mr); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateMRRequest_JSON(t *testing.T) {
+	empty := []UserRef{}
+	labels := []LabelRef{{ID: "l1"}}
+	req := &UpdateMRRequest{Reviewers: &empty, Labels: &labels}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	// Nil lists are omitted, empty lists clear the field
+	want := `{"reviewers":[],"labels":[{"id":"l1"}]}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(&UpdateMRRequest{TargetBranch: &BranchRef{ID: "release"}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"targetBranch":{"id":"release"}}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
+
+func TestMergeRequest_PeopleParsing(t *testing.T) {
+	response := `{
+		"localId": 7,
+		"workInProgress": true,
+		"assignedUsers": [{"id": "u1", "username": "alice"}],
+		"reviewers": [{"id": "u2", "username": "bob"}],
+		"labels": [{"id": "l1", "title": "bug", "hexColor": "#ff0000"}],
+		"sourceProject": {"id": "p2", "alias": "app", "owner": {"alias": "alice"}},
+		"targetProject": {"id": "p1", "alias": "app", "owner": {"alias": "team"}}
+	}`
+
+	var mr MergeRequest
+	if err := json.Unmarshal([]byte(response), &mr); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if !mr.IsDraft {
+		t.Error("IsDraft = false, want true")
+	}
+	if len(mr.Assignees) != 1 || mr.Assignees[0].Username != "alice" {
+		t.Errorf("Assignees = %+v", mr.Assignees)
+	}
+	if len(mr.Reviewers) != 1 || mr.Reviewers[0].Username != "bob" {
+		t.Errorf("Reviewers = %+v", mr.Reviewers)
+	}
+	if !mr.HasLabel("BUG") {
+		t.Errorf("HasLabel(BUG) = false, labels = %+v", mr.Labels)
+	}
+	if !mr.IsCrossProject() {
+		t.Error("IsCrossProject() = false, want true")
+	}
+}
+
+func TestMergeRequestService_DiffAndCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/project/owner/repo/merge-request/5/diff":
+			w.Write([]byte(`{"diffs": [
+				{"filePath": "main.go", "changeType": "MODIFY", "additions": 3, "deletions": 1, "diffContent": "@@ -1 +1 @@"},
+				{"filePath": "new.go", "changeType": "MODIFY", "diffContent": "--- a/new.go\n+++ b/new.go\n@@ -1,2 +1,3 @@\n-a\n+b\n+c\n d"}
+			]}`))
+		case "/project/owner/repo/merge-request/5/commits":
+			w.Write([]byte(`{"_embedded": {"commitList": [
+				{"hash": "bbb", "createdAt": "2024-01-15T11:00:00Z"},
+				{"hash": "aaa", "createdAt": "2024-01-15T10:00:00Z"}
+			]}}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	diffs, err := client.MergeRequests().Diff("owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("Diff() error: %v", err)
+	}
+	if len(diffs) != 2 || diffs[0].FilePath != "main.go" || diffs[0].Additions != 3 {
+		t.Errorf("Diff() = %+v", diffs)
+	}
+	// Counted from the diff content when the server leaves them out
+	if diffs[1].Additions != 2 || diffs[1].Deletions != 1 || diffs[1].DiffContent == "" {
+		t.Errorf("Diff()[1] = %+v, want +2 -1 with content", diffs[1])
+	}
+
+	files, err := client.MergeRequests().Files("owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("Files() error: %v", err)
+	}
+	if len(files) != 2 || files[0].DiffContent != "" || files[0].Additions != 3 {
+		t.Errorf("Files() = %+v", files)
+	}
+	// Counted from the diff when the server leaves them out
+	if files[1].Additions != 2 || files[1].Deletions != 1 {
+		t.Errorf("Files()[1] counts = +%d -%d, want +2 -1", files[1].Additions, files[1].Deletions)
+	}
+
+	commits, err := client.MergeRequests().Commits("owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("Commits() error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Hash != "aaa" || commits[1].Hash != "bbb" {
+		t.Errorf("Commits() = %+v, want oldest first", commits)
+	}
+}
+
+func TestMergeRequestService_EditDeleteUnresolveNote(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPut {
+			var req EditNoteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Message != "fixed" {
+				t.Errorf("message = %q, want fixed", req.Message)
+			}
+		}
+		w.Write([]byte(`{"uuid": "abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	mrs := client.MergeRequests()
+
+	if _, err := mrs.EditNote("owner", "repo", 5, "abc", "fixed"); err != nil {
+		t.Fatalf("EditNote() error: %v", err)
+	}
+	if err := mrs.DeleteNote("owner", "repo", 5, "abc"); err != nil {
+		t.Fatalf("DeleteNote() error: %v", err)
+	}
+	if _, err := mrs.UnresolveDiscussion("owner", "repo", 5, "abc"); err != nil {
+		t.Fatalf("UnresolveDiscussion() error: %v", err)
+	}
+
+	want := []string{
+		"PUT /project/owner/repo/merge-request/5/discussions/abc",
+		"DELETE /project/owner/repo/merge-request/5/discussions/abc",
+		"POST /project/owner/repo/merge-request/5/discussions/unresolve/abc",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestFindNote(t *testing.T) {
+	threads := []DiscussionThread{
+		{RootNote: DiscussionNote{UUID: "aaaa1111"}, Replies: []DiscussionNote{{UUID: "aaaa2222"}}},
+		{RootNote: DiscussionNote{UUID: "bbbb1111"}},
+	}
+
+	note, thread, err := FindNote(threads, "aaaa22")
+	if err != nil || note.UUID != "aaaa2222" || thread.RootNote.UUID != "aaaa1111" {
+		t.Errorf("FindNote(reply prefix) = %v, %v, %v", note, thread, err)
+	}
+	if note, _, err := FindNote(threads, "bbbb1111"); err != nil || note.UUID != "bbbb1111" {
+		t.Errorf("FindNote(exact) = %v, %v", note, err)
+	}
+	if _, _, err := FindNote(threads, "aaaa"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("FindNote(ambiguous) err = %v", err)
+	}
+	if _, _, err := FindNote(threads, "cccc"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("FindNote(missing) err = %v", err)
+	}
+}
+
+func TestMergeRequestService_Approvals(t *testing.T) {
+	var unapproved bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/project/owner/repo/merge-request/5/approvals":
+			w.Write([]byte(`{"approvalsRequired": 2, "approved": false, "approvals": [
+				{"user": {"id": "u1", "username": "alice"}, "createdAt": "2024-01-15T10:00:00Z"}
+			]}`))
+		case "/project/owner/repo/merge-request/5/unapprove":
+			unapproved = r.Method == http.MethodPost
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	a, err := client.MergeRequests().Approvals("owner", "repo", 5)
+	if err != nil {
+		t.Fatalf("Approvals() error: %v", err)
+	}
+	if a.Required != 2 || len(a.Approvals) != 1 || a.Left() != 1 || a.Satisfied() {
+		t.Errorf("Approvals() = %+v", a)
+	}
+	if !a.ApprovedBy(&User{Username: "Alice"}) || a.ApprovedBy(&User{ID: "u2", Username: "bob"}) {
+		t.Error("ApprovedBy() mismatch")
+	}
+	missing, err := client.MergeRequests().MissingApprovals("owner", "repo", &MergeRequest{LocalID: 5, CanMerge: true})
+	if err != nil || missing != "1 more approval(s) required (1/2 approved)" {
+		t.Errorf("MissingApprovals() = %q, %v", missing, err)
+	}
+
+	if err := client.MergeRequests().Unapprove("owner", "repo", 5); err != nil || !unapproved {
+		t.Errorf("Unapprove() = %v, posted %v", err, unapproved)
+	}
+}
+
+func TestMRApprovals_Summary(t *testing.T) {
+	one := []Approval{{User: User{Username: "alice"}}}
+	tests := []struct {
+		approvals MRApprovals
+		want      string
+	}{
+		{MRApprovals{Required: 2, Approvals: one}, "1/2 approved"},
+		{MRApprovals{Required: 1, Approvals: one}, "✓ 1/1 approved"},
+		{MRApprovals{Approvals: one}, "✓ 1 approval(s)"},
+		{MRApprovals{}, "no approvals"},
+		// The server may accept fewer approvals (e.g. code owners rules)
+		{MRApprovals{Required: 2, Approvals: one, Approved: true}, "✓ 1/2 approved"},
+	}
+	for _, tt := range tests {
+		if got := tt.approvals.Summary(); got != tt.want {
+			t.Errorf("Summary(%+v) = %q, want %q", tt.approvals, got, tt.want)
+		}
+	}
+}
@@ -14,6 +14,8 @@ import (
 const (
 	pipelineSearchMaxPages = 5
 	pipelineSearchPageSize = 50
+	// pipelineWatchCallTimeout limits each request made by Watch
+	pipelineWatchCallTimeout = 30 * time.Second
 )
 
 // FlexTime handles time parsing with or without timezone
@@ -90,6 +92,31 @@ func (p *Pipeline) NormalizedStatus() string {
 	return strings.ToLower(p.Status)
 }
 
+// IsFinished reports whether the pipeline reached a final status
+func (p *Pipeline) IsFinished() bool {
+	return PipelineFinished(p.NormalizedStatus())
+}
+
+// Succeeded reports whether the pipeline finished successfully
+func (p *Pipeline) Succeeded() bool {
+	switch p.NormalizedStatus() {
+	case "success", "passed":
+		return true
+	default:
+		return false
+	}
+}
+
+// PipelineFinished reports whether a normalized pipeline status is final
+func PipelineFinished(status string) bool {
+	switch status {
+	case "success", "passed", "failed", "canceled":
+		return true
+	default:
+		return false
+	}
+}
+
 // PipelineListResponse represents the paginated response from pipeline list API
 type PipelineListResponse struct {
 	Embedded struct {
@@ -228,6 +255,90 @@ func (s *PipelineService) findPipelineByLocalID(ctx context.Context, owner, proj
 	return nil, &APIError{StatusCode: 404, Message: fmt.Sprintf("pipeline #%d not found", localID)}
 }
 
+// LatestForCommit returns the most recent pipeline for ref that was run on
+// commit sha (any commit if sha is empty). Returns a 404 APIError if no
+// such pipeline exists yet.
+func (s *PipelineService) LatestForCommit(ctx context.Context, owner, project, ref, sha string) (*Pipeline, error) {
+	for page := 0; page < pipelineSearchMaxPages; page++ {
+		pipelines, err := s.listWithContext(ctx, owner, project, &PipelineListOptions{
+			Page: page,
+			Size: pipelineSearchPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Pipelines are listed newest first: stop once one is found
+		if p := LatestPipeline(pipelines, ref, sha); p != nil {
+			return p, nil
+		}
+		if len(pipelines) < pipelineSearchPageSize {
+			break
+		}
+	}
+
+	return nil, &APIError{StatusCode: 404, Message: fmt.Sprintf("no pipeline for %s at %s", ref, sha)}
+}
+
+// LatestPipeline returns the pipeline with the highest local ID among
+// pipelines for ref run on commit sha (any commit if sha is empty), or nil
+func LatestPipeline(pipelines []Pipeline, ref, sha string) *Pipeline {
+	var latest *Pipeline
+	for i := range pipelines {
+		p := &pipelines[i]
+		if p.Ref != ref || !strings.HasPrefix(p.CommitID, sha) {
+			continue
+		}
+		if latest == nil || p.LocalID > latest.LocalID {
+			latest = p
+		}
+	}
+	return latest
+}
+
+// PipelineWatchFunc is called by Watch after every poll. Returning an
+// error stops watching and Watch returns that error.
+type PipelineWatchFunc func(p *Pipeline) error
+
+// Watch polls a pipeline every interval until it finishes or ctx is done,
+// calling fn with every fetched state (including the first and the last).
+// Each request is limited to 30 seconds.
+func (s *PipelineService) Watch(ctx context.Context, owner, project string, localID int, interval time.Duration, fn PipelineWatchFunc) (*Pipeline, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		callCtx, cancel := context.WithTimeout(ctx, pipelineWatchCallTimeout)
+		p, err := s.GetWithContext(callCtx, owner, project, localID)
+		timedOut := callCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if timedOut {
+				return nil, fmt.Errorf("API request timed out")
+			}
+			return nil, err
+		}
+
+		if fn != nil {
+			if err := fn(p); err != nil {
+				return p, err
+			}
+		}
+		if p.IsFinished() {
+			return p, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return p, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // Start starts a new pipeline
 func (s *PipelineService) Start(owner, project string, ref string) (*Pipeline, error) {
 	path := fmt.Sprintf("/project/%s/%s/cicd/pipeline/start", owner, project)
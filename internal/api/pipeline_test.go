@@ -1,10 +1,15 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestPipeline_SHA(t *testing.T) {
@@ -308,3 +313,105 @@ func TestPipeline_JSONParsing(t *testing.T) {
 		t.Error("FinishedAt is nil")
 	}
 }
+
+func TestPipelineService_LatestForCommit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"_embedded": {
+				"restPipelineModelList": [
+					{"localId": 12, "status": "RUNNING", "ref": "feature", "commitId": "bbb222"},
+					{"localId": 11, "status": "FAILED", "ref": "feature", "commitId": "aaa111"},
+					{"localId": 10, "status": "SUCCESS", "ref": "main", "commitId": "aaa111"},
+					{"localId": 9, "status": "FAILED", "ref": "feature", "commitId": "bbb222"}
+				]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	p, err := client.Pipelines().LatestForCommit(context.Background(), "owner", "repo", "feature", "bbb222")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.LocalID != 12 {
+		t.Errorf("LocalID = %d, want 12", p.LocalID)
+	}
+
+	_, err = client.Pipelines().LatestForCommit(context.Background(), "owner", "repo", "feature", "ccc333")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestPipelineService_Watch(t *testing.T) {
+	statuses := []string{"PENDING", "RUNNING", "SUCCESS"}
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&polls, 1)
+		status := statuses[min(int(n)-1, len(statuses)-1)]
+		json.NewEncoder(w).Encode(map[string]any{"localId": 5, "status": status})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	var seen []string
+	p, err := client.Pipelines().Watch(context.Background(), "owner", "repo", 5, time.Millisecond,
+		func(p *Pipeline) error {
+			seen = append(seen, p.NormalizedStatus())
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.Succeeded() {
+		t.Errorf("final status = %q, want success", p.Status)
+	}
+	if strings.Join(seen, ",") != "pending,running,success" {
+		t.Errorf("seen = %v", seen)
+	}
+
+	// An error from the callback stops watching
+	atomic.StoreInt32(&polls, 0)
+	stop := errors.New("stop")
+	_, err = client.Pipelines().Watch(context.Background(), "owner", "repo", 5, time.Millisecond,
+		func(p *Pipeline) error { return stop })
+	if err != stop {
+		t.Errorf("err = %v, want %v", err, stop)
+	}
+	if polls != 1 {
+		t.Errorf("polls = %d, want 1", polls)
+	}
+}
+
+func TestLatestPipeline(t *testing.T) {
+	pipelines := []Pipeline{
+		{LocalID: 3, Ref: "main", CommitID: "aaa111"},
+		{LocalID: 5, Ref: "feature", CommitID: "bbb222"},
+		{LocalID: 4, Ref: "feature", CommitID: "aaa111"},
+	}
+
+	tests := []struct {
+		ref, sha string
+		want     int
+	}{
+		{"feature", "aaa111", 4},
+		{"feature", "", 5},
+		{"main", "aaa", 3},
+		{"main", "bbb222", 0},
+		{"other", "", 0},
+	}
+
+	for _, tt := range tests {
+		got := LatestPipeline(pipelines, tt.ref, tt.sha)
+		gotID := 0
+		if got != nil {
+			gotID = got.LocalID
+		}
+		if gotID != tt.want {
+			t.Errorf("LatestPipeline(%q, %q) = #%d, want #%d", tt.ref, tt.sha, gotID, tt.want)
+		}
+	}
+}
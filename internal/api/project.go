@@ -22,6 +22,10 @@ type Project struct {
 	DefaultBranch    string `json:"defaultBranch"`
 	HTTPTransportURL string `json:"httpTransportUrl"`
 	SSHTransportURL  string `json:"sshTransportUrl"`
+	// ForkedFrom is the parent of a fork, nil for other projects
+	ForkedFrom *struct {
+		ID string `json:"id"`
+	} `json:"forkedFrom,omitempty"`
 }
 
 // Get returns a project by owner and name
@@ -34,6 +38,23 @@ func (s *ProjectService) Get(owner, project string) (*Project, error) {
 	return &p, nil
 }
 
+// IsForkOf reports whether owner/project was forked from
+// parentOwner/parentProject
+func (s *ProjectService) IsForkOf(owner, project, parentOwner, parentProject string) (bool, error) {
+	p, err := s.Get(owner, project)
+	if err != nil {
+		return false, err
+	}
+	if p.ForkedFrom == nil || p.ForkedFrom.ID == "" {
+		return false, nil
+	}
+	parent, err := s.Get(parentOwner, parentProject)
+	if err != nil {
+		return false, err
+	}
+	return p.ForkedFrom.ID == parent.ID, nil
+}
+
 // MyProjects returns projects belonging to the authenticated user
 func (s *ProjectService) MyProjects() ([]Project, error) {
 	var projects []Project
@@ -42,3 +63,17 @@ func (s *ProjectService) MyProjects() ([]Project, error) {
 	}
 	return projects, nil
 }
+
+// Labels returns the labels defined in a project
+func (s *ProjectService) Labels(owner, project string) ([]Label, error) {
+	var resp struct {
+		Embedded struct {
+			Labels []Label `json:"labelModelList"`
+		} `json:"_embedded"`
+	}
+	path := fmt.Sprintf("/project/%s/%s/label?size=100", owner, project)
+	if err := s.client.Get(path, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Embedded.Labels, nil
+}
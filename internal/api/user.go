@@ -1,5 +1,10 @@
 package api
 
+import (
+	"fmt"
+	"net/url"
+)
+
 // UserService handles user-related API calls
 type UserService struct {
 	client *Client
@@ -16,6 +21,11 @@ type User struct {
 	AvatarURL string `json:"avatar"`
 }
 
+// UserRef is a reference to a user for API requests
+type UserRef struct {
+	ID string `json:"id"`
+}
+
 // Alias returns username for compatibility
 func (u *User) Alias() string {
 	return u.Username
@@ -29,3 +39,12 @@ func (s *UserService) Me() (*User, error) {
 	}
 	return &user, nil
 }
+
+// Get returns a user by alias (username)
+func (s *UserService) Get(alias string) (*User, error) {
+	var user User
+	if err := s.client.Get(fmt.Sprintf("/user/%s", url.PathEscape(alias)), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
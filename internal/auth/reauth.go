@@ -43,8 +43,11 @@ func PromptReauth(hostname string) (*api.Client, error) {
 	}
 
 	// Verify new token
-	baseURL := config.BaseURL(hostname)
-	client := api.NewClient(baseURL, token)
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	client := api.NewClientForHost(cfg, hostname, token)
 
 	user, err := client.Users().Me()
 	if err != nil {
@@ -52,18 +55,19 @@ func PromptReauth(hostname string) (*api.Client, error) {
 	}
 
 	// Save to config
-	cfg, err := config.Load()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
-	}
-
-	cfg.SetHost(hostname, &config.Host{
-		Token:    token,
-		User:     user.Username,
-		Protocol: "https",
+	err = config.Update(func(cfg *config.Config) error {
+		host := &config.Host{
+			Token:    token,
+			User:     user.Username,
+			Protocol: "https",
+		}
+		if existing := cfg.GetHost(hostname); existing != nil {
+			host.WarmupURL = existing.WarmupURL
+		}
+		cfg.SetHost(hostname, host)
+		return nil
 	})
-
-	if err := config.Save(cfg); err != nil {
+	if err != nil {
 		return nil, fmt.Errorf("failed to save config: %w", err)
 	}
 
@@ -0,0 +1,142 @@
+// Package bulk runs an operation on many merge requests or issues with
+// bounded concurrency, reporting progress and collecting failures.
+package bulk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency is the number of items processed at once by default
+const DefaultConcurrency = 4
+
+// MaxConcurrency limits --concurrency to stay friendly to the API
+const MaxConcurrency = 16
+
+// Item is an object to act on, identified by its local ID
+type Item struct {
+	ID    int
+	Title string // empty if unknown (IDs read from stdin)
+}
+
+// Failure is an item the operation failed on
+type Failure struct {
+	Item Item
+	Err  error
+}
+
+// ReadIDs reads IDs from r, one per line. Only the first field of a line
+// is used and "#" and "!" prefixes are accepted. Blank lines are skipped.
+// Duplicate IDs are returned once.
+func ReadIDs(r io.Reader) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		id, err := ParseID(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read IDs: %w", err)
+	}
+	return ids, nil
+}
+
+// ParseID parses an ID such as 12, #12 or !12
+func ParseID(s string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimLeft(s, "#!"))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID: %s", s)
+	}
+	return id, nil
+}
+
+// Run calls fn for every item, at most concurrency at a time, and writes
+// a progress line to w as each item completes. It returns the failures
+// in item order.
+func Run(items []Item, concurrency int, w io.Writer, fn func(item Item) error) []Failure {
+	concurrency = max(1, min(concurrency, len(items)))
+
+	var (
+		mu       sync.Mutex
+		done     int
+		failures []Failure
+		wg       sync.WaitGroup
+	)
+	queue := make(chan int)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				err := fn(items[i])
+
+				mu.Lock()
+				done++
+				if err != nil {
+					failures = append(failures, Failure{Item: items[i], Err: err})
+					fmt.Fprintf(w, "[%d/%d] ✗ #%d: %v\n", done, len(items), items[i].ID, err)
+				} else {
+					fmt.Fprintf(w, "[%d/%d] ✓ #%d%s\n", done, len(items), items[i].ID, titleSuffix(items[i]))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range items {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	// Report failures in a stable order
+	order := make(map[int]int, len(items))
+	for i, item := range items {
+		order[item.ID] = i
+	}
+	sort.Slice(failures, func(a, b int) bool {
+		return order[failures[a].Item.ID] < order[failures[b].Item.ID]
+	})
+	return failures
+}
+
+// WritePreview lists the items an operation will act on
+func WritePreview(w io.Writer, items []Item) {
+	for _, item := range items {
+		fmt.Fprintf(w, "  #%d%s\n", item.ID, titleSuffix(item))
+	}
+}
+
+// WriteSummary writes how many items succeeded and lists the failures
+func WriteSummary(w io.Writer, total int, failures []Failure) {
+	if len(failures) == 0 {
+		fmt.Fprintf(w, "\n✓ Done: %d succeeded\n", total)
+		return
+	}
+	fmt.Fprintf(w, "\n%d succeeded, %d failed:\n", total-len(failures), len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(w, "  #%d%s: %v\n", f.Item.ID, titleSuffix(f.Item), f.Err)
+	}
+}
+
+func titleSuffix(item Item) string {
+	if item.Title == "" {
+		return ""
+	}
+	return " " + item.Title
+}
@@ -0,0 +1,109 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr string
+	}{
+		{name: "plain", input: "12\n13\n", want: []int{12, 13}},
+		{name: "prefixes and columns", input: "#12 Fix login\n!13\n\n  14  \n", want: []int{12, 13, 14}},
+		{name: "duplicates", input: "3\n1\n3\n", want: []int{3, 1}},
+		{name: "empty", input: "", want: nil},
+		{name: "invalid", input: "12\nabc\n", wantErr: "line 2: invalid ID: abc"},
+		{name: "zero", input: "0\n", wantErr: "line 1: invalid ID: 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadIDs(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ReadIDs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadIDs() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	var items []Item
+	for i := 1; i <= 20; i++ {
+		items = append(items, Item{ID: i})
+	}
+
+	var running, peak atomic.Int32
+	var buf bytes.Buffer
+	failures := Run(items, 3, &buf, func(item Item) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if item.ID%7 == 0 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if p := peak.Load(); p > 3 {
+		t.Errorf("%d items ran at once, want at most 3", p)
+	}
+	var ids []int
+	for _, f := range failures {
+		ids = append(ids, f.Item.ID)
+	}
+	if !reflect.DeepEqual(ids, []int{7, 14}) {
+		t.Errorf("failures = %v, want [7 14]", ids)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(items) {
+		t.Fatalf("got %d progress lines, want %d:\n%s", len(lines), len(items), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "[1/20] ") || !strings.HasPrefix(lines[19], "[20/20] ") {
+		t.Errorf("unexpected progress counters:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "✗ #7: boom") {
+		t.Errorf("failure not reported:\n%s", buf.String())
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	var buf bytes.Buffer
+	WriteSummary(&buf, 3, nil)
+	if buf.String() != "\n✓ Done: 3 succeeded\n" {
+		t.Errorf("WriteSummary(no failures) = %q", buf.String())
+	}
+
+	buf.Reset()
+	WriteSummary(&buf, 3, []Failure{
+		{Item: Item{ID: 12, Title: "Fix login"}, Err: errors.New("not found")},
+		{Item: Item{ID: 13}, Err: errors.New("merge request is merged")},
+	})
+	want := "\n1 succeeded, 2 failed:\n  #12 Fix login: not found\n  #13: merge request is merged\n"
+	if buf.String() != want {
+		t.Errorf("WriteSummary() =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
@@ -0,0 +1,15 @@
+// Package cmdutil holds helpers shared by several command packages.
+package cmdutil
+
+import "github.com/spf13/cobra"
+
+// IsBuiltin reports whether name resolves to a built-in top-level command (or its alias)
+func IsBuiltin(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	// Cobra adds help and completion commands lazily
+	return name == "help" || name == "completion"
+}
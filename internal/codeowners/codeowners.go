@@ -0,0 +1,170 @@
+// Package codeowners parses CODEOWNERS files to suggest reviewers.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Locations are the paths, relative to the repository root, searched for
+// a CODEOWNERS file in order
+var Locations = []string{
+	".gitflic/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Rule maps a path pattern to its owners
+type Rule struct {
+	Pattern string
+	Owners  []string // usernames without the leading @
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Rules []Rule
+}
+
+// Find reads the first CODEOWNERS file found under root.
+// It returns nil without error if the repository has none.
+func Find(root string) (*File, error) {
+	for _, loc := range Locations {
+		f, err := os.Open(filepath.Join(root, filepath.FromSlash(loc)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		defer f.Close()
+		return Parse(f)
+	}
+	return nil, nil
+}
+
+// Parse reads CODEOWNERS rules: "pattern @owner ...", # comments.
+// Team (@org/team) and e-mail owners are ignored, as they cannot be
+// requested as reviewers directly.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := Rule{Pattern: fields[0]}
+		for _, owner := range fields[1:] {
+			if !strings.HasPrefix(owner, "@") || strings.Contains(owner, "/") {
+				continue
+			}
+			rule.Owners = append(rule.Owners, strings.TrimPrefix(owner, "@"))
+		}
+		file.Rules = append(file.Rules, rule)
+	}
+	return file, scanner.Err()
+}
+
+// Owners returns the owners of a file path (slash-separated, relative to
+// the repository root). As in other forges, the last matching rule wins.
+func (f *File) Owners(filePath string) []string {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if match(f.Rules[i].Pattern, filePath) {
+			return f.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// OwnersOf returns the owners of all paths, deduplicated, in order of
+// first appearance
+func (f *File) OwnersOf(paths []string) []string {
+	seen := make(map[string]bool)
+	var owners []string
+	for _, p := range paths {
+		for _, o := range f.Owners(p) {
+			key := strings.ToLower(o)
+			if !seen[key] {
+				seen[key] = true
+				owners = append(owners, o)
+			}
+		}
+	}
+	return owners
+}
+
+// match reports whether a gitignore-style pattern matches filePath.
+// Supported: "*" (everything), leading "/" (anchored to root), trailing
+// "/" (directory and its contents), "*" and "?" within a path segment,
+// and "**" spanning directories.
+func match(pattern, filePath string) bool {
+	if pattern == "*" {
+		return true
+	}
+
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// A pattern with an inner slash is relative to the root
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+
+	parts := strings.Split(filePath, "/")
+	patParts := strings.Split(pattern, "/")
+
+	starts := []int{0}
+	if !anchored {
+		starts = starts[:0]
+		for i := range parts {
+			starts = append(starts, i)
+		}
+	}
+
+	for _, start := range starts {
+		n, ok := matchParts(patParts, parts[start:])
+		if !ok {
+			continue
+		}
+		// The pattern matched a directory prefix: it owns everything below.
+		// dirOnly patterns must not match the final file name itself.
+		if n < len(parts[start:]) || !dirOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// matchParts matches pattern segments against a prefix of path segments
+// and returns how many path segments were consumed
+func matchParts(pat, parts []string) (int, bool) {
+	if len(pat) == 0 {
+		return 0, true
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if n, ok := matchParts(pat[1:], parts[i:]); ok {
+				return i + n, true
+			}
+		}
+		return 0, false
+	}
+	if len(parts) == 0 {
+		return 0, false
+	}
+	if ok, _ := path.Match(pat[0], parts[0]); !ok {
+		return 0, false
+	}
+	n, ok := matchParts(pat[1:], parts[1:])
+	return n + 1, ok
+}
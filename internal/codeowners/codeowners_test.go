@@ -0,0 +1,93 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `# Default owners
+*                 @lead
+
+/docs/            @writer  # documentation
+*.go              @gopher @lead
+/cmd/mr/**/*.go   @mr-team
+internal/api/     @api-owner @org/backend someone@example.com
+`
+
+func TestFile_Owners(t *testing.T) {
+	f, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"lead"}},
+		{"docs/guide.md", []string{"writer"}},
+		{"sub/docs/guide.md", []string{"lead"}}, // /docs/ is anchored
+		{"main.go", []string{"gopher", "lead"}},
+		{"cmd/mr/create.go", []string{"mr-team"}},
+		{"cmd/mr/sub/x.go", []string{"mr-team"}},
+		{"cmd/issue/list.go", []string{"gopher", "lead"}},
+		{"internal/api/client.go", []string{"api-owner"}},
+		{"pkg/internal/api/x.txt", []string{"lead"}}, // inner slash anchors
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := f.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"build/", "build/out.bin", true},
+		{"build/", "src/build/out.bin", true},
+		{"build/", "build", false},
+		{"Makefile", "tools/Makefile", true},
+		{"/Makefile", "tools/Makefile", false},
+		{"**/test_*.py", "a/b/test_x.py", true},
+		{"docs/*.md", "docs/a/b.md", false},
+		{"docs/**", "docs/a/b.md", true},
+	}
+
+	for _, tt := range tests {
+		if got := match(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+
+	f, err := Find(root)
+	if err != nil || f != nil {
+		t.Fatalf("Find(no file) = %v, %v, want nil, nil", f, err)
+	}
+
+	dir := filepath.Join(root, ".gitflic")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "CODEOWNERS"), []byte("* @alice\n"), 0644)
+	os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @bob\n"), 0644)
+
+	f, err = Find(root)
+	if err != nil || f == nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got := f.OwnersOf([]string{"a", "b"}); !reflect.DeepEqual(got, []string{"alice"}) {
+		t.Errorf("OwnersOf = %v, want [alice] from .gitflic/CODEOWNERS", got)
+	}
+}
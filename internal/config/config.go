@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/josinSbazin/gf/internal/fileutil"
 )
 
 const (
@@ -92,6 +96,9 @@ type Config struct {
 	Version    int              `json:"version"`
 	ActiveHost string           `json:"active_host"`
 	Hosts      map[string]*Host `json:"hosts"`
+	// Aliases maps alias names to their expansions.
+	// Expansions starting with "!" are run through the shell.
+	Aliases map[string]string `json:"aliases,omitempty"`
 }
 
 // Host represents a GitFlic host configuration
@@ -99,6 +106,11 @@ type Host struct {
 	Token    string `json:"token"`
 	User     string `json:"user"`
 	Protocol string `json:"protocol,omitempty"`
+	// WarmupURL is visited before API calls to obtain bot-protection
+	// (e.g. DDoS-Guard) cookies. Defaults to https://gitflic.ru/ for GitFlic cloud.
+	WarmupURL string `json:"warmup_url,omitempty"`
+	// ExpiresAt is the token expiry date, if known (set via gf auth login --expires-at)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 // DefaultHost returns the default GitFlic hostname
@@ -106,13 +118,56 @@ func DefaultHost() string {
 	return DefaultHostname
 }
 
+// Dir returns the gf configuration directory.
+// Priority: GF_CONFIG_DIR > $XDG_CONFIG_HOME/gf > ~/.gf.
+// An existing ~/.gf keeps being used when $XDG_CONFIG_HOME/gf does not exist yet,
+// so setting XDG_CONFIG_HOME does not hide an existing login.
+func Dir() (string, error) {
+	if dir := os.Getenv("GF_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	return xdgDir("XDG_CONFIG_HOME")
+}
+
+// StateDir returns the directory for runtime state such as cookies.
+// Priority: GF_CONFIG_DIR > $XDG_STATE_HOME/gf > config directory.
+func StateDir() (string, error) {
+	if dir := os.Getenv("GF_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	return xdgDir("XDG_STATE_HOME")
+}
+
+// xdgDir resolves $<env>/gf, falling back to the legacy ~/.gf directory
+func xdgDir(env string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	legacy := filepath.Join(home, configDir)
+
+	base := os.Getenv(env)
+	if base == "" || !filepath.IsAbs(base) {
+		return legacy, nil
+	}
+	xdg := filepath.Join(base, "gf")
+
+	if _, err := os.Stat(xdg); err == nil {
+		return xdg, nil
+	}
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, nil
+	}
+	return xdg, nil
+}
+
 // ConfigPath returns the path to the config file
 func ConfigPath() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := Dir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, configDir, configFile), nil
+	return filepath.Join(dir, configFile), nil
 }
 
 // Load reads the config from disk
@@ -121,7 +176,11 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	return load(path)
+}
 
+// load reads the config file at path
+func load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -147,7 +206,9 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save writes the config to disk
+// Save writes the config to disk.
+// The file is replaced atomically while holding an exclusive lock,
+// so concurrent gf processes never see a partially written config.
 func Save(cfg *Config) error {
 	path, err := ConfigPath()
 	if err != nil {
@@ -155,18 +216,60 @@ func Save(cfg *Config) error {
 	}
 
 	// Create directory with restricted permissions
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	lock, err := fileutil.Lock(path)
+	if err != nil {
 		return err
 	}
+	defer lock.Unlock()
 
+	return save(path, cfg)
+}
+
+// save writes cfg to path; the caller must hold the lock
+func save(path string, cfg *Config) error {
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return err
 	}
 
 	// Write file with restricted permissions (owner read/write only)
-	return os.WriteFile(path, data, 0600)
+	return fileutil.WriteAtomic(path, data, 0600)
+}
+
+// Update loads the config, applies fn and saves the result while holding
+// an exclusive lock. Use it instead of Load+Save for read-modify-write
+// changes so that concurrent gf processes do not overwrite each other.
+// If fn returns an error the config is left unchanged.
+func Update(fn func(cfg *Config) error) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	lock, err := fileutil.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	cfg, err := load(path)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(cfg); err != nil {
+		return err
+	}
+
+	return save(path, cfg)
 }
 
 // GetHost returns the host configuration for the given hostname
@@ -208,6 +311,14 @@ func (c *Config) SetHost(hostname string, host *Host) {
 	c.Hosts[hostname] = host
 }
 
+// SetAlias sets the expansion for an alias
+func (c *Config) SetAlias(name, expansion string) {
+	if c.Aliases == nil {
+		c.Aliases = make(map[string]string)
+	}
+	c.Aliases[name] = expansion
+}
+
 // BaseURL returns the API base URL for the given hostname
 func BaseURL(hostname string) string {
 	if hostname == DefaultHostname {
@@ -216,3 +327,35 @@ func BaseURL(hostname string) string {
 	// For self-hosted instances
 	return "https://" + hostname + "/rest-api"
 }
+
+// HostnameFromBaseURL returns the configured hostname for an API base URL
+// (the inverse of BaseURL): https://api.gitflic.ru -> gitflic.ru
+func HostnameFromBaseURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	if u.Host == DefaultAPIHost {
+		return DefaultHostname
+	}
+	return u.Host
+}
+
+// WarmupURL returns the URL visited to obtain bot-protection cookies for
+// hostname, or "" if the host is not known to be protected.
+func (c *Config) WarmupURL(hostname string) string {
+	if host := c.GetHost(hostname); host != nil && host.WarmupURL != "" {
+		return host.WarmupURL
+	}
+	return DefaultWarmupURL(hostname)
+}
+
+// DefaultWarmupURL returns the built-in warmup URL for hostname, used when
+// the host has no warmup_url configured
+func DefaultWarmupURL(hostname string) string {
+	// GitFlic cloud (api.gitflic.ru) is behind DDoS-Guard
+	if hostname == DefaultHostname {
+		return "https://" + DefaultHostname + "/"
+	}
+	return ""
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 )
 
@@ -32,6 +35,53 @@ func TestBaseURL(t *testing.T) {
 	}
 }
 
+func TestHostnameFromBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"https://api.gitflic.ru", "gitflic.ru"},
+		{"https://git.company.com/rest-api", "git.company.com"},
+		{"https://git.company.com:8443/rest-api", "git.company.com:8443"},
+		{"not a url", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseURL, func(t *testing.T) {
+			if got := HostnameFromBaseURL(tt.baseURL); got != tt.want {
+				t.Errorf("HostnameFromBaseURL(%q) = %q, want %q", tt.baseURL, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_WarmupURL(t *testing.T) {
+	cfg := &Config{
+		Hosts: map[string]*Host{
+			"git.company.com": {Token: "t", WarmupURL: "https://git.company.com/explore"},
+			"plain.example":   {Token: "t"},
+		},
+	}
+
+	tests := []struct {
+		hostname string
+		want     string
+	}{
+		{"gitflic.ru", "https://gitflic.ru/"},
+		{"git.company.com", "https://git.company.com/explore"},
+		{"plain.example", ""},
+		{"unknown.example", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			if got := cfg.WarmupURL(tt.hostname); got != tt.want {
+				t.Errorf("WarmupURL(%q) = %q, want %q", tt.hostname, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConfig_GetHost(t *testing.T) {
 	cfg := &Config{
 		Hosts: map[string]*Host{
@@ -226,6 +276,8 @@ func TestLoadSave(t *testing.T) {
 		os.Setenv("HOME", origHome)
 		os.Setenv("USERPROFILE", origUserProfile)
 	}()
+	t.Setenv("GF_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
 
 	// Test Save
 	cfg := &Config{
@@ -284,6 +336,8 @@ func TestLoad_NoFile(t *testing.T) {
 		os.Setenv("HOME", origHome)
 		os.Setenv("USERPROFILE", origUserProfile)
 	}()
+	t.Setenv("GF_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
 
 	// Load should return empty config when file doesn't exist
 	cfg, err := Load()
@@ -304,3 +358,126 @@ func TestLoad_NoFile(t *testing.T) {
 		t.Error("Hosts is nil")
 	}
 }
+
+func TestDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	xdg := filepath.Join(home, "xdg")
+	legacy := filepath.Join(home, ".gf")
+
+	tests := []struct {
+		name      string
+		configDir string
+		xdgHome   string
+		mkdirs    []string
+		want      string
+	}{
+		{"default", "", "", nil, legacy},
+		{"GF_CONFIG_DIR wins", "/tmp/gf-ci", xdg, nil, "/tmp/gf-ci"},
+		{"XDG without legacy dir", "", xdg, nil, filepath.Join(xdg, "gf")},
+		{"legacy dir kept", "", xdg, []string{legacy}, legacy},
+		{"existing XDG dir wins", "", xdg, []string{legacy, filepath.Join(xdg, "gf")}, filepath.Join(xdg, "gf")},
+		{"relative XDG ignored", "", "relative", nil, legacy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.RemoveAll(legacy)
+			os.RemoveAll(xdg)
+			for _, d := range tt.mkdirs {
+				if err := os.MkdirAll(d, 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("GF_CONFIG_DIR", tt.configDir)
+			t.Setenv("XDG_CONFIG_HOME", tt.xdgHome)
+
+			got, err := Dir()
+			if err != nil {
+				t.Fatalf("Dir() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Dir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("GF_CONFIG_DIR", "")
+
+	state := filepath.Join(home, "state")
+	t.Setenv("XDG_STATE_HOME", state)
+	got, err := StateDir()
+	if err != nil {
+		t.Fatalf("StateDir() error: %v", err)
+	}
+	if got != filepath.Join(state, "gf") {
+		t.Errorf("StateDir() = %q, want %q", got, filepath.Join(state, "gf"))
+	}
+
+	t.Setenv("GF_CONFIG_DIR", filepath.Join(home, "isolated"))
+	got, _ = StateDir()
+	if got != filepath.Join(home, "isolated") {
+		t.Errorf("StateDir() with GF_CONFIG_DIR = %q", got)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GF_CONFIG_DIR", dir)
+
+	err := Update(func(cfg *Config) error {
+		cfg.SetHost("gitflic.ru", &Host{Token: "token"})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// Concurrent updates must not lose each other's changes
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := Update(func(cfg *Config) error {
+				cfg.SetAlias(fmt.Sprintf("a%d", i), "mr list")
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Update failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.Aliases) != 10 {
+		t.Errorf("len(Aliases) = %d, want 10", len(cfg.Aliases))
+	}
+	if cfg.GetHost("gitflic.ru") == nil {
+		t.Error("host lost after concurrent updates")
+	}
+
+	// A failing update leaves the config untouched
+	wantErr := errors.New("boom")
+	err = Update(func(cfg *Config) error {
+		cfg.Aliases = nil
+		return wantErr
+	})
+	if err != wantErr {
+		t.Errorf("Update error = %v, want %v", err, wantErr)
+	}
+	cfg, _ = Load()
+	if len(cfg.Aliases) != 10 {
+		t.Errorf("failed Update modified config: %d aliases", len(cfg.Aliases))
+	}
+}
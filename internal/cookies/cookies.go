@@ -7,11 +7,19 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/josinSbazin/gf/internal/config"
+	"github.com/josinSbazin/gf/internal/fileutil"
 )
 
-const cookiesFile = "cookies.json"
+const cookiesDir = "cookies"
+
+// legacyCookiesFile is the single cookie file used before cookies were
+// kept per host. It only ever held cookies for GitFlic cloud.
+const legacyCookiesFile = "cookies.json"
 
 // PersistentCookie represents a cookie for JSON storage
 type PersistentCookie struct {
@@ -22,76 +30,194 @@ type PersistentCookie struct {
 	Expires  time.Time `json:"expires,omitempty"`
 	Secure   bool      `json:"secure,omitempty"`
 	HttpOnly bool      `json:"httpOnly,omitempty"`
+	HostOnly bool      `json:"hostOnly,omitempty"`
+}
+
+// expired reports whether the cookie has expired at now.
+// Session cookies (zero Expires) never expire on disk.
+func (p PersistentCookie) expired(now time.Time) bool {
+	return !p.Expires.IsZero() && p.Expires.Before(now)
+}
+
+// cookieKey identifies a cookie the same way browsers do
+type cookieKey struct {
+	domain, path, name string
+}
+
+func keyOf(p PersistentCookie) cookieKey {
+	return cookieKey{p.Domain, p.Path, p.Name}
 }
 
-// Store manages persistent cookie storage
+// Store is a persistent cookie jar scoped to a single configured host.
+// It implements http.CookieJar and records full cookie attributes
+// (domain, path, expiry) so they survive between gf invocations.
 type Store struct {
-	jar      *cookiejar.Jar
-	path     string
-	mu       sync.Mutex
-	modified bool
+	jar     *cookiejar.Jar
+	host    string
+	path    string
+	mu      sync.Mutex
+	cookies map[cookieKey]PersistentCookie
+	// changed holds keys set or deleted by this process since the last save
+	changed map[cookieKey]bool
 }
 
-// NewStore creates a new cookie store with persistence
-func NewStore() (*Store, error) {
-	home, err := os.UserHomeDir()
+// NewStore creates a persistent cookie store for hostname.
+// Cookies are kept in <state dir>/cookies/<hostname>.json; expired
+// cookies are pruned from the file on load.
+func NewStore(hostname string) (*Store, error) {
+	dir, err := config.StateDir()
 	if err != nil {
 		return nil, err
 	}
 
-	path := filepath.Join(home, ".gf", cookiesFile)
-
 	jar, _ := cookiejar.New(nil)
 	store := &Store{
-		jar:  jar,
-		path: path,
+		jar:     jar,
+		host:    hostname,
+		path:    filepath.Join(dir, cookiesDir, fileName(hostname)),
+		cookies: make(map[cookieKey]PersistentCookie),
+		changed: make(map[cookieKey]bool),
+	}
+
+	if hostname == config.DefaultHostname {
+		migrateLegacy(filepath.Join(dir, legacyCookiesFile), store.path)
 	}
 
 	// Load existing cookies
-	store.load()
+	if pruned, err := store.load(); err == nil && pruned {
+		store.Save()
+	}
 
 	return store, nil
 }
 
-// Jar returns the underlying cookie jar for http.Client
+// migrateLegacy moves the legacy cookie file to path unless path already
+// exists, in which case the legacy file is stale and removed. Failures
+// only cost a new warmup, so they are ignored.
+func migrateLegacy(legacy, path string) {
+	if _, err := os.Stat(legacy); err != nil {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		os.Remove(legacy)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	os.Rename(legacy, path)
+}
+
+// fileName returns a safe file name for a hostname (host:port -> host_port.json)
+func fileName(hostname string) string {
+	name := strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(hostname)
+	if name == "" || name == "." || name == ".." {
+		name = "default"
+	}
+	return name + ".json"
+}
+
+// Host returns the hostname this store belongs to
+func (s *Store) Host() string {
+	return s.host
+}
+
+// Path returns the path of the cookie file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Jar returns the cookie jar for http.Client
 func (s *Store) Jar() http.CookieJar {
-	return s.jar
+	return s
 }
 
-// Save persists cookies to disk
-func (s *Store) Save() error {
+// Cookies implements http.CookieJar
+func (s *Store) Cookies(u *url.URL) []*http.Cookie {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if !s.modified {
-		return nil
-	}
+	jar := s.jar
+	s.mu.Unlock()
+	return jar.Cookies(u)
+}
 
-	// Get cookies for gitflic.ru
-	u, _ := url.Parse("https://gitflic.ru")
-	cookies := s.jar.Cookies(u)
+// SetCookies implements http.CookieJar, recording cookies for persistence
+func (s *Store) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if len(cookies) == 0 {
-		return nil
-	}
+	s.jar.SetCookies(u, cookies)
 
-	// Convert to persistable format
-	var persistent []PersistentCookie
+	now := time.Now()
 	for _, c := range cookies {
-		persistent = append(persistent, PersistentCookie{
+		p := PersistentCookie{
 			Name:     c.Name,
 			Value:    c.Value,
-			Domain:   "gitflic.ru",
+			Domain:   strings.TrimPrefix(strings.ToLower(c.Domain), "."),
 			Path:     c.Path,
 			Expires:  c.Expires,
 			Secure:   c.Secure,
 			HttpOnly: c.HttpOnly,
-		})
+		}
+		if p.Domain == "" {
+			p.Domain = u.Hostname()
+			p.HostOnly = true
+		}
+		if p.Path == "" || !strings.HasPrefix(p.Path, "/") {
+			p.Path = "/"
+		}
+
+		deleted := c.MaxAge < 0
+		if c.MaxAge > 0 {
+			p.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		}
+
+		k := keyOf(p)
+		if deleted || p.expired(now) {
+			delete(s.cookies, k)
+		} else {
+			s.cookies[k] = p
+		}
+		s.changed[k] = true
 	}
+}
 
-	data, err := json.MarshalIndent(persistent, "", "  ")
-	if err != nil {
-		return err
+// Valid returns the number of unexpired cookies and the earliest expiry
+// among them (zero if all are session cookies)
+func (s *Store) Valid() (int, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	var earliest time.Time
+	for _, p := range s.cookies {
+		if p.expired(now) {
+			continue
+		}
+		count++
+		if !p.Expires.IsZero() && (earliest.IsZero() || p.Expires.Before(earliest)) {
+			earliest = p.Expires
+		}
+	}
+	return count, earliest
+}
+
+// HasValid reports whether the store holds at least one unexpired cookie
+func (s *Store) HasValid() bool {
+	count, _ := s.Valid()
+	return count > 0
+}
+
+// Save persists cookies to disk.
+// The cookie file is locked while saving and merged with cookies written
+// by other gf processes, so parallel runs do not drop each other's cookies.
+// Only cookies changed by this process override what is on disk.
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.changed) == 0 {
+		return nil
 	}
 
 	// Ensure directory exists
@@ -99,56 +225,129 @@ func (s *Store) Save() error {
 		return err
 	}
 
-	s.modified = false
-	return os.WriteFile(s.path, data, 0600)
+	lock, err := fileutil.Lock(s.path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// Start from what is on disk, then overlay our changes
+	existing, err := readCookies(s.path)
+	if err != nil {
+		existing = nil // Corrupt file: overwrite it
+	}
+
+	updates := make(map[cookieKey]*PersistentCookie, len(s.changed))
+	for k := range s.changed {
+		if p, ok := s.cookies[k]; ok {
+			updates[k] = &p
+		} else {
+			updates[k] = nil
+		}
+	}
+
+	merged := mergeCookies(existing, updates, time.Now())
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := fileutil.WriteAtomic(s.path, data, 0600); err != nil {
+		return err
+	}
+	s.changed = make(map[cookieKey]bool)
+	return nil
 }
 
-// load reads cookies from disk
-func (s *Store) load() error {
-	data, err := os.ReadFile(s.path)
+// mergeCookies applies updates (nil value = delete) on top of cookies
+// read from disk and drops expired cookies.
+func mergeCookies(existing []PersistentCookie, updates map[cookieKey]*PersistentCookie, now time.Time) []PersistentCookie {
+	merged := make([]PersistentCookie, 0, len(existing)+len(updates))
+	seen := make(map[cookieKey]bool)
+
+	for _, p := range existing {
+		k := keyOf(p)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if u, ok := updates[k]; ok {
+			if u == nil {
+				continue
+			}
+			p = *u
+		}
+		if !p.expired(now) {
+			merged = append(merged, p)
+		}
+	}
+
+	for k, u := range updates {
+		if seen[k] || u == nil || u.expired(now) {
+			continue
+		}
+		merged = append(merged, *u)
+	}
+
+	return merged
+}
+
+// readCookies reads persisted cookies from path
+func readCookies(path string) ([]PersistentCookie, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
 
 	var persistent []PersistentCookie
 	if err := json.Unmarshal(data, &persistent); err != nil {
-		return err
+		return nil, err
 	}
+	return persistent, nil
+}
 
-	// Convert to http.Cookie and set in jar
-	var cookies []*http.Cookie
+// load reads cookies from disk into the jar.
+// Returns true if expired cookies were skipped and the file should be rewritten.
+func (s *Store) load() (bool, error) {
+	persistent, err := readCookies(s.path)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	pruned := false
 	for _, p := range persistent {
 		// Skip expired cookies
-		if !p.Expires.IsZero() && p.Expires.Before(time.Now()) {
+		if p.expired(now) {
+			s.changed[keyOf(p)] = true
+			pruned = true
 			continue
 		}
-		cookies = append(cookies, &http.Cookie{
+		if p.Path == "" {
+			p.Path = "/"
+		}
+
+		cookie := &http.Cookie{
 			Name:     p.Name,
 			Value:    p.Value,
-			Domain:   p.Domain,
 			Path:     p.Path,
 			Expires:  p.Expires,
 			Secure:   p.Secure,
 			HttpOnly: p.HttpOnly,
-		})
-	}
+		}
+		if !p.HostOnly {
+			cookie.Domain = p.Domain
+		}
 
-	if len(cookies) > 0 {
-		u, _ := url.Parse("https://gitflic.ru")
-		s.jar.SetCookies(u, cookies)
+		u := &url.URL{Scheme: "https", Host: p.Domain, Path: "/"}
+		s.jar.SetCookies(u, []*http.Cookie{cookie})
+		s.cookies[keyOf(p)] = p
 	}
 
-	return nil
-}
-
-// MarkModified marks the store as having new cookies to save
-func (s *Store) MarkModified() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.modified = true
+	return pruned, nil
 }
 
 // Clear removes all cookies and the cookie file
@@ -158,7 +357,13 @@ func (s *Store) Clear() error {
 
 	// Create fresh jar
 	s.jar, _ = cookiejar.New(nil)
-	s.modified = false
+	s.cookies = make(map[cookieKey]PersistentCookie)
+	s.changed = make(map[cookieKey]bool)
+
+	lock, err := fileutil.Lock(s.path)
+	if err == nil {
+		defer lock.Unlock()
+	}
 
 	// Remove file
 	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
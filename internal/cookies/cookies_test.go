@@ -0,0 +1,207 @@
+package cookies
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMergeCookies(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	existing := []PersistentCookie{
+		{Name: "__ddg1_", Value: "old", Domain: "gitflic.ru", Path: "/", Expires: future},
+		{Name: "__ddg2_", Value: "keep", Domain: "gitflic.ru", Path: "/", Expires: future},
+		{Name: "gone", Value: "x", Domain: "gitflic.ru", Path: "/", Expires: future},
+		{Name: "stale", Value: "x", Domain: "gitflic.ru", Path: "/", Expires: past},
+	}
+	updates := map[cookieKey]*PersistentCookie{
+		{"gitflic.ru", "/", "__ddg1_"}: {Name: "__ddg1_", Value: "new", Domain: "gitflic.ru", Path: "/", Expires: future},
+		{"gitflic.ru", "/", "session"}: {Name: "session", Value: "s", Domain: "gitflic.ru", Path: "/"},
+		{"gitflic.ru", "/", "gone"}:    nil,
+	}
+
+	got := mergeCookies(existing, updates, now)
+
+	want := map[string]string{
+		"__ddg1_": "new",
+		"__ddg2_": "keep",
+		"session": "s",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mergeCookies returned %d cookies, want %d: %+v", len(got), len(want), got)
+	}
+	for _, c := range got {
+		if want[c.Name] != c.Value {
+			t.Errorf("cookie %s = %q, want %q", c.Name, c.Value, want[c.Name])
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"gitflic.ru", "gitflic.ru.json"},
+		{"git.company.com:8443", "git.company.com_8443.json"},
+		{"../etc", ".._etc.json"},
+		{"", "default.json"},
+	}
+
+	for _, tt := range tests {
+		if got := fileName(tt.host); got != tt.want {
+			t.Errorf("fileName(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestStore_SaveMerges(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	first, err := NewStore("gitflic.ru")
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	second, err := NewStore("gitflic.ru")
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	setCookie(first, "https://gitflic.ru", "a", "1", time.Hour)
+	setCookie(second, "https://gitflic.ru", "b", "2", time.Hour)
+
+	if err := first.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := second.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	persisted, err := readCookies(first.path)
+	if err != nil {
+		t.Fatalf("readCookies failed: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, c := range persisted {
+		names[c.Name] = true
+	}
+	if !names["a"] || !names["b"] {
+		t.Errorf("persisted cookies = %+v, want both a and b", persisted)
+	}
+}
+
+func TestStore_PerHost(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	cloud, _ := NewStore("gitflic.ru")
+	onprem, _ := NewStore("git.company.com")
+
+	if cloud.Path() == onprem.Path() {
+		t.Fatalf("stores share cookie file %s", cloud.Path())
+	}
+	if filepath.Base(onprem.Path()) != "git.company.com.json" {
+		t.Errorf("Path() = %s, want git.company.com.json", onprem.Path())
+	}
+
+	setCookie(cloud, "https://gitflic.ru", "__ddg1_", "cloud", time.Hour)
+	if err := cloud.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, _ := NewStore("git.company.com")
+	if reloaded.HasValid() {
+		t.Error("cookies of gitflic.ru leaked into git.company.com store")
+	}
+}
+
+func TestStore_MigratesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GF_CONFIG_DIR", dir)
+
+	expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	legacy := filepath.Join(dir, legacyCookiesFile)
+	data := `[{"name": "__ddg1_", "value": "old", "domain": "gitflic.ru", "path": "/", "expires": "` + expires + `"}]`
+	if err := os.WriteFile(legacy, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other hosts never had cookies in the legacy file
+	if other, _ := NewStore("git.company.com"); other.HasValid() {
+		t.Error("legacy cookies loaded for git.company.com")
+	}
+
+	store, _ := NewStore("gitflic.ru")
+	u, _ := url.Parse("https://api.gitflic.ru/project")
+	if got := store.Cookies(u); len(got) != 1 || got[0].Value != "old" {
+		t.Errorf("Cookies() after migration = %v, want __ddg1_=old", got)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy file still exists: %v", err)
+	}
+	if _, err := os.Stat(store.Path()); err != nil {
+		t.Errorf("migrated file missing: %v", err)
+	}
+}
+
+func TestStore_PersistsExpiry(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	store, _ := NewStore("gitflic.ru")
+	setCookie(store, "https://gitflic.ru", "__ddg1_", "v", time.Hour)
+	setCookie(store, "https://gitflic.ru", "old", "v", -time.Hour)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, _ := NewStore("gitflic.ru")
+	count, expires := reloaded.Valid()
+	if count != 1 {
+		t.Fatalf("Valid() count = %d, want 1", count)
+	}
+	if until := time.Until(expires); until < 50*time.Minute || until > time.Hour {
+		t.Errorf("expiry in %v, want about 1h", until)
+	}
+
+	// Domain cookies are sent to the API subdomain
+	u, _ := url.Parse("https://api.gitflic.ru/project")
+	if got := reloaded.Cookies(u); len(got) != 1 || got[0].Value != "v" {
+		t.Errorf("Cookies(api.gitflic.ru) = %v, want __ddg1_=v", got)
+	}
+}
+
+func TestStore_Clear(t *testing.T) {
+	t.Setenv("GF_CONFIG_DIR", t.TempDir())
+
+	store, _ := NewStore("gitflic.ru")
+	setCookie(store, "https://gitflic.ru", "a", "1", time.Hour)
+	store.Save()
+
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if store.HasValid() {
+		t.Error("HasValid() = true after Clear")
+	}
+
+	reloaded, _ := NewStore("gitflic.ru")
+	if reloaded.HasValid() {
+		t.Error("cookies persisted after Clear")
+	}
+}
+
+func setCookie(s *Store, rawURL, name, value string, ttl time.Duration) {
+	u, _ := url.Parse(rawURL)
+	s.SetCookies(u, []*http.Cookie{{
+		Name:    name,
+		Value:   value,
+		Domain:  u.Hostname(),
+		Path:    "/",
+		Expires: time.Now().Add(ttl),
+	}})
+}
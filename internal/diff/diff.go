@@ -0,0 +1,90 @@
+// Package diff parses the hunks of unified diffs.
+package diff
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeader matches unified diff hunk headers: @@ -old,count +new,count @@
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Line is a line of a diff hunk. Old and New are its line numbers on each
+// side, 0 for lines that only exist on the other side.
+type Line struct {
+	Kind byte // '+', '-' or ' '
+	Old  int
+	New  int
+	Text string
+}
+
+// ParseHunks splits the hunks of a unified diff of one file into numbered
+// lines. Each hunk is read for the line counts of its header, so lines
+// between hunks (file headers) are skipped while content lines that look
+// like headers, such as "--- a", are kept. Parsing stops at the next
+// "diff --git" line.
+func ParseHunks(content string) [][]Line {
+	var hunks [][]Line
+	oldN, newN := 0, 0
+	oldLeft, newLeft := 0, 0
+	for _, line := range strings.Split(strings.TrimRight(strings.ReplaceAll(content, "\r\n", "\n"), "\n"), "\n") {
+		if oldLeft <= 0 && newLeft <= 0 {
+			if strings.HasPrefix(line, "diff --git ") && len(hunks) > 0 {
+				break
+			}
+			if m := hunkHeader.FindStringSubmatch(line); m != nil {
+				oldN, _ = strconv.Atoi(m[1])
+				newN, _ = strconv.Atoi(m[3])
+				oldLeft, newLeft = hunkLength(m[2]), hunkLength(m[4])
+				hunks = append(hunks, nil)
+			}
+			continue
+		}
+
+		cur := &hunks[len(hunks)-1]
+		switch {
+		case strings.HasPrefix(line, "+"):
+			*cur = append(*cur, Line{Kind: '+', New: newN, Text: line[1:]})
+			newN++
+			newLeft--
+		case strings.HasPrefix(line, "-"):
+			*cur = append(*cur, Line{Kind: '-', Old: oldN, Text: line[1:]})
+			oldN++
+			oldLeft--
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			*cur = append(*cur, Line{Kind: ' ', Old: oldN, New: newN, Text: strings.TrimPrefix(line, " ")})
+			newN++
+			oldN++
+			oldLeft--
+			newLeft--
+		}
+	}
+	return hunks
+}
+
+// CountLines counts added and removed lines in a unified diff of one file
+func CountLines(content string) (additions, deletions int) {
+	for _, hunk := range ParseHunks(content) {
+		for _, l := range hunk {
+			switch l.Kind {
+			case '+':
+				additions++
+			case '-':
+				deletions++
+			}
+		}
+	}
+	return additions, deletions
+}
+
+// hunkLength parses the line count of a hunk header; an omitted count is 1
+func hunkLength(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
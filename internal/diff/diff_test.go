@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHunks(t *testing.T) {
+	content := "diff --git a/f b/f\n--- a/f\n+++ b/f\n" +
+		"@@ -10,3 +10,3 @@ func main() {\n a\n--- b\n+++ c\n d\n" +
+		"@@ -20 +20,2 @@\n-x\n+y\n+z\n\\ No newline at end of file\n" +
+		"diff --git a/g b/g\n@@ -1 +1 @@\n-g\n+h\n"
+	want := [][]Line{
+		{
+			{Kind: ' ', Old: 10, New: 10, Text: "a"},
+			{Kind: '-', Old: 11, Text: "-- b"},
+			{Kind: '+', New: 11, Text: "++ c"},
+			{Kind: ' ', Old: 12, New: 12, Text: "d"},
+		},
+		{
+			{Kind: '-', Old: 20, Text: "x"},
+			{Kind: '+', New: 20, Text: "y"},
+			{Kind: '+', New: 21, Text: "z"},
+		},
+	}
+	if got := ParseHunks(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseHunks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	tests := []struct {
+		name                 string
+		content              string
+		additions, deletions int
+	}{
+		{"simple", "@@ -1,2 +1,3 @@\n a\n-b\n+c\n+d\n", 2, 1},
+		{"file headers", "diff --git a/f b/f\n--- a/f\n+++ b/f\n@@ -1 +1 @@\n-x\n+y\n", 1, 1},
+		{"content like headers", "@@ -1,2 +1,2 @@\n--- a\n+++ b\n c\n", 1, 1},
+		{"two hunks", "@@ -1 +1,2 @@\n a\n+b\n@@ -10 +11 @@\n-c\n+d\n\\ No newline at end of file\n", 2, 1},
+		{"empty", "", 0, 0},
+	}
+	for _, tt := range tests {
+		a, d := CountLines(tt.content)
+		if a != tt.additions || d != tt.deletions {
+			t.Errorf("%s: CountLines() = +%d -%d, want +%d -%d", tt.name, a, d, tt.additions, tt.deletions)
+		}
+	}
+}
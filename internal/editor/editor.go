@@ -0,0 +1,69 @@
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Command returns the user's preferred editor command.
+// Priority: GF_EDITOR > VISUAL > EDITOR > git core.editor > platform default.
+func Command() string {
+	for _, env := range []string{"GF_EDITOR", "VISUAL", "EDITOR"} {
+		if e := os.Getenv(env); e != "" {
+			return e
+		}
+	}
+	if out, err := exec.Command("git", "config", "core.editor").Output(); err == nil {
+		if e := strings.TrimSpace(string(out)); e != "" {
+			return e
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Edit opens initial in the user's editor and returns the edited text.
+// fileName is used as the temporary file name so editors pick the right
+// syntax highlighting (e.g. MERGE_REQUEST.md).
+func Edit(fileName, initial string) (string, error) {
+	dir, err := os.MkdirTemp("", "gf-edit-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, fileName)
+	if err := os.WriteFile(path, []byte(initial), 0600); err != nil {
+		return "", err
+	}
+
+	cmd := editorCmd(Command(), path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// editorCmd builds the command running editor on path. The editor may
+// contain arguments (e.g. "code --wait"), so on Unix it runs through sh
+// with the path passed as a positional argument to avoid quoting issues.
+func editorCmd(editor, path string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		args := strings.Fields(editor)
+		return exec.Command(args[0], append(args[1:], path)...)
+	}
+	return exec.Command("sh", "-c", editor+` "$@"`, "sh", path)
+}
@@ -0,0 +1,91 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	if err := WriteAtomic(path, []byte("first"), 0600); err != nil {
+		t.Fatalf("WriteAtomic failed: %v", err)
+	}
+	if err := WriteAtomic(path, []byte("second"), 0600); err != nil {
+		t.Fatalf("WriteAtomic (overwrite) failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("content = %q, want second", data)
+	}
+
+	if os.PathSeparator == '/' {
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("file permissions = %o, want 0600", info.Mode().Perm())
+		}
+	}
+
+	// No temp files should be left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want 1", len(entries))
+	}
+}
+
+func TestWriteAtomic_MissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "config.json")
+	if err := WriteAtomic(path, []byte("x"), 0600); err == nil {
+		t.Error("WriteAtomic into missing directory should return error")
+	}
+}
+
+func TestLock_Exclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+	if err := os.WriteFile(path, []byte("0"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each goroutine does a locked read-modify-write; without the lock
+	// increments would be lost.
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lock, err := Lock(path)
+			if err != nil {
+				t.Errorf("Lock failed: %v", err)
+				return
+			}
+			defer lock.Unlock()
+
+			data, _ := os.ReadFile(path)
+			n := len(data)
+			if err := WriteAtomic(path, make([]byte, n+1), 0600); err != nil {
+				t.Errorf("WriteAtomic failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(path)
+	if len(data) != workers+1 {
+		t.Errorf("file length = %d, want %d", len(data), workers+1)
+	}
+}
+
+func TestFileLock_UnlockNil(t *testing.T) {
+	var l *FileLock
+	if err := l.Unlock(); err != nil {
+		t.Errorf("Unlock on nil lock = %v, want nil", err)
+	}
+}
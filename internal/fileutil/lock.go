@@ -0,0 +1,62 @@
+package fileutil
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	// lockTimeout is how long Lock waits for another process to release the lock
+	lockTimeout = 10 * time.Second
+	// lockRetryInterval is the delay between lock attempts
+	lockRetryInterval = 20 * time.Millisecond
+)
+
+// ErrLockTimeout is returned when a lock cannot be acquired in time
+var ErrLockTimeout = errors.New("timed out waiting for file lock")
+
+// FileLock is an exclusive advisory lock held on a <path>.lock file
+type FileLock struct {
+	file *os.File
+}
+
+// Lock acquires an exclusive advisory lock for path.
+// The lock is held on a separate <path>.lock file so that path itself can be
+// replaced atomically while locked. Lock waits up to 10 seconds.
+func Lock(path string) (*FileLock, error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if locked {
+			return &FileLock{file: f}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, ErrLockTimeout
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Unlock releases the lock
+func (l *FileLock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlock(l.file)
+	if cerr := l.file.Close(); err == nil {
+		err = cerr
+	}
+	l.file = nil
+	return err
+}
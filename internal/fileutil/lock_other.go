@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package fileutil
+
+import "os"
+
+// tryLock is a no-op on platforms without advisory locking support;
+// writes are still atomic via WriteAtomic.
+func tryLock(f *os.File) (bool, error) {
+	return true, nil
+}
+
+func unlock(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UpstreamRemote is the conventional name of the remote pointing at the
+// parent repository of a fork
+const UpstreamRemote = "upstream"
+
+// RemoteRepo returns the repository the named remote points to
+func RemoteRepo(remote string) (*Repository, error) {
+	if strings.HasPrefix(remote, "-") {
+		return nil, ErrNoRemote
+	}
+	output, err := runGit("remote", "get-url", remote)
+	if err != nil {
+		return nil, ErrNoRemote
+	}
+	return parseRemoteURL(output)
+}
+
+// RemoteURL returns the fetch URL of the named remote
+func RemoteURL(remote string) (string, error) {
+	if strings.HasPrefix(remote, "-") {
+		return "", ErrNoRemote
+	}
+	output, err := runGit("remote", "get-url", remote)
+	if err != nil {
+		return "", ErrNoRemote
+	}
+	return output, nil
+}
+
+// FindRemoteFor returns the name of a remote pointing to owner/name
+func FindRemoteFor(owner, name string) (string, error) {
+	output, err := runGit("remote")
+	if err != nil {
+		return "", errors.New("failed to list remotes: not a git repository")
+	}
+
+	for _, remote := range strings.Fields(output) {
+		repo, err := RemoteRepo(remote)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(repo.Owner, owner) && strings.EqualFold(repo.Name, name) {
+			return remote, nil
+		}
+	}
+	return "", ErrNoRemote
+}
+
+// DetectFork returns the fork (origin) and parent (upstream remote)
+// repositories when the current clone has an upstream remote besides
+// origin. Both are nil if there is no upstream remote, it is not a
+// GitFlic URL or it points to origin itself. Remotes alone do not prove
+// a fork: callers should confirm the relationship with the API.
+func DetectFork() (fork, parent *Repository, err error) {
+	output, err := runGit("remote")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	if !slices.Contains(strings.Fields(output), UpstreamRemote) {
+		return nil, nil, nil
+	}
+
+	url, err := runGit("remote", "get-url", UpstreamRemote)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read remote %s: %w", UpstreamRemote, err)
+	}
+	parent, err = parseRemoteURL(url)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	remote, err := FindGitflicRemote()
+	if errors.Is(err, ErrNoRemote) || remote == UpstreamRemote {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	url, err = runGit("remote", "get-url", remote)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read remote %s: %w", remote, err)
+	}
+	fork, err = parseRemoteURL(url)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	if strings.EqualFold(fork.Owner, parent.Owner) && strings.EqualFold(fork.Name, parent.Name) {
+		return nil, nil, nil
+	}
+	return fork, parent, nil
+}
+
+// IsSSHURL reports whether a remote URL uses SSH transport
+func IsSSHURL(url string) bool {
+	return strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "ssh://")
+}
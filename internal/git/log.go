@@ -0,0 +1,89 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// Commit is a commit in the local repository
+type Commit struct {
+	SHA     string
+	Subject string
+	Body    string
+}
+
+// CommitsBetween returns commits reachable from head but not from base,
+// oldest first (git log base..head)
+func CommitsBetween(base, head string) ([]Commit, error) {
+	if strings.HasPrefix(base, "-") || strings.HasPrefix(head, "-") {
+		return nil, errors.New("invalid ref")
+	}
+
+	// Fields are separated by NUL, commits by the record separator
+	output, err := runGit("log", "--reverse", "--format=%H%x00%s%x00%b%x1e", base+".."+head, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.Trim(record, "\n")
+		if record == "" {
+			continue
+		}
+		parts := strings.SplitN(record, "\x00", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		c := Commit{SHA: parts[0], Subject: parts[1]}
+		if len(parts) == 3 {
+			c.Body = strings.TrimSpace(parts[2])
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// RefExists reports whether ref resolves to a commit
+func RefExists(ref string) bool {
+	if strings.HasPrefix(ref, "-") {
+		return false
+	}
+	return runGitCheck("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+}
+
+// TopLevel returns the root directory of the current working tree
+func TopLevel() (string, error) {
+	output, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", ErrNotGitRepo
+	}
+	return output, nil
+}
+
+// GitDir returns the absolute path of the git directory shared by all
+// worktrees of the current repository (usually <top level>/.git)
+func GitDir() (string, error) {
+	output, err := runGit("rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", ErrNotGitRepo
+	}
+	return filepath.Abs(output)
+}
+
+// ChangedFiles returns the paths changed on head since it diverged from
+// base (git diff --name-only base...head)
+func ChangedFiles(base, head string) ([]string, error) {
+	if strings.HasPrefix(base, "-") || strings.HasPrefix(head, "-") {
+		return nil, errors.New("invalid ref")
+	}
+	output, err := runGit("diff", "--name-only", base+"..."+head, "--")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
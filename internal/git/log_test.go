@@ -0,0 +1,151 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// initTestRepo creates a git repository in a temp dir and changes into it
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	gitT(t, "init", "-q", "-b", "main")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Initial commit")
+	return dir
+}
+
+func gitT(t *testing.T, args ...string) {
+	t.Helper()
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestCommitsBetween(t *testing.T) {
+	initTestRepo(t)
+	gitT(t, "checkout", "-q", "-b", "feature")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Add login", "-m", "Body line 1\nBody line 2")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Fix typo")
+
+	commits, err := CommitsBetween("main", "feature")
+	if err != nil {
+		t.Fatalf("CommitsBetween failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2: %+v", len(commits), commits)
+	}
+	if commits[0].Subject != "Add login" || commits[0].Body != "Body line 1\nBody line 2" {
+		t.Errorf("first commit = %+v", commits[0])
+	}
+	if commits[1].Subject != "Fix typo" || commits[1].Body != "" {
+		t.Errorf("second commit = %+v", commits[1])
+	}
+	if len(commits[0].SHA) != 40 {
+		t.Errorf("SHA = %q, want full hash", commits[0].SHA)
+	}
+
+	if !RefExists("feature") || RefExists("missing") {
+		t.Error("RefExists returned wrong result")
+	}
+	if _, err := CommitsBetween("--all", "feature"); err == nil {
+		t.Error("expected error for option-like ref")
+	}
+}
+
+func TestPush(t *testing.T) {
+	dir := initTestRepo(t)
+
+	// Bare repository acting as the remote
+	remote := t.TempDir()
+	gitT(t, "init", "-q", "--bare", remote)
+	gitT(t, "remote", "add", "origin", remote)
+	gitT(t, "checkout", "-q", "-b", "feature")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Work")
+
+	if RemoteBranchExists("origin", "feature") {
+		t.Fatal("feature should not exist on origin yet")
+	}
+	if !LocalBranchExists("feature") {
+		t.Fatal("LocalBranchExists(feature) = false")
+	}
+
+	if err := Push("origin", "feature", true); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if !RemoteBranchExists("origin", "feature") {
+		t.Error("feature not on origin after push")
+	}
+	if upstream, _ := runGit("rev-parse", "--abbrev-ref", "feature@{upstream}"); upstream != "origin/feature" {
+		t.Errorf("upstream = %q, want origin/feature (repo %s)", upstream, dir)
+	}
+
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "More work")
+	if ahead, err := CommitsAhead("origin/feature", "feature"); err != nil || ahead != 1 {
+		t.Errorf("CommitsAhead = %d, %v, want 1", ahead, err)
+	}
+}
+
+func TestDetectFork(t *testing.T) {
+	initTestRepo(t)
+
+	if fork, _, err := DetectFork(); err != nil || fork != nil {
+		t.Fatalf("DetectFork without remotes = %v, %v, want no fork", fork, err)
+	}
+
+	gitT(t, "remote", "add", "origin", "https://gitflic.ru/project/alice/app.git")
+	if fork, _, err := DetectFork(); err != nil || fork != nil {
+		t.Fatalf("DetectFork without upstream = %v, %v, want no fork", fork, err)
+	}
+
+	gitT(t, "remote", "add", "upstream", "git@gitflic.ru:team/app.git")
+	fork, parent, err := DetectFork()
+	if err != nil || fork == nil {
+		t.Fatalf("DetectFork should detect fork, got %v", err)
+	}
+	if fork.FullName() != "alice/app" || parent.FullName() != "team/app" {
+		t.Errorf("fork = %s, parent = %s, want alice/app and team/app", fork.FullName(), parent.FullName())
+	}
+
+	remote, err := FindRemoteFor("TEAM", "app")
+	if err != nil || remote != "upstream" {
+		t.Errorf("FindRemoteFor(team/app) = %q, %v, want upstream", remote, err)
+	}
+	if _, err := FindRemoteFor("bob", "app"); err == nil {
+		t.Error("FindRemoteFor(bob/app) should fail")
+	}
+}
+
+func TestGitDir(t *testing.T) {
+	dir := initTestRepo(t)
+	os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	os.Chdir(filepath.Join(dir, "sub"))
+
+	got, err := GitDir()
+	if err != nil {
+		t.Fatalf("GitDir failed: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(filepath.Join(dir, ".git"))
+	if got, _ = filepath.EvalSymlinks(got); got != want {
+		t.Errorf("GitDir() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// LocalBranchExists reports whether refs/heads/branch exists
+func LocalBranchExists(branch string) bool {
+	if strings.HasPrefix(branch, "-") {
+		return false
+	}
+	return runGitCheck("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+}
+
+// RemoteBranchExists reports whether the remote-tracking branch
+// refs/remotes/remote/branch exists (as of the last fetch or push)
+func RemoteBranchExists(remote, branch string) bool {
+	if strings.HasPrefix(remote, "-") || strings.HasPrefix(branch, "-") {
+		return false
+	}
+	return runGitCheck("rev-parse", "--verify", "--quiet", "refs/remotes/"+remote+"/"+branch)
+}
+
+// CommitsAhead returns the number of commits on head that are not on base
+func CommitsAhead(base, head string) (int, error) {
+	if strings.HasPrefix(base, "-") || strings.HasPrefix(head, "-") {
+		return 0, errors.New("invalid ref")
+	}
+	output, err := runGit("rev-list", "--count", base+".."+head, "--")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(output)
+}
+
+// Push pushes branch to remote, optionally setting it as upstream.
+// Git output is shown to the user; there is no timeout since pushing
+// may take long or ask for credentials.
+func Push(remote, branch string, setUpstream bool) error {
+	if strings.HasPrefix(remote, "-") || strings.HasPrefix(branch, "-") {
+		return errors.New("invalid remote or branch name")
+	}
+
+	args := []string{"push"}
+	if setUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, remote, "refs/heads/"+branch+":refs/heads/"+branch)
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// mergeSearchDepth limits how far back FindMergeCommit looks
+const mergeSearchDepth = 1000
+
+// ErrMergeNotFound is returned by FindMergeCommit if head was not merged
+// into ref with a merge commit or a fast-forward
+var ErrMergeNotFound = errors.New("merge commit not found")
+
+// FindMergeCommit returns the commit on the first-parent history of ref
+// that brought in head. merge is true for a merge commit (head is one of
+// its other parents) and false if head itself is on that history, as
+// after a fast-forward.
+func FindMergeCommit(ref, head string) (sha string, merge bool, err error) {
+	if strings.HasPrefix(ref, "-") || strings.HasPrefix(head, "-") {
+		return "", false, errors.New("invalid ref")
+	}
+	output, err := runGit("log", "--first-parent", "--format=%H %P",
+		fmt.Sprintf("--max-count=%d", mergeSearchDepth), ref, "--")
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], head) {
+			return fields[0], false, nil
+		}
+		// fields[1] is the first parent
+		for _, parent := range fields[min(2, len(fields)):] {
+			if strings.HasPrefix(parent, head) {
+				return fields[0], true, nil
+			}
+		}
+	}
+	return "", false, ErrMergeNotFound
+}
+
+// Revert applies the reverse of commits, in the given order, to the
+// working tree and the index without committing. Merge commits are
+// reverted relative to their first parent. On conflicts the revert is
+// left in progress for the user to resolve.
+func Revert(commits []string, merge bool) error {
+	args := []string{"revert", "--no-commit"}
+	if merge {
+		args = append(args, "--mainline", "1")
+	}
+	for _, c := range commits {
+		if strings.HasPrefix(c, "-") {
+			return errors.New("invalid commit")
+		}
+	}
+	args = append(args, commits...)
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// CommitStaged commits the staged changes with message. There is no
+// timeout since commit hooks and signing may take long.
+func CommitStaged(message string) error {
+	cmd := exec.Command("git", "commit", "--quiet", "--file=-")
+	cmd.Stdin = strings.NewReader(message)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+	return nil
+}
+
+// CommitParents returns the parent hashes of commit
+func CommitParents(commit string) ([]string, error) {
+	if strings.HasPrefix(commit, "-") {
+		return nil, errors.New("invalid commit")
+	}
+	output, err := runGit("rev-list", "--parents", "--max-count=1", commit, "--")
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("unknown commit %s", commit)
+	}
+	return fields[1:], nil
+}
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestFindMergeCommitAndRevert(t *testing.T) {
+	initTestRepo(t)
+	gitT(t, "checkout", "-q", "-b", "feature")
+	if err := os.WriteFile("login.txt", []byte("login\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitT(t, "add", "login.txt")
+	gitT(t, "commit", "-q", "-m", "Add login")
+	head, _ := RefSHA("feature")
+
+	gitT(t, "checkout", "-q", "main")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Unrelated")
+	gitT(t, "merge", "-q", "--no-ff", "-m", "Merge feature", "feature")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "Later")
+	mergeSHA, _ := RefSHA("main~1")
+
+	sha, merge, err := FindMergeCommit("main", head)
+	if err != nil || sha != mergeSHA || !merge {
+		t.Fatalf("FindMergeCommit = %s, %v, %v, want merge commit %s", sha, merge, err, mergeSHA)
+	}
+	if parents, err := CommitParents(sha); err != nil || len(parents) != 2 || parents[1] != head {
+		t.Errorf("CommitParents = %v, %v", parents, err)
+	}
+
+	if sha, merge, err := FindMergeCommit("main", head[:12]); err != nil || sha != mergeSHA || !merge {
+		t.Errorf("FindMergeCommit(short hash) = %s, %v, %v", sha, merge, err)
+	}
+
+	// Commits on the first-parent history, as after a fast-forward
+	unrelated, _ := RefSHA("main~2")
+	if sha, merge, err := FindMergeCommit("main", unrelated); err != nil || merge || sha != unrelated {
+		t.Errorf("FindMergeCommit(first-parent commit) = %s, %v, %v", sha, merge, err)
+	}
+	if _, _, err := FindMergeCommit("main", "0000000000"); !errors.Is(err, ErrMergeNotFound) {
+		t.Errorf("FindMergeCommit(unknown) error = %v, want ErrMergeNotFound", err)
+	}
+
+	if err := Revert([]string{mergeSHA}, true); err != nil {
+		t.Fatalf("Revert failed: %v", err)
+	}
+	if err := CommitStaged("Revert feature"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat("login.txt"); !os.IsNotExist(err) {
+		t.Errorf("login.txt still exists after revert: %v", err)
+	}
+	if dirty, _ := HasUncommittedChanges(); dirty {
+		t.Error("working tree dirty after CommitStaged")
+	}
+}
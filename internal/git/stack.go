@@ -0,0 +1,155 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// stackParentKey is the per-branch git config key that records the
+// branch a stacked branch is based on (branch.<name>.gf-parent)
+const stackParentKey = "gf-parent"
+
+// StackParents returns the recorded parent of every stacked branch,
+// keyed by branch name
+func StackParents() (map[string]string, error) {
+	parents := make(map[string]string)
+	output, err := runGit("config", "--get-regexp", `^branch\..*\.`+stackParentKey+`$`)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			// No stacked branches
+			return parents, nil
+		}
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		key, parent, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		branch := strings.TrimSuffix(strings.TrimPrefix(key, "branch."), "."+stackParentKey)
+		if branch != "" && parent != "" {
+			parents[branch] = parent
+		}
+	}
+	return parents, nil
+}
+
+// SetStackParent records parent as the branch that branch is stacked on
+func SetStackParent(branch, parent string) error {
+	if strings.HasPrefix(branch, "-") || strings.HasPrefix(parent, "-") {
+		return errors.New("invalid branch name")
+	}
+	if _, err := runGit("config", "branch."+branch+"."+stackParentKey, parent); err != nil {
+		return fmt.Errorf("failed to write git config: %w", err)
+	}
+	return nil
+}
+
+// UnsetStackParent removes branch from its stack. It is not an error if
+// branch was not stacked.
+func UnsetStackParent(branch string) error {
+	if strings.HasPrefix(branch, "-") {
+		return errors.New("invalid branch name")
+	}
+	_, err := runGit("config", "--unset", "branch."+branch+"."+stackParentKey)
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 5) {
+		return fmt.Errorf("failed to write git config: %w", err)
+	}
+	return nil
+}
+
+// RefSHA returns the commit hash ref points to
+func RefSHA(ref string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", errors.New("invalid ref")
+	}
+	return runGit("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+}
+
+// IsAncestor reports whether commit ancestor is reachable from ref
+func IsAncestor(ancestor, ref string) bool {
+	if strings.HasPrefix(ancestor, "-") || strings.HasPrefix(ref, "-") {
+		return false
+	}
+	return runGitCheck("merge-base", "--is-ancestor", ancestor, ref)
+}
+
+// ForkPoint returns the commit where branch forked from ref, taking the
+// reflog of ref into account so that it is found even after ref was
+// rebased (git merge-base --fork-point)
+func ForkPoint(ref, branch string) (string, error) {
+	if strings.HasPrefix(ref, "-") || strings.HasPrefix(branch, "-") {
+		return "", errors.New("invalid ref")
+	}
+	return runGit("merge-base", "--fork-point", ref, branch)
+}
+
+// HasUncommittedChanges reports whether tracked files have staged or
+// unstaged changes
+func HasUncommittedChanges() (bool, error) {
+	output, err := runGit("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+	return output != "", nil
+}
+
+// Fetch fetches remote, updating its remote-tracking branches
+func Fetch(remote string) error {
+	if strings.HasPrefix(remote, "-") {
+		return errors.New("invalid remote name")
+	}
+	return runGitInteractive("fetch", "--quiet", remote)
+}
+
+// CreateBranch creates branch at start and switches to it
+func CreateBranch(branch, start string) error {
+	if strings.HasPrefix(branch, "-") || strings.HasPrefix(start, "-") {
+		return errors.New("invalid branch name")
+	}
+	return runGitInteractive("checkout", "--quiet", "-b", branch, start)
+}
+
+// Checkout switches to branch
+func Checkout(branch string) error {
+	if strings.HasPrefix(branch, "-") {
+		return errors.New("invalid branch name")
+	}
+	return runGitInteractive("checkout", "--quiet", branch, "--")
+}
+
+// RebaseOnto replays the commits of branch that are not on upstream onto
+// onto (git rebase --onto onto upstream branch). On conflicts the rebase
+// is left in progress for the user to resolve.
+func RebaseOnto(onto, upstream, branch string) error {
+	if strings.HasPrefix(onto, "-") || strings.HasPrefix(upstream, "-") || strings.HasPrefix(branch, "-") {
+		return errors.New("invalid ref")
+	}
+	return runGitInteractive("rebase", "--quiet", "--onto", onto, upstream, branch)
+}
+
+// ForcePush pushes branch to remote, replacing the remote branch only if
+// it still is where the remote-tracking branch says (--force-with-lease)
+func ForcePush(remote, branch string) error {
+	if strings.HasPrefix(remote, "-") || strings.HasPrefix(branch, "-") {
+		return errors.New("invalid remote or branch name")
+	}
+	return runGitInteractive("push", "--force-with-lease", "--set-upstream", remote,
+		"refs/heads/"+branch+":refs/heads/"+branch)
+}
+
+// runGitInteractive runs a git command that may take long or ask for
+// credentials: git output is shown to the user and there is no timeout
+func runGitInteractive(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStackParents(t *testing.T) {
+	initTestRepo(t)
+
+	parents, err := StackParents()
+	if err != nil || len(parents) != 0 {
+		t.Fatalf("StackParents() = %v, %v, want none", parents, err)
+	}
+
+	if err := SetStackParent("feature/api", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetStackParent("feature/ui", "feature/api"); err != nil {
+		t.Fatal(err)
+	}
+	parents, err = StackParents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"feature/api": "main", "feature/ui": "feature/api"}
+	if !reflect.DeepEqual(parents, want) {
+		t.Errorf("StackParents() = %v, want %v", parents, want)
+	}
+
+	if err := UnsetStackParent("feature/api"); err != nil {
+		t.Fatal(err)
+	}
+	if err := UnsetStackParent("feature/api"); err != nil {
+		t.Errorf("UnsetStackParent of an untracked branch: %v", err)
+	}
+	if parents, _ = StackParents(); !reflect.DeepEqual(parents, map[string]string{"feature/ui": "feature/api"}) {
+		t.Errorf("after unset: %v", parents)
+	}
+
+	if err := SetStackParent("--global", "main"); err == nil {
+		t.Error("expected error for option-like branch")
+	}
+}
+
+func TestRebaseOntoForkPoint(t *testing.T) {
+	initTestRepo(t)
+	gitT(t, "checkout", "-q", "-b", "a")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "A1")
+	gitT(t, "checkout", "-q", "-b", "b")
+	gitT(t, "commit", "-q", "--allow-empty", "-m", "B1")
+
+	// a is amended after b was stacked on it
+	gitT(t, "checkout", "-q", "a")
+	gitT(t, "commit", "-q", "--allow-empty", "--amend", "-m", "A1 amended")
+
+	fork, err := ForkPoint("a", "b")
+	if err != nil {
+		t.Fatalf("ForkPoint failed: %v", err)
+	}
+	if IsAncestor("a", "b") {
+		t.Fatal("b should not contain the amended a yet")
+	}
+	if err := RebaseOnto("a", fork, "b"); err != nil {
+		t.Fatalf("RebaseOnto failed: %v", err)
+	}
+	commits, err := CommitsBetween("main", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var subjects []string
+	for _, c := range commits {
+		subjects = append(subjects, c.Subject)
+	}
+	if !reflect.DeepEqual(subjects, []string{"A1 amended", "B1"}) {
+		t.Errorf("b after rebase = %v", subjects)
+	}
+	if !IsAncestor("a", "b") {
+		t.Error("IsAncestor(a, b) = false after rebase")
+	}
+
+	dirty, err := HasUncommittedChanges()
+	if err != nil || dirty {
+		t.Errorf("HasUncommittedChanges() = %v, %v", dirty, err)
+	}
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// HeadSHA returns the commit hash of HEAD
+func HeadSHA() (string, error) {
+	output, err := runGit("rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("could not resolve HEAD: %w", err)
+	}
+	return output, nil
+}
+
+// ModifiedFiles returns the paths among paths that have uncommitted
+// changes (staged, unstaged or untracked)
+func ModifiedFiles(paths ...string) ([]string, error) {
+	args := append([]string{"status", "--porcelain", "--untracked-files=all", "--"}, paths...)
+	output, err := runGit(args...)
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		// "XY path"; the leading space of the first line was trimmed
+		if _, path, ok := strings.Cut(strings.TrimLeft(line, " "), " "); ok {
+			files = append(files, strings.TrimLeft(path, " "))
+		}
+	}
+	return files, nil
+}
+
+// CommitFiles stages paths and commits them with message. Other staged
+// changes are not included in the commit. There is no timeout since
+// commit hooks and signing may take long.
+func CommitFiles(message string, paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("nothing to commit")
+	}
+	if _, err := runGit(append([]string{"add", "--"}, paths...)...); err != nil {
+		return fmt.Errorf("git add failed: %w", err)
+	}
+
+	cmd := exec.Command("git", append([]string{"commit", "--quiet", "--file=-", "--"}, paths...)...)
+	cmd.Stdin = strings.NewReader(message)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+	return nil
+}
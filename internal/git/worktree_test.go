@@ -0,0 +1,39 @@
+package git
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestModifiedFilesAndCommitFiles(t *testing.T) {
+	initTestRepo(t)
+	os.WriteFile("a.txt", []byte("a\n"), 0644)
+	os.WriteFile("b.txt", []byte("b\n"), 0644)
+	gitT(t, "add", ".")
+	gitT(t, "commit", "-q", "-m", "Add files")
+
+	files, err := ModifiedFiles("a.txt", "b.txt")
+	if err != nil || len(files) != 0 {
+		t.Fatalf("ModifiedFiles() = %v, %v; want clean", files, err)
+	}
+
+	os.WriteFile("a.txt", []byte("changed\n"), 0644)
+	os.WriteFile("b.txt", []byte("changed\n"), 0644)
+	files, err = ModifiedFiles("a.txt")
+	if err != nil || !reflect.DeepEqual(files, []string{"a.txt"}) {
+		t.Fatalf("ModifiedFiles(a.txt) = %v, %v", files, err)
+	}
+
+	if err := CommitFiles("Change a\n", []string{"a.txt"}); err != nil {
+		t.Fatalf("CommitFiles failed: %v", err)
+	}
+	files, _ = ModifiedFiles()
+	if !reflect.DeepEqual(files, []string{"b.txt"}) {
+		t.Errorf("after commit, modified = %v, want only b.txt", files)
+	}
+	commits, err := CommitsBetween("HEAD~1", "HEAD")
+	if err != nil || len(commits) != 1 || commits[0].Subject != "Change a" {
+		t.Errorf("last commit = %+v, %v", commits, err)
+	}
+}
@@ -0,0 +1,93 @@
+package prompt
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match scores text against a fuzzy query. Every whitespace-separated
+// term of the query must appear in text as a subsequence, ignoring case.
+// Higher scores are better matches; ok is false if a term does not match.
+func Match(query, text string) (score int, ok bool) {
+	hay := []rune(strings.ToLower(text))
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		s, ok := matchTerm([]rune(term), hay)
+		if !ok {
+			return 0, false
+		}
+		score += s
+	}
+	return score, true
+}
+
+// matchTerm returns the best score of term as a subsequence of hay,
+// trying every start position of the first rune
+func matchTerm(term, hay []rune) (int, bool) {
+	best, found := 0, false
+	for start := range hay {
+		if hay[start] != term[0] {
+			continue
+		}
+		score, ok := scoreFrom(term, hay, start)
+		if ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// scoreFrom greedily matches term in hay starting at start. Matches at
+// word starts and runs of consecutive runes score higher; gaps cost.
+func scoreFrom(term, hay []rune, start int) (int, bool) {
+	score, ti, last := 0, 0, -1
+	for i := start; i < len(hay) && ti < len(term); i++ {
+		if hay[i] != term[ti] {
+			continue
+		}
+		score++
+		switch {
+		case i == 0 || !isWordRune(hay[i-1]):
+			score += 8
+		case last == i-1:
+			score += 8
+		}
+		if last >= 0 {
+			score -= min(2*(i-last-1), 10)
+		}
+		last = i
+		ti++
+	}
+	if ti < len(term) {
+		return 0, false
+	}
+	// Earlier matches are slightly better
+	return score*4 - min(start, 3), true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Filter returns the indexes of items whose labels match query, best
+// matches first. An empty query keeps all items in their order.
+func Filter(items []Item, query string) []int {
+	type scored struct {
+		index, score int
+	}
+	var matches []scored
+	for i, item := range items {
+		if score, ok := Match(query, item.Label); ok {
+			matches = append(matches, scored{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].score > matches[b].score
+	})
+
+	result := make([]int, len(matches))
+	for i, m := range matches {
+		result[i] = m.index
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+// Package prompt implements interactive terminal prompts.
+//
+// Prompts are only shown when stdin and stderr are terminals. They are
+// disabled by the --no-prompt flag (see Disable) and the
+// GF_PROMPT_DISABLED environment variable.
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/josinSbazin/gf/internal/api"
+	"golang.org/x/term"
+)
+
+// ErrCancelled is returned when the user cancels a prompt with Esc or Ctrl+C
+var ErrCancelled = errors.New("cancelled")
+
+// ErrDisabled is returned when a prompt is needed but prompts are disabled
+var ErrDisabled = errors.New("prompts are disabled")
+
+var disabled bool
+
+// Disable turns off prompts for the rest of the process (--no-prompt)
+func Disable() {
+	disabled = true
+}
+
+// Enabled reports whether interactive prompts may be shown
+func Enabled() bool {
+	if disabled || os.Getenv("GF_PROMPT_DISABLED") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// Item is an entry of a selection list
+type Item struct {
+	// Label is shown in the list and matched by the filter
+	Label string
+	// Preview is shown below the list while the item is highlighted
+	Preview string
+}
+
+// Select shows a list of items with type-to-filter and a preview pane and
+// returns the index of the chosen item. The prompt is drawn on stderr.
+func Select(title string, items []Item) (int, error) {
+	if len(items) == 0 {
+		return -1, errors.New("nothing to select")
+	}
+	if !Enabled() {
+		return -1, ErrDisabled
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return -1, fmt.Errorf("failed to read from terminal: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	width := 80
+	if w, _, err := term.GetSize(int(os.Stderr.Fd())); err == nil && w > 0 {
+		width = w
+	}
+
+	s := newSelector(title, items)
+	v := &view{w: os.Stderr, width: width, color: !api.NoColor()}
+	in := bufio.NewReader(os.Stdin)
+
+	fmt.Fprint(os.Stderr, hideCursor)
+	defer fmt.Fprint(os.Stderr, showCursor)
+
+	for {
+		v.draw(s.render(v.width, v.color))
+		k, err := readKey(in)
+		if err != nil {
+			v.clear()
+			return -1, err
+		}
+		switch s.handle(k) {
+		case resultSelected:
+			v.clear()
+			fmt.Fprintf(os.Stderr, "? %s %s\r\n", title, items[s.selected()].Label)
+			return s.selected(), nil
+		case resultCancelled:
+			v.clear()
+			return -1, ErrCancelled
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query, text string
+		ok          bool
+	}{
+		{"", "anything", true},
+		{"fix", "Fix login", true},
+		{"flg", "Fix login", true},
+		{"login fix", "Fix login", true},
+		{"#12", "#12 Add feature", true},
+		{"xyz", "Fix login", false},
+		{"fix logout", "Fix login", false},
+		{"ёж", "Ёжик", true},
+	}
+	for _, tt := range tests {
+		if _, ok := Match(tt.query, tt.text); ok != tt.ok {
+			t.Errorf("Match(%q, %q) ok = %v, want %v", tt.query, tt.text, ok, tt.ok)
+		}
+	}
+
+	// Word starts and consecutive runs beat scattered matches
+	word, _ := Match("log", "Fix login")
+	scattered, _ := Match("log", "blue flag")
+	if word <= scattered {
+		t.Errorf("Match(log): word start %d <= scattered %d", word, scattered)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := []Item{
+		{Label: "#3 Update docs"},
+		{Label: "#2 Fix typo in login page"},
+		{Label: "#1 Login form"},
+		{Label: "#0 Log in again"},
+	}
+	if got := Filter(items, ""); !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Errorf("Filter(\"\") = %v", got)
+	}
+	// Ties keep the original order; gaps rank lower
+	if got := Filter(items, "login"); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Filter(login) = %v, want [1 2 3]", got)
+	}
+	if got := Filter(items, "nothing"); len(got) != 0 {
+		t.Errorf("Filter(nothing) = %v, want none", got)
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []key
+	}{
+		{"a", []key{{code: keyRune, r: 'a'}}},
+		{"ж", []key{{code: keyRune, r: 'ж'}}},
+		{"\r", []key{{code: keyEnter}}},
+		{"\x1b[A\x1b[B", []key{{code: keyUp}, {code: keyDown}}},
+		{"\x1bOA", []key{{code: keyUp}}},
+		{"\x1b[5~\x1b[6~", []key{{code: keyPageUp}, {code: keyPageDown}}},
+		{"\x1b[1;5A", []key{{code: keyUp}}},
+		{"\x7f\x03", []key{{code: keyBackspace}, {code: keyCancel}}},
+		{"\x1b", []key{{code: keyCancel}}},
+		{"\x10\x0e", []key{{code: keyUp}, {code: keyDown}}},
+	}
+	for _, tt := range tests {
+		in := bufio.NewReader(strings.NewReader(tt.input))
+		var got []key
+		for range tt.want {
+			k, err := readKey(in)
+			if err != nil {
+				t.Fatalf("readKey(%q): %v", tt.input, err)
+			}
+			got = append(got, k)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("readKey(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSelector(t *testing.T) {
+	items := make([]Item, 15)
+	for i := range items {
+		items[i] = Item{Label: "item " + string(rune('a'+i)), Preview: "preview " + string(rune('a'+i))}
+	}
+	items[14].Label = "special"
+
+	s := newSelector("Pick", items)
+	if s.handle(key{code: keyUp}); s.selected() != 0 {
+		t.Errorf("up at top: selected %d, want 0", s.selected())
+	}
+	for range 12 {
+		s.handle(key{code: keyDown})
+	}
+	if s.selected() != 12 || s.offset != 3 {
+		t.Errorf("after 12 downs: selected %d offset %d, want 12 and 3", s.selected(), s.offset)
+	}
+	s.handle(key{code: keyEnd})
+	if s.selected() != 14 {
+		t.Errorf("end: selected %d, want 14", s.selected())
+	}
+
+	for _, r := range "spc" {
+		s.handle(key{code: keyRune, r: r})
+	}
+	if s.selected() != 14 || len(s.matches) != 1 {
+		t.Errorf("filter spc: selected %d of %d matches", s.selected(), len(s.matches))
+	}
+	s.handle(key{code: keyRune, r: 'z'})
+	if s.handle(key{code: keyEnter}) != resultNone {
+		t.Error("enter without matches should not select")
+	}
+	s.handle(key{code: keyBackspace})
+	if got := s.handle(key{code: keyEnter}); got != resultSelected || s.selected() != 14 {
+		t.Errorf("enter: result %v selected %d", got, s.selected())
+	}
+
+	s.handle(key{code: keyRune, r: ' '})
+	s.handle(key{code: keyRune, r: 'x'})
+	if s.handle(key{code: keyDeleteWord}); string(s.query) != "spc " {
+		t.Errorf("delete word: query %q, want %q", string(s.query), "spc ")
+	}
+	if s.handle(key{code: keyClear}); len(s.query) != 0 || len(s.matches) != 15 {
+		t.Errorf("clear: query %q, %d matches", string(s.query), len(s.matches))
+	}
+	if s.handle(key{code: keyCancel}) != resultCancelled {
+		t.Error("cancel key should cancel")
+	}
+}
+
+func TestSelectorRender(t *testing.T) {
+	items := []Item{
+		{Label: "#1 First", Preview: "line 1\nline 2"},
+		{Label: "#2 Second with a rather long title that does not fit"},
+	}
+	s := newSelector("Select a merge request", items)
+	lines := s.render(40, false)
+
+	want := []string{
+		"? Select a merge request [type to filt…",
+		"> #1 First",
+		"  #2 Second with a rather long title t…",
+		"  ─────────────────────────────────────",
+		"  line 1",
+		"  line 2",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("render() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	var buf bytes.Buffer
+	v := &view{w: &buf, width: 40}
+	v.draw(lines)
+	v.draw(lines[:2])
+	if !strings.Contains(buf.String(), "\x1b[5A\x1b[J") {
+		t.Errorf("redraw did not move up over the previous %d lines: %q", len(lines), buf.String())
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("GF_PROMPT_DISABLED", "1")
+	if Enabled() {
+		t.Error("Enabled() with GF_PROMPT_DISABLED set = true")
+	}
+	if _, err := Select("Pick", []Item{{Label: "a"}}); err != ErrDisabled {
+		t.Errorf("Select() error = %v, want ErrDisabled", err)
+	}
+}
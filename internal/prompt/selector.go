@@ -0,0 +1,318 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxVisible is the number of list rows shown at once
+	maxVisible = 10
+	// maxPreview is the number of preview lines shown
+	maxPreview = 8
+
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+	colorCyan  = "\x1b[36m"
+	colorDim   = "\x1b[2m"
+	colorBold  = "\x1b[1m"
+	colorReset = "\x1b[0m"
+)
+
+type keyCode int
+
+const (
+	keyNone keyCode = iota
+	keyRune
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyBackspace
+	keyDeleteWord
+	keyClear
+	keyCancel
+)
+
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// readKey reads one key press from a terminal in raw mode
+func readKey(in *bufio.Reader) (key, error) {
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+	switch r {
+	case '\r', '\n':
+		return key{code: keyEnter}, nil
+	case 3, 4: // Ctrl+C, Ctrl+D
+		return key{code: keyCancel}, nil
+	case 127, 8: // Backspace, Ctrl+H
+		return key{code: keyBackspace}, nil
+	case 16: // Ctrl+P
+		return key{code: keyUp}, nil
+	case 14: // Ctrl+N
+		return key{code: keyDown}, nil
+	case 21: // Ctrl+U
+		return key{code: keyClear}, nil
+	case 23: // Ctrl+W
+		return key{code: keyDeleteWord}, nil
+	case 27:
+		return readEscape(in)
+	}
+	if unicode.IsPrint(r) {
+		return key{code: keyRune, r: r}, nil
+	}
+	return key{code: keyNone}, nil
+}
+
+// readEscape reads the rest of an escape sequence. A lone Esc (nothing
+// else buffered) cancels.
+func readEscape(in *bufio.Reader) (key, error) {
+	if in.Buffered() == 0 {
+		return key{code: keyCancel}, nil
+	}
+	b, err := in.ReadByte()
+	if err != nil {
+		return key{}, err
+	}
+	if b != '[' && b != 'O' {
+		return key{code: keyNone}, nil
+	}
+
+	// CSI: optional numeric parameters, then a final byte
+	var params []byte
+	for {
+		c, err := in.ReadByte()
+		if err != nil {
+			return key{}, err
+		}
+		if c >= '0' && c <= '9' || c == ';' {
+			params = append(params, c)
+			continue
+		}
+		switch {
+		case c == 'A':
+			return key{code: keyUp}, nil
+		case c == 'B':
+			return key{code: keyDown}, nil
+		case c == 'H':
+			return key{code: keyHome}, nil
+		case c == 'F':
+			return key{code: keyEnd}, nil
+		case c == '~' && string(params) == "5":
+			return key{code: keyPageUp}, nil
+		case c == '~' && string(params) == "6":
+			return key{code: keyPageDown}, nil
+		case c == '~' && (string(params) == "1" || string(params) == "7"):
+			return key{code: keyHome}, nil
+		case c == '~' && (string(params) == "4" || string(params) == "8"):
+			return key{code: keyEnd}, nil
+		}
+		return key{code: keyNone}, nil
+	}
+}
+
+type result int
+
+const (
+	resultNone result = iota
+	resultSelected
+	resultCancelled
+)
+
+// selector is the state of a Select prompt, independent of the terminal
+type selector struct {
+	title   string
+	items   []Item
+	query   []rune
+	matches []int
+	cursor  int // index into matches
+	offset  int // first visible row
+}
+
+func newSelector(title string, items []Item) *selector {
+	s := &selector{title: title, items: items}
+	s.filter()
+	return s
+}
+
+func (s *selector) filter() {
+	s.matches = Filter(s.items, string(s.query))
+	s.cursor, s.offset = 0, 0
+}
+
+// selected returns the item index under the cursor, or -1
+func (s *selector) selected() int {
+	if len(s.matches) == 0 {
+		return -1
+	}
+	return s.matches[s.cursor]
+}
+
+func (s *selector) move(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.cursor = max(0, min(len(s.matches)-1, s.cursor+delta))
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+maxVisible {
+		s.offset = s.cursor - maxVisible + 1
+	}
+}
+
+// handle applies a key press
+func (s *selector) handle(k key) result {
+	switch k.code {
+	case keyEnter:
+		if len(s.matches) > 0 {
+			return resultSelected
+		}
+	case keyCancel:
+		return resultCancelled
+	case keyUp:
+		s.move(-1)
+	case keyDown:
+		s.move(1)
+	case keyPageUp:
+		s.move(-maxVisible)
+	case keyPageDown:
+		s.move(maxVisible)
+	case keyHome:
+		s.move(-len(s.matches))
+	case keyEnd:
+		s.move(len(s.matches))
+	case keyRune:
+		s.query = append(s.query, k.r)
+		s.filter()
+	case keyBackspace:
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			s.filter()
+		}
+	case keyDeleteWord:
+		q := strings.TrimRightFunc(string(s.query), unicode.IsSpace)
+		if i := strings.LastIndexFunc(q, unicode.IsSpace); i >= 0 {
+			q = q[:i+1]
+		} else {
+			q = ""
+		}
+		s.query = []rune(q)
+		s.filter()
+	case keyClear:
+		s.query = nil
+		s.filter()
+	}
+	return resultNone
+}
+
+// render returns the lines of the prompt, each at most width-1 columns
+func (s *selector) render(width int, color bool) []string {
+	paint := func(code, text string) string {
+		if !color {
+			return text
+		}
+		return code + text + colorReset
+	}
+	fit := func(text string) string {
+		return truncate(text, width-1)
+	}
+
+	var lines []string
+	header := fit("? " + s.title + " " + string(s.query))
+	if room := width - 1 - len([]rune(header)); len(s.query) == 0 && room > 10 {
+		header += paint(colorDim, truncate("[type to filter, ↑↓ to move, enter to select, esc to cancel]", room))
+	}
+	lines = append(lines, header)
+
+	if len(s.matches) == 0 {
+		lines = append(lines, paint(colorDim, fit("  no matches")))
+	}
+	end := min(len(s.matches), s.offset+maxVisible)
+	for i := s.offset; i < end; i++ {
+		label := s.items[s.matches[i]].Label
+		if i == s.cursor {
+			lines = append(lines, paint(colorCyan+colorBold, fit("> "+label)))
+		} else {
+			lines = append(lines, fit("  "+label))
+		}
+	}
+	if len(s.matches) > maxVisible || len(s.query) > 0 {
+		lines = append(lines, paint(colorDim, fit(fmt.Sprintf("  %d/%d", len(s.matches), len(s.items)))))
+	}
+
+	if i := s.selected(); i >= 0 && s.items[i].Preview != "" {
+		lines = append(lines, paint(colorDim, fit("  "+strings.Repeat("─", max(0, min(width-3, 60))))))
+		preview := strings.Split(strings.TrimRight(s.items[i].Preview, "\n"), "\n")
+		for j, line := range preview {
+			if j == maxPreview {
+				lines = append(lines, paint(colorDim, fit("  …")))
+				break
+			}
+			lines = append(lines, fit("  "+strings.ReplaceAll(line, "\t", "    ")))
+		}
+	}
+	return lines
+}
+
+// truncate cuts text to n runes, marking the cut with "…"
+func truncate(text string, n int) string {
+	r := []rune(text)
+	if len(r) <= n {
+		return text
+	}
+	if n <= 1 {
+		return string(r[:max(0, n)])
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// view redraws the prompt in place on a terminal in raw mode
+type view struct {
+	w     io.Writer
+	width int
+	color bool
+	lines int // lines drawn last time
+}
+
+// draw replaces the previously drawn lines
+func (v *view) draw(lines []string) {
+	var b strings.Builder
+	v.rewind(&b)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+	}
+	v.lines = len(lines)
+	io.WriteString(v.w, b.String())
+}
+
+// clear erases the previously drawn lines
+func (v *view) clear() {
+	var b strings.Builder
+	v.rewind(&b)
+	v.lines = 0
+	io.WriteString(v.w, b.String())
+}
+
+// rewind moves to the first drawn line and erases everything below
+func (v *view) rewind(b *strings.Builder) {
+	b.WriteString("\r")
+	if v.lines > 1 {
+		fmt.Fprintf(b, "\x1b[%dA", v.lines-1)
+	}
+	b.WriteString("\x1b[J")
+}